@@ -19,9 +19,13 @@ package clickhouse
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
 	"github.com/pkg/errors"
 )
 
@@ -56,3 +60,68 @@ func extractNormalizedInsertQueryAndColumns(query string) (normalizedQuery strin
 
 	return
 }
+
+var backQuoteReplacer = strings.NewReplacer(`\`, `\\`, "`", "\\`")
+
+// insertQuery builds the INSERT of a batch that writes only the given columns
+func insertQuery(table string, columns []string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, name := range columns {
+		quoted = append(quoted, "`"+backQuoteReplacer.Replace(name)+"`")
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) FORMAT Native", table, strings.Join(quoted, ", "))
+}
+
+// defaultedColumns returns the stored columns of the table an insert of the given columns doesn't write
+func defaultedColumns(tableColumns []driver.TableColumn, columns []string) []string {
+	written := make(map[string]struct{}, len(columns))
+	for _, name := range columns {
+		written[name] = struct{}{}
+	}
+	var defaulted []string
+	for _, c := range tableColumns {
+		if _, found := written[c.Name]; found || c.DefaultKind == proto.ColumnAlias || c.DefaultKind == proto.ColumnEphemeral {
+			continue
+		}
+		defaulted = append(defaulted, c.Name)
+	}
+	return defaulted
+}
+
+func withoutColumns(columns, omitted []string) []string {
+	remaining := make([]string, 0, len(columns))
+	for _, name := range columns {
+		if !slices.Contains(omitted, name) {
+			remaining = append(remaining, name)
+		}
+	}
+	return remaining
+}
+
+// omittedColumns returns the columns a struct has no field for, when every one of them has a default expression.
+// Leaving them out of the INSERT lets the server compute their defaults, like input_format_defaults_for_omitted_fields.
+func omittedColumns(m *structMap, tableColumns []driver.TableColumn, columns []string, v any) []string {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	var (
+		index    = m.index(t.Elem())
+		defaults = make(map[string]bool, len(tableColumns))
+		omitted  []string
+	)
+	for _, c := range tableColumns {
+		defaults[c.Name] = c.HasDefault()
+	}
+	for _, name := range columns {
+		if _, found := index[name]; found {
+			continue
+		}
+		if !defaults[name] {
+			// the usual missing field error is more helpful than a partial omission
+			return nil
+		}
+		omitted = append(omitted, name)
+	}
+	return omitted
+}
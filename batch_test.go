@@ -19,8 +19,13 @@ package clickhouse
 
 import (
 	"testing"
+	"time"
 
+	chproto "github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExtractNormalizedInsertQueryAndColumns(t *testing.T) {
@@ -218,3 +223,69 @@ func TestExtractNormalizedInsertQueryAndColumns(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTableColumns(t *testing.T) {
+	columns, err := proto.ParseTableColumns("columns format version: 1\n" +
+		"5 columns:\n" +
+		"`id` UInt64\n" +
+		"`created` DateTime('UTC')\tDEFAULT\tnow()\n" +
+		"`day` Date\tMATERIALIZED\ttoDate(created)\n" +
+		"`name\\`s` String\tALIAS\tconcat(\\'a\\tb\\', toString(id))\tCOMMENT \\'the name\\'\n" +
+		"`raw` String\tEPHEMERAL\t\\'\\'\tCODEC(ZSTD(1))\n")
+	require.NoError(t, err)
+	assert.Equal(t, []proto.TableColumn{
+		{Name: "id", Type: "UInt64"},
+		{Name: "created", Type: "DateTime('UTC')", DefaultKind: proto.ColumnDefault, DefaultExpression: "now()"},
+		{Name: "day", Type: "Date", DefaultKind: proto.ColumnMaterialized, DefaultExpression: "toDate(created)"},
+		{Name: "name`s", Type: "String", DefaultKind: proto.ColumnAlias, DefaultExpression: "concat('a\tb', toString(id))"},
+		{Name: "raw", Type: "String", DefaultKind: proto.ColumnEphemeral, DefaultExpression: "''"},
+	}, columns)
+
+	_, err = proto.ParseTableColumns("columns format version: 1\n2 columns:\n`id` UInt64\n")
+	assert.Error(t, err)
+
+	// a description that can't be parsed doesn't fail the packet, it leaves Columns empty
+	var buffer chproto.Buffer
+	buffer.PutString("")
+	buffer.PutString("columns format version: 2\n")
+	var info proto.TableColumns
+	require.NoError(t, info.Decode(chproto.NewReader(buffer.Reader()), 0))
+	assert.Error(t, info.ParseColumns())
+	assert.Empty(t, info.Columns)
+}
+
+func TestBatchColumnDefaults(t *testing.T) {
+	tableColumns := []driver.TableColumn{
+		{Name: "id", Type: "UInt64"},
+		{Name: "created", Type: "DateTime", DefaultKind: proto.ColumnDefault, DefaultExpression: "now()"},
+		{Name: "day", Type: "Date", DefaultKind: proto.ColumnMaterialized, DefaultExpression: "toDate(created)"},
+		{Name: "alias", Type: "String", DefaultKind: proto.ColumnAlias, DefaultExpression: "toString(id)"},
+		{Name: "plain", Type: "String"},
+	}
+	assert.Equal(t, []string{"created", "day"}, defaultedColumns(tableColumns, []string{"id", "plain"}))
+
+	var (
+		m       = &structMap{}
+		columns = []string{"id", "created", "plain"}
+	)
+	type withCreated struct {
+		ID      uint64    `ch:"id"`
+		Created time.Time `ch:"created"`
+		Plain   string    `ch:"plain"`
+	}
+	type withoutCreated struct {
+		ID    uint64 `ch:"id"`
+		Plain string `ch:"plain"`
+		Day   string `ch:"day"`
+	}
+	type withoutPlain struct {
+		ID uint64 `ch:"id"`
+	}
+	assert.Empty(t, omittedColumns(m, tableColumns, columns, &withCreated{}))
+	assert.Equal(t, []string{"created"}, omittedColumns(m, tableColumns, columns, &withoutCreated{}))
+	// plain has no default expression, the struct can't leave it out
+	assert.Empty(t, omittedColumns(m, tableColumns, columns, &withoutPlain{}))
+	assert.Empty(t, omittedColumns(m, nil, columns, &withoutCreated{}))
+
+	assert.Equal(t, "INSERT INTO db.t (`id`, `plain`, `a\\`b`) FORMAT Native", insertQuery("db.t", []string{"id", "plain", "a`b"}))
+}
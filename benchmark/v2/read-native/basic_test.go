@@ -21,6 +21,8 @@ import (
 	"context"
 	"fmt"
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 	"log"
 	"testing"
 	"time"
@@ -53,6 +55,8 @@ func getConnection() clickhouse.Conn {
 func BenchmarkRead(b *testing.B) {
 	b.Run("string", benchmarkStringRead)
 	b.Run("random", benchmarkRandom)
+	b.Run("string-block", benchmarkStringReadBlock)
+	b.Run("random-block", benchmarkRandomBlock)
 }
 
 func benchmarkRandom(b *testing.B) {
@@ -101,3 +105,50 @@ func benchmarkStringRead(b *testing.B) {
 		}
 	}
 }
+
+func benchmarkRandomBlock(b *testing.B) {
+	conn := getConnection()
+	b.ResetTimer()
+	rows, err := conn.Query(context.Background(), fmt.Sprintf(`SELECT number, randomString(25), array(1, 2, 3, 4, 5), now() FROM system.numbers LIMIT %d`, b.N))
+	if err != nil {
+		b.Fatal(err)
+	}
+	i := 0
+	blocks := rows.(driver.BlockRows)
+	for blocks.NextBlock() {
+		block := blocks.Block()
+		var (
+			col1 = block.Columns[0].(*column.UInt64).Data()
+			col2 = block.Columns[1].(*column.String).Data()
+			col3 = block.Columns[2].(*column.Array)
+			col4 = block.Columns[3].(*column.DateTime)
+		)
+		_, _, _, _ = col1, col2, col3.Offsets(0), col3.Base().(*column.UInt8).Data()
+		for row := 0; row < col4.Rows(); row++ {
+			_ = col4.Row(row, false)
+		}
+		i += block.Rows()
+		if i >= b.N {
+			break
+		}
+	}
+}
+
+func benchmarkStringReadBlock(b *testing.B) {
+	conn := getConnection()
+	b.ResetTimer()
+	rows, err := conn.Query(context.Background(), fmt.Sprintf(`SELECT toString(number) FROM numbers(%d)`, b.N))
+	if err != nil {
+		b.Fatal(err)
+	}
+	i := 0
+	blocks := rows.(driver.BlockRows)
+	for blocks.NextBlock() {
+		block := blocks.Block()
+		_ = block.Columns[0].(*column.String).Data()
+		i += block.Rows()
+		if i >= b.N {
+			break
+		}
+	}
+}
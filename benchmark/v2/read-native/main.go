@@ -24,6 +24,8 @@ import (
 	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 )
 
 func benchmarkRead(conn clickhouse.Conn) error {
@@ -68,6 +70,18 @@ func benchmarkString(conn clickhouse.Conn) error {
 	return nil
 }
 
+func benchmarkStringBlock(conn clickhouse.Conn) error {
+	rows, err := conn.Query(context.Background(), `SELECT toString(number) FROM numbers(500000000)`)
+	if err != nil {
+		return err
+	}
+	blocks := rows.(driver.BlockRows)
+	for blocks.NextBlock() {
+		_ = blocks.Block().Columns[0].(*column.String).Data()
+	}
+	return rows.Err()
+}
+
 func main() {
 	conn, err := clickhouse.Open(&clickhouse.Options{
 		Addr: []string{"127.0.0.1:9000"},
@@ -95,4 +109,9 @@ func main() {
 		log.Fatal(err)
 	}
 	fmt.Printf("benchmarkString: %v\n", time.Since(start))
+	start = time.Now()
+	if err := benchmarkStringBlock(conn); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("benchmarkStringBlock: %v\n", time.Since(start))
 }
@@ -25,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 )
@@ -100,7 +101,7 @@ func checkAllNamedArguments(args ...any) (bool, error) {
 	)
 	for _, v := range args {
 		switch v.(type) {
-		case driver.NamedValue, driver.NamedDateValue:
+		case driver.NamedValue, driver.NamedParam, driver.NamedDateValue:
 			haveNamed = true
 		default:
 			haveAnonymous = true
@@ -210,6 +211,9 @@ func bindNamed(tz *time.Location, query string, args ...any) (_ string, err erro
 		params = make(map[string]string)
 	)
 	for _, v := range args {
+		if p, ok := v.(driver.NamedParam); ok {
+			v = driver.NamedValue(p)
+		}
 		switch v := v.(type) {
 		case driver.NamedValue:
 			value := v.Value
@@ -275,6 +279,15 @@ func formatTime(tz *time.Location, scale TimeUnit, value time.Time) (string, err
 	return fmt.Sprintf("toDateTime64('%s', %d, '%s')", value.Format(fmt.Sprintf("2006-01-02 15:04:05.%0*d", int(scale*3), 0)), int(scale*3), value.Location().String()), nil
 }
 
+// formatDuration renders a time of day as a Time value, or as Time64 with the precision of the bind scale
+func formatDuration(scale TimeUnit, value time.Duration) string {
+	if scale == Seconds {
+		return fmt.Sprintf("CAST('%s', 'Time')", chcol.FormatDuration(value, 0))
+	}
+	precision := int(scale * 3)
+	return fmt.Sprintf("CAST('%s', 'Time64(%d)')", chcol.FormatDuration(value, precision), precision)
+}
+
 var stringQuoteReplacer = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
 
 func format(tz *time.Location, scale TimeUnit, v any) (string, error) {
@@ -288,6 +301,27 @@ func format(tz *time.Location, scale TimeUnit, v any) (string, error) {
 		return quote(v), nil
 	case time.Time:
 		return formatTime(tz, scale, v)
+	case time.Duration:
+		return chcol.DurationInterval(v).String(), nil
+	case *time.Duration:
+		if v == nil {
+			return "NULL", nil
+		}
+		return chcol.DurationInterval(*v).String(), nil
+	case chcol.Interval:
+		return v.String(), nil
+	case *chcol.Interval:
+		if v == nil {
+			return "NULL", nil
+		}
+		return v.String(), nil
+	case chcol.Time:
+		return formatDuration(scale, v.Duration()), nil
+	case *chcol.Time:
+		if v == nil {
+			return "NULL", nil
+		}
+		return formatDuration(scale, v.Duration()), nil
 	case bool:
 		if v {
 			return "1", nil
@@ -301,6 +301,36 @@ func TestFormatScaledTime(t *testing.T) {
 	require.Equal(t, "toDateTime64('2022-01-12 15:00:00.123456789', 9, 'UTC')", val)
 }
 
+func TestFormatTimeOfDay(t *testing.T) {
+	d := time.Hour + 2*time.Minute + 3*time.Second + 456*time.Millisecond
+	val, _ := format(time.UTC, Seconds, NewTime(d))
+	require.Equal(t, "CAST('01:02:03', 'Time')", val)
+	val, _ = format(time.UTC, MilliSeconds, NewTime(d))
+	require.Equal(t, "CAST('01:02:03.456', 'Time64(3)')", val)
+	val, _ = format(time.UTC, NanoSeconds, NewTime(-d))
+	require.Equal(t, "CAST('-01:02:03.456000000', 'Time64(9)')", val)
+	val, _ = format(time.UTC, Seconds, NewTime(100*time.Hour))
+	require.Equal(t, "CAST('100:00:00', 'Time')", val)
+	var nilTime *Time
+	val, _ = format(time.UTC, Seconds, nilTime)
+	require.Equal(t, "NULL", val)
+}
+
+func TestFormatInterval(t *testing.T) {
+	d := time.Hour + 2*time.Minute + 3*time.Second + 456*time.Millisecond
+	val, _ := format(time.UTC, Seconds, d)
+	require.Equal(t, "INTERVAL 3723456 MILLISECOND", val)
+	val, _ = format(time.UTC, Seconds, -90*time.Minute)
+	require.Equal(t, "INTERVAL -90 MINUTE", val)
+	val, _ = format(time.UTC, Seconds, []time.Duration{48 * time.Hour, time.Nanosecond})
+	require.Equal(t, "[INTERVAL 2 DAY, INTERVAL 1 NANOSECOND]", val)
+	val, _ = format(time.UTC, Seconds, NewInterval(3, IntervalMonth))
+	require.Equal(t, "INTERVAL 3 MONTH", val)
+	var nilDuration *time.Duration
+	val, _ = format(time.UTC, Seconds, nilDuration)
+	require.Equal(t, "NULL", val)
+}
+
 func TestStringBasedType(t *testing.T) {
 	type (
 		SupperString       string
@@ -0,0 +1,275 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package charrow
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+// Append appends the rows of an Arrow record to a batch. Every column of the batch takes the values of the record
+// field with the same name, and the record has no other fields.
+// An error converting the record leaves the batch as it was, but a column failing to append can leave the columns
+// before it, or itself, with more rows than the others, and the batch must then be aborted.
+func Append(batch driver.Batch, record arrow.Record) error {
+	var (
+		columns = batch.Columns()
+		schema  = record.Schema()
+		values  = make([]any, len(columns))
+	)
+	if int(record.NumCols()) != len(columns) {
+		return fmt.Errorf("clickhouse [arrow]: record has %d fields for the %d columns of the batch", record.NumCols(), len(columns))
+	}
+	// convert every field before appending any, so a conversion failure leaves the batch as it was
+	for i, col := range columns {
+		fields := schema.FieldIndices(col.Name())
+		if len(fields) == 0 {
+			return fmt.Errorf("clickhouse [arrow]: record has no field for column %s", col.Name())
+		}
+		v, err := goValues(record.Column(fields[0]), col)
+		if err != nil {
+			return err
+		}
+		values[i] = v.Interface()
+	}
+	for i, v := range values {
+		if err := batch.Column(i).Append(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// goValues converts an Arrow array into the slice of Go values column appends
+func goValues(arr arrow.Array, col column.Interface) (reflect.Value, error) {
+	switch arr := arr.(type) {
+	case *array.Map:
+		return mapValues(arr, col)
+	case *array.List:
+		return listValues(arr, col)
+	case *array.Dictionary:
+		return dictionaryValues(arr, col)
+	}
+	values, err := flatValues(arr, leaf(col))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if !nullable(col) {
+		if arr.NullN() != 0 {
+			return reflect.Value{}, fmt.Errorf("clickhouse [arrow]: column %s of type %s can't hold the %d NULL values of the field", col.Name(), col.Type(), arr.NullN())
+		}
+		return values, nil
+	}
+	ptrs := reflect.MakeSlice(reflect.SliceOf(reflect.PointerTo(values.Type().Elem())), values.Len(), values.Len())
+	for i := 0; i < values.Len(); i++ {
+		if arr.IsValid(i) {
+			ptrs.Index(i).Set(values.Index(i).Addr())
+		}
+	}
+	return ptrs, nil
+}
+
+// leaf returns the column that holds the values of a Nullable or LowCardinality column
+func leaf(col column.Interface) column.Interface {
+	switch c := col.(type) {
+	case *column.Nullable:
+		return c.Base()
+	case *column.LowCardinality:
+		return leaf(c.Dictionary())
+	}
+	return col
+}
+
+func listValues(arr *array.List, col column.Interface) (reflect.Value, error) {
+	elem := col
+	if c, ok := col.(*column.Array); ok {
+		// the levels of an Array column share it, only the innermost list holds its Base values
+		if _, nested := arr.ListValues().(*array.List); !nested {
+			elem = c.Base()
+		}
+	}
+	values, err := goValues(arr.ListValues(), elem)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	rows := reflect.MakeSlice(reflect.SliceOf(values.Type()), arr.Len(), arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		start, end := arr.ValueOffsets(i)
+		rows.Index(i).Set(values.Slice(int(start), int(end)))
+	}
+	return rows, nil
+}
+
+func mapValues(arr *array.Map, col column.Interface) (reflect.Value, error) {
+	keysColumn, valuesColumn := col, col
+	if c, ok := col.(*column.Map); ok {
+		keysColumn, valuesColumn = c.Keys(), c.Values()
+	}
+	keys, err := goValues(arr.Keys(), keysColumn)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	items, err := goValues(arr.Items(), valuesColumn)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	// a Go map would lose the order of the entries, append them in the order of the Arrow map instead
+	rows := make([]column.IterableOrderedMap, arr.Len())
+	for i := range rows {
+		start, end := arr.ValueOffsets(i)
+		rows[i] = &orderedMap{
+			keys:   keys.Slice(int(start), int(end)),
+			values: items.Slice(int(start), int(end)),
+		}
+	}
+	return reflect.ValueOf(rows), nil
+}
+
+// orderedMap is a row of a Map column, holding its keys and values in order
+type orderedMap struct {
+	keys, values reflect.Value
+}
+
+func (m *orderedMap) Put(key any, value any) {
+	m.keys = reflect.Append(m.keys, reflect.ValueOf(key))
+	m.values = reflect.Append(m.values, reflect.ValueOf(value))
+}
+
+func (m *orderedMap) Iterator() column.MapIterator {
+	return &orderedMapIterator{m: m, i: -1}
+}
+
+type orderedMapIterator struct {
+	m *orderedMap
+	i int
+}
+
+func (it *orderedMapIterator) Next() bool { it.i++; return it.i < it.m.keys.Len() }
+
+func (it *orderedMapIterator) Key() any { return it.m.keys.Index(it.i).Interface() }
+
+func (it *orderedMapIterator) Value() any { return it.m.values.Index(it.i).Interface() }
+
+func dictionaryValues(arr *array.Dictionary, col column.Interface) (reflect.Value, error) {
+	dictionaryColumn := col
+	if c, ok := col.(*column.LowCardinality); ok {
+		dictionaryColumn = c.Dictionary()
+	}
+	values, err := goValues(arr.Dictionary(), dictionaryColumn)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	rows := reflect.MakeSlice(values.Type(), arr.Len(), arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			if values.Type().Elem().Kind() != reflect.Pointer {
+				return reflect.Value{}, fmt.Errorf("clickhouse [arrow]: column %s of type %s can't hold the %d NULL values of the field", col.Name(), col.Type(), arr.NullN())
+			}
+			continue
+		}
+		rows.Index(i).Set(values.Index(arr.GetValueIndex(i)))
+	}
+	return rows, nil
+}
+
+// flatValues converts the values of an Arrow array with a flat type, ignoring its validity bitmap
+func flatValues(arr arrow.Array, col column.Interface) (reflect.Value, error) {
+	switch arr := arr.(type) {
+	case *array.Int8:
+		return reflect.ValueOf(arr.Int8Values()), nil
+	case *array.Int16:
+		return reflect.ValueOf(arr.Int16Values()), nil
+	case *array.Int32:
+		return reflect.ValueOf(arr.Int32Values()), nil
+	case *array.Int64:
+		return reflect.ValueOf(arr.Int64Values()), nil
+	case *array.Uint8:
+		return reflect.ValueOf(arr.Uint8Values()), nil
+	case *array.Uint16:
+		return reflect.ValueOf(arr.Uint16Values()), nil
+	case *array.Uint32:
+		return reflect.ValueOf(arr.Uint32Values()), nil
+	case *array.Uint64:
+		return reflect.ValueOf(arr.Uint64Values()), nil
+	case *array.Float32:
+		return reflect.ValueOf(arr.Float32Values()), nil
+	case *array.Float64:
+		return reflect.ValueOf(arr.Float64Values()), nil
+	case *array.Boolean:
+		return convert(arr.Len(), arr.Value), nil
+	case *array.String:
+		return convert(arr.Len(), arr.Value), nil
+	case *array.LargeString:
+		return convert(arr.Len(), arr.Value), nil
+	case *array.Binary:
+		return convert(arr.Len(), arr.ValueString), nil
+	case *array.FixedSizeBinary:
+		if _, ok := col.(*column.UUID); ok {
+			if width := arr.DataType().(*arrow.FixedSizeBinaryType).ByteWidth; width != 16 {
+				break
+			}
+			return convert(arr.Len(), func(i int) uuid.UUID {
+				return uuid.UUID(arr.Value(i))
+			}), nil
+		}
+		return convert(arr.Len(), func(i int) string {
+			return string(arr.Value(i))
+		}), nil
+	case *array.Date32:
+		return convert(arr.Len(), func(i int) time.Time {
+			return arr.Value(i).ToTime()
+		}), nil
+	case *array.Date64:
+		return convert(arr.Len(), func(i int) time.Time {
+			return arr.Value(i).ToTime()
+		}), nil
+	case *array.Timestamp:
+		unit := arr.DataType().(*arrow.TimestampType).Unit
+		return convert(arr.Len(), func(i int) time.Time {
+			return arr.Value(i).ToTime(unit)
+		}), nil
+	case *array.Decimal128:
+		scale := arr.DataType().(*arrow.Decimal128Type).Scale
+		return convert(arr.Len(), func(i int) decimal.Decimal {
+			return decimal.NewFromBigInt(arr.Value(i).BigInt(), -scale)
+		}), nil
+	case *array.Decimal256:
+		scale := arr.DataType().(*arrow.Decimal256Type).Scale
+		return convert(arr.Len(), func(i int) decimal.Decimal {
+			return decimal.NewFromBigInt(arr.Value(i).BigInt(), -scale)
+		}), nil
+	}
+	return reflect.Value{}, fmt.Errorf("clickhouse [arrow]: field of type %s can't be appended to column %s of type %s", arr.DataType(), col.Name(), col.Type())
+}
+
+func convert[T any](n int, value func(int) T) reflect.Value {
+	values := make([]T, n)
+	for i := range values {
+		values[i] = value(i)
+	}
+	return reflect.ValueOf(values)
+}
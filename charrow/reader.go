@@ -0,0 +1,109 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package charrow
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+// Reader reads the result of a query as Arrow records, one record for each block sent by the server
+type Reader struct {
+	refs   int64
+	mem    memory.Allocator
+	rows   driver.Rows
+	blocks driver.BlockRows
+	schema *arrow.Schema
+	record arrow.Record
+	err    error
+}
+
+// NewReader returns a reader over rows, which it closes once released. The rows must implement driver.BlockRows,
+// like the rows of a query of this driver.
+func NewReader(mem memory.Allocator, rows driver.Rows) (*Reader, error) {
+	blocks, ok := rows.(driver.BlockRows)
+	if !ok {
+		rows.Close()
+		return nil, fmt.Errorf("charrow: %T can't be read by block", rows)
+	}
+	schema, err := Schema(blocks.Block())
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+	return &Reader{
+		refs:   1,
+		mem:    mem,
+		rows:   rows,
+		blocks: blocks,
+		schema: schema,
+	}, nil
+}
+
+func (r *Reader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+// Next converts the next block of rows. The record of the previous block is released, callers that keep it
+// retain it first.
+func (r *Reader) Next() bool {
+	if r.record != nil {
+		r.record.Release()
+		r.record = nil
+	}
+	if r.err != nil {
+		return false
+	}
+	if !r.blocks.NextBlock() {
+		r.err = r.rows.Err()
+		return false
+	}
+	r.record, r.err = NewRecord(r.mem, r.blocks.Block())
+	return r.err == nil
+}
+
+func (r *Reader) Record() arrow.Record {
+	return r.record
+}
+
+func (r *Reader) Err() error {
+	return r.err
+}
+
+func (r *Reader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+func (r *Reader) Release() {
+	if atomic.AddInt64(&r.refs, -1) != 0 {
+		return
+	}
+	if r.record != nil {
+		r.record.Release()
+		r.record = nil
+	}
+	r.rows.Close()
+}
+
+var _ array.RecordReader = (*Reader)(nil)
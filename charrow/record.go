@@ -0,0 +1,397 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package charrow converts the blocks read and written by clickhouse-go from and to Apache Arrow records.
+//
+// ClickHouse types map to Arrow as follows: integers and floats to their primitive types, Bool to Boolean,
+// String and Enum to String, FixedString and UUID to FixedSizeBinary, Date and Date32 to Date32, DateTime and
+// DateTime64 to Timestamp, Decimal to Decimal128 or Decimal256, Nullable to the validity bitmap of the nested type,
+// Array to List, Map to Map and LowCardinality to Dictionary. Other types are rejected with UnsupportedTypeError.
+package charrow
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/decimal256"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+)
+
+// UnsupportedTypeError is returned for the columns that have no Arrow equivalent
+type UnsupportedTypeError struct {
+	Column string
+	Type   string
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("clickhouse [arrow]: column %s of type %s has no Arrow equivalent", e.Column, e.Type)
+}
+
+// Schema returns the Arrow schema of the columns of a block
+func Schema(block *proto.Block) (*arrow.Schema, error) {
+	fields := make([]arrow.Field, len(block.Columns))
+	for i, col := range block.Columns {
+		dt, err := dataType(col)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = arrow.Field{Name: col.Name(), Type: dt, Nullable: nullable(col)}
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// NewRecord converts the columns of a block into an Arrow record, which the caller has to release
+func NewRecord(mem memory.Allocator, block *proto.Block) (arrow.Record, error) {
+	schema, err := Schema(block)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]arrow.Array, 0, len(block.Columns))
+	defer func() {
+		for _, c := range columns {
+			c.Release()
+		}
+	}()
+	for _, col := range block.Columns {
+		arr, err := newArray(mem, col, nil)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, arr)
+	}
+	return array.NewRecord(schema, columns, int64(block.Rows())), nil
+}
+
+func nullable(col column.Interface) bool {
+	switch col := col.(type) {
+	case *column.Nullable:
+		return true
+	case *column.LowCardinality:
+		_, ok := col.Dictionary().(*column.Nullable)
+		return ok
+	}
+	return false
+}
+
+func dataType(col column.Interface) (arrow.DataType, error) {
+	switch col := col.(type) {
+	case *column.Int8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case *column.Int16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case *column.Int32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case *column.Int64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case *column.UInt8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case *column.UInt16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case *column.UInt32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case *column.UInt64:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case *column.Float32:
+		return arrow.PrimitiveTypes.Float32, nil
+	case *column.Float64:
+		return arrow.PrimitiveTypes.Float64, nil
+	case *column.Bool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case *column.String, *column.Enum8, *column.Enum16:
+		return arrow.BinaryTypes.String, nil
+	case *column.FixedString:
+		var size int
+		if _, err := fmt.Sscanf(string(col.Type()), "FixedString(%d)", &size); err != nil {
+			return nil, err
+		}
+		return &arrow.FixedSizeBinaryType{ByteWidth: size}, nil
+	case *column.UUID:
+		return &arrow.FixedSizeBinaryType{ByteWidth: 16}, nil
+	case *column.Date, *column.Date32:
+		return arrow.FixedWidthTypes.Date32, nil
+	case *column.DateTime:
+		return &arrow.TimestampType{Unit: arrow.Second, TimeZone: timeZone(col.Location())}, nil
+	case *column.DateTime64:
+		return &arrow.TimestampType{Unit: timeUnit(col.Precision()), TimeZone: timeZone(col.Location())}, nil
+	case *column.Decimal:
+		if col.Precision() > 38 {
+			return &arrow.Decimal256Type{Precision: int32(col.Precision()), Scale: int32(col.Scale())}, nil
+		}
+		return &arrow.Decimal128Type{Precision: int32(col.Precision()), Scale: int32(col.Scale())}, nil
+	case *column.Nullable:
+		return dataType(col.Base())
+	case *column.Array:
+		dt, err := dataType(col.Base())
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < col.Depth(); i++ {
+			dt = arrow.ListOf(dt)
+		}
+		return dt, nil
+	case *column.Map:
+		keys, err := dataType(col.Keys())
+		if err != nil {
+			return nil, err
+		}
+		values, err := dataType(col.Values())
+		if err != nil {
+			return nil, err
+		}
+		return arrow.MapOf(keys, values), nil
+	case *column.LowCardinality:
+		values, err := dataType(col.Dictionary())
+		if err != nil {
+			return nil, err
+		}
+		return &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: values}, nil
+	}
+	return nil, &UnsupportedTypeError{Column: col.Name(), Type: string(col.Type())}
+}
+
+// timeUnit returns the coarsest Arrow unit that holds the given number of fractional digits
+func timeUnit(precision int) arrow.TimeUnit {
+	switch {
+	case precision == 0:
+		return arrow.Second
+	case precision <= 3:
+		return arrow.Millisecond
+	case precision <= 6:
+		return arrow.Microsecond
+	}
+	return arrow.Nanosecond
+}
+
+func timeZone(loc *time.Location) string {
+	if loc == nil || loc == time.Local {
+		return ""
+	}
+	return loc.String()
+}
+
+func timestamp(t time.Time, unit arrow.TimeUnit) arrow.Timestamp {
+	switch unit {
+	case arrow.Second:
+		return arrow.Timestamp(t.Unix())
+	case arrow.Millisecond:
+		return arrow.Timestamp(t.UnixMilli())
+	case arrow.Microsecond:
+		return arrow.Timestamp(t.UnixMicro())
+	}
+	return arrow.Timestamp(t.UnixNano())
+}
+
+// newArray converts a column into an Arrow array, valid marks the rows that are not NULL when it is not nil
+func newArray(mem memory.Allocator, col column.Interface, valid []bool) (arrow.Array, error) {
+	switch col := col.(type) {
+	case *column.Nullable:
+		nulls := col.Nulls()
+		valid := make([]bool, len(nulls))
+		for i, null := range nulls {
+			valid[i] = null == 0
+		}
+		return newArray(mem, col.Base(), valid)
+	case *column.Array:
+		values, err := newArray(mem, col.Base(), nil)
+		if err != nil {
+			return nil, err
+		}
+		for level := col.Depth() - 1; level >= 0; level-- {
+			offsets := col.Offsets(level)
+			if values, err = newList(values, len(offsets), func(i int) int64 { return int64(offsets[i]) }); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	case *column.Map:
+		return newMap(mem, col)
+	case *column.LowCardinality:
+		return newDictionary(mem, col)
+	}
+	dt, err := dataType(col)
+	if err != nil {
+		return nil, err
+	}
+	b := array.NewBuilder(mem, dt)
+	defer b.Release()
+	if err := appendValues(b, col, valid); err != nil {
+		return nil, err
+	}
+	return b.NewArray(), nil
+}
+
+// listOffsets returns the Arrow offsets of rows whose ends are given by end
+func listOffsets(rows int, end func(int) int64) (*memory.Buffer, error) {
+	offsets := make([]int32, rows+1)
+	for i := 0; i < rows; i++ {
+		v := end(i)
+		if v > math.MaxInt32 {
+			return nil, fmt.Errorf("clickhouse [arrow]: %d values overflow the offsets of a list", v)
+		}
+		offsets[i+1] = int32(v)
+	}
+	return memory.NewBufferBytes(arrow.Int32Traits.CastToBytes(offsets)), nil
+}
+
+// newList wraps values into a list array and releases them
+func newList(values arrow.Array, rows int, end func(int) int64) (arrow.Array, error) {
+	defer values.Release()
+	offsets, err := listOffsets(rows, end)
+	if err != nil {
+		return nil, err
+	}
+	data := array.NewData(arrow.ListOf(values.DataType()), rows, []*memory.Buffer{nil, offsets}, []arrow.ArrayData{values.Data()}, 0, 0)
+	defer data.Release()
+	return array.NewListData(data), nil
+}
+
+func newMap(mem memory.Allocator, col *column.Map) (arrow.Array, error) {
+	keys, err := newArray(mem, col.Keys(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer keys.Release()
+	values, err := newArray(mem, col.Values(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer values.Release()
+	ends := col.Offsets()
+	offsets, err := listOffsets(len(ends), func(i int) int64 { return ends[i] })
+	if err != nil {
+		return nil, err
+	}
+	dt := arrow.MapOf(keys.DataType(), values.DataType())
+	entries := array.NewData(dt.Elem(), keys.Len(), []*memory.Buffer{nil}, []arrow.ArrayData{keys.Data(), values.Data()}, 0, 0)
+	defer entries.Release()
+	data := array.NewData(dt, len(ends), []*memory.Buffer{nil, offsets}, []arrow.ArrayData{entries}, 0, 0)
+	defer data.Release()
+	return array.NewMapData(data), nil
+}
+
+func newDictionary(mem memory.Allocator, col *column.LowCardinality) (arrow.Array, error) {
+	values, isNullable := col.Dictionary(), false
+	if n, ok := values.(*column.Nullable); ok {
+		// the first value of a nullable dictionary stands for NULL
+		values, isNullable = n.Base(), true
+	}
+	dictionary, err := newArray(mem, values, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer dictionary.Release()
+	b := array.NewInt32Builder(mem)
+	defer b.Release()
+	keys := col.Keys()
+	b.Reserve(len(keys))
+	for _, key := range keys {
+		if isNullable && key == 0 {
+			b.AppendNull()
+			continue
+		}
+		b.Append(int32(key))
+	}
+	indices := b.NewArray()
+	defer indices.Release()
+	dt := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: dictionary.DataType()}
+	return array.NewDictionaryArray(dt, indices, dictionary), nil
+}
+
+// appendValues appends the rows of a column with a flat Arrow type to the builder made for its type
+func appendValues(b array.Builder, col column.Interface, valid []bool) error {
+	switch col := col.(type) {
+	case *column.Int8:
+		b.(*array.Int8Builder).AppendValues(col.Data(), valid)
+	case *column.Int16:
+		b.(*array.Int16Builder).AppendValues(col.Data(), valid)
+	case *column.Int32:
+		b.(*array.Int32Builder).AppendValues(col.Data(), valid)
+	case *column.Int64:
+		b.(*array.Int64Builder).AppendValues(col.Data(), valid)
+	case *column.UInt8:
+		b.(*array.Uint8Builder).AppendValues(col.Data(), valid)
+	case *column.UInt16:
+		b.(*array.Uint16Builder).AppendValues(col.Data(), valid)
+	case *column.UInt32:
+		b.(*array.Uint32Builder).AppendValues(col.Data(), valid)
+	case *column.UInt64:
+		b.(*array.Uint64Builder).AppendValues(col.Data(), valid)
+	case *column.Float32:
+		b.(*array.Float32Builder).AppendValues(col.Data(), valid)
+	case *column.Float64:
+		b.(*array.Float64Builder).AppendValues(col.Data(), valid)
+	case *column.String:
+		b.(*array.StringBuilder).AppendValues(col.Data(), valid)
+	case *column.Bool:
+		bb := b.(*array.BooleanBuilder)
+		appendRows(b, col, valid, func(i int) { bb.Append(col.Row(i, false).(bool)) })
+	case *column.Enum8, *column.Enum16:
+		sb := b.(*array.StringBuilder)
+		appendRows(b, col, valid, func(i int) { sb.Append(col.Row(i, false).(string)) })
+	case *column.FixedString:
+		fb := b.(*array.FixedSizeBinaryBuilder)
+		appendRows(b, col, valid, func(i int) { fb.Append([]byte(col.Row(i, false).(string))) })
+	case *column.UUID:
+		fb := b.(*array.FixedSizeBinaryBuilder)
+		appendRows(b, col, valid, func(i int) {
+			v := col.Row(i, false).(uuid.UUID)
+			fb.Append(v[:])
+		})
+	case *column.Date, *column.Date32:
+		db := b.(*array.Date32Builder)
+		appendRows(b, col, valid, func(i int) { db.Append(arrow.Date32FromTime(col.Row(i, false).(time.Time))) })
+	case *column.DateTime, *column.DateTime64:
+		tb := b.(*array.TimestampBuilder)
+		unit := tb.Type().(*arrow.TimestampType).Unit
+		appendRows(b, col, valid, func(i int) { tb.Append(timestamp(col.Row(i, false).(time.Time), unit)) })
+	case *column.Decimal:
+		scale := int32(col.Scale())
+		switch db := b.(type) {
+		case *array.Decimal128Builder:
+			appendRows(b, col, valid, func(i int) {
+				db.Append(decimal128.FromBigInt(col.Row(i, false).(decimal.Decimal).Shift(scale).BigInt()))
+			})
+		case *array.Decimal256Builder:
+			appendRows(b, col, valid, func(i int) {
+				db.Append(decimal256.FromBigInt(col.Row(i, false).(decimal.Decimal).Shift(scale).BigInt()))
+			})
+		}
+	default:
+		return &UnsupportedTypeError{Column: col.Name(), Type: string(col.Type())}
+	}
+	return nil
+}
+
+func appendRows(b array.Builder, col column.Interface, valid []bool, appendRow func(int)) {
+	b.Reserve(col.Rows())
+	for i := 0; i < col.Rows(); i++ {
+		if valid != nil && !valid[i] {
+			b.AppendNull()
+			continue
+		}
+		appendRow(i)
+	}
+}
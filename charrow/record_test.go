@@ -0,0 +1,311 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package charrow
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+)
+
+// testBatch appends to a block the way a batch does
+type testBatch struct {
+	driver.Batch
+	block *proto.Block
+}
+
+func (b *testBatch) Columns() []column.Interface {
+	return b.block.Columns
+}
+
+func (b *testBatch) Column(i int) driver.BatchColumn {
+	return testBatchColumn{b.block.Columns[i]}
+}
+
+type testBatchColumn struct {
+	column.Interface
+}
+
+func (c testBatchColumn) Append(v any) error {
+	_, err := c.Interface.Append(v)
+	return err
+}
+
+func newBlock(t *testing.T, types ...string) *proto.Block {
+	block := &proto.Block{}
+	for i, chType := range types {
+		require.NoError(t, block.AddColumn(string(rune('a'+i)), column.Type(chType)))
+	}
+	return block
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	var (
+		day   = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+		now   = time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC)
+		one   = int64(1)
+		label = "label"
+	)
+	tests := []struct {
+		chType string
+		values any
+		id     arrow.Type
+	}{
+		{"Int8", []int8{-1, 2}, arrow.INT8},
+		{"Int16", []int16{-1, 2}, arrow.INT16},
+		{"Int32", []int32{-1, 2}, arrow.INT32},
+		{"Int64", []int64{-1, 2}, arrow.INT64},
+		{"UInt8", []uint8{1, 2}, arrow.UINT8},
+		{"UInt16", []uint16{1, 2}, arrow.UINT16},
+		{"UInt32", []uint32{1, 2}, arrow.UINT32},
+		{"UInt64", []uint64{1, 2}, arrow.UINT64},
+		{"Float32", []float32{1.5, -2}, arrow.FLOAT32},
+		{"Float64", []float64{1.5, -2}, arrow.FLOAT64},
+		{"Bool", []bool{true, false}, arrow.BOOL},
+		{"String", []string{"a", ""}, arrow.STRING},
+		{"Enum8('a' = 1, 'b' = 2)", []string{"a", "b"}, arrow.STRING},
+		{"Enum16('a' = 1, 'b' = 2)", []string{"b", "a"}, arrow.STRING},
+		{"FixedString(2)", []string{"ab", "cd"}, arrow.FIXED_SIZE_BINARY},
+		{"UUID", []uuid.UUID{uuid.New(), uuid.New()}, arrow.FIXED_SIZE_BINARY},
+		{"Date", []time.Time{day, day.AddDate(0, 0, 1)}, arrow.DATE32},
+		{"Date32", []time.Time{day, day.AddDate(-100, 0, 0)}, arrow.DATE32},
+		{"DateTime('UTC')", []time.Time{now.Truncate(time.Second)}, arrow.TIMESTAMP},
+		{"DateTime64(3, 'UTC')", []time.Time{now.Truncate(time.Millisecond)}, arrow.TIMESTAMP},
+		{"DateTime64(9, 'UTC')", []time.Time{now}, arrow.TIMESTAMP},
+		{"Decimal(10, 2)", []decimal.Decimal{decimal.RequireFromString("-12.34")}, arrow.DECIMAL128},
+		{"Decimal(76, 4)", []decimal.Decimal{decimal.RequireFromString("1234.5678")}, arrow.DECIMAL256},
+		{"Nullable(Int64)", []*int64{&one, nil}, arrow.INT64},
+		{"Nullable(String)", []*string{nil, &label}, arrow.STRING},
+		{"Array(String)", [][]string{{"a", "b"}, {}, {"c"}}, arrow.LIST},
+		{"Array(Array(UInt8))", [][][]uint8{{{1}, {2, 3}}, {}}, arrow.LIST},
+		{"Array(Nullable(Int64))", [][]*int64{{nil, &one}}, arrow.LIST},
+		{"Map(String, UInt64)", []map[string]uint64{{"a": 1, "b": 2}, {}}, arrow.MAP},
+		{"Map(String, Array(String))", []map[string][]string{{"a": {"b"}}}, arrow.MAP},
+		{"Array(Map(String, UInt64))", [][]map[string]uint64{{{"a": 1}, {"b": 2}}, {}}, arrow.LIST},
+		{"LowCardinality(String)", []string{"a", "b", "a"}, arrow.DICTIONARY},
+		{"LowCardinality(Nullable(String))", []*string{&label, nil, &label}, arrow.DICTIONARY},
+	}
+	for _, tt := range tests {
+		t.Run(tt.chType, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			block := newBlock(t, tt.chType)
+			_, err := block.Columns[0].Append(tt.values)
+			require.NoError(t, err)
+
+			record, err := NewRecord(mem, block)
+			require.NoError(t, err)
+			defer record.Release()
+			require.Equal(t, int64(block.Rows()), record.NumRows())
+			assert.Equal(t, tt.id, record.Schema().Field(0).Type.ID())
+			assert.True(t, arrow.TypeEqual(record.Schema().Field(0).Type, record.Column(0).DataType()))
+
+			target := newBlock(t, tt.chType)
+			require.NoError(t, Append(&testBatch{block: target}, record))
+			require.Equal(t, block.Rows(), target.Rows())
+			again, err := NewRecord(mem, target)
+			require.NoError(t, err)
+			defer again.Release()
+			assert.True(t, array.Equal(record.Column(0), again.Column(0)), "%v != %v", record.Column(0), again.Column(0))
+		})
+	}
+}
+
+func TestRecordValues(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	block := newBlock(t, "Nullable(UInt64)", "Array(String)", "LowCardinality(Nullable(String))", "DateTime64(6, 'UTC')")
+	one, label := uint64(1), "label"
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+	require.NoError(t, block.Append(&one, []string{"a", "b"}, &label, now))
+	require.NoError(t, block.Append(nil, []string{}, nil, now))
+
+	record, err := NewRecord(mem, block)
+	require.NoError(t, err)
+	defer record.Release()
+
+	nullable := record.Column(0).(*array.Uint64)
+	assert.True(t, nullable.IsValid(0))
+	assert.Equal(t, uint64(1), nullable.Value(0))
+	assert.True(t, nullable.IsNull(1))
+
+	list := record.Column(1).(*array.List)
+	start, end := list.ValueOffsets(0)
+	assert.Equal(t, [2]int64{0, 2}, [2]int64{start, end})
+	start, end = list.ValueOffsets(1)
+	assert.Equal(t, start, end)
+	assert.Equal(t, "b", list.ListValues().(*array.String).Value(1))
+
+	dictionary := record.Column(2).(*array.Dictionary)
+	assert.Equal(t, "label", dictionary.Dictionary().(*array.String).Value(dictionary.GetValueIndex(0)))
+	assert.True(t, dictionary.IsNull(1))
+
+	timestamps := record.Column(3).(*array.Timestamp)
+	assert.Equal(t, arrow.Microsecond, timestamps.DataType().(*arrow.TimestampType).Unit)
+	assert.Equal(t, "UTC", timestamps.DataType().(*arrow.TimestampType).TimeZone)
+	assert.Equal(t, arrow.Timestamp(now.UnixMicro()), timestamps.Value(0))
+}
+
+func TestUnsupportedTypes(t *testing.T) {
+	for _, chType := range []string{
+		"Int128",
+		"UInt128",
+		"Int256",
+		"UInt256",
+		"IPv4",
+		"IPv6",
+		"Point",
+		"Ring",
+		"Polygon",
+		"MultiPolygon",
+		"Tuple(String, Int64)",
+		"Nothing",
+		"Variant(String, Int64)",
+		"Dynamic",
+		"JSON",
+		"Object('json')",
+		"IntervalSecond",
+		"SimpleAggregateFunction(sum, UInt64)",
+		"Nested(a String)",
+		"Array(IPv4)",
+		"Map(String, Tuple(String, Int64))",
+	} {
+		t.Run(chType, func(t *testing.T) {
+			block := &proto.Block{}
+			if err := block.AddColumn("c", column.Type(chType)); err != nil {
+				t.Skipf("%s isn't parsed by this version: %v", chType, err)
+			}
+			_, err := Schema(block)
+			var unsupported *UnsupportedTypeError
+			assert.ErrorAs(t, err, &unsupported)
+			_, err = NewRecord(memory.NewGoAllocator(), block)
+			assert.ErrorAs(t, err, &unsupported)
+		})
+	}
+}
+
+func TestAppendErrors(t *testing.T) {
+	mem := memory.NewGoAllocator()
+	newRecord := func(types ...string) arrow.Record {
+		block := newBlock(t, types...)
+		// a row of zero values, which are NULL for Nullable columns
+		for _, col := range block.Columns {
+			require.NoError(t, col.AppendRow(reflect.Zero(col.ScanType()).Interface()))
+		}
+		record, err := NewRecord(mem, block)
+		require.NoError(t, err)
+		return record
+	}
+	tests := map[string]struct {
+		record arrow.Record
+		target *proto.Block
+	}{
+		"fewer fields":            {newRecord("UInt64"), newBlock(t, "UInt64", "String")},
+		"more fields":             {newRecord("UInt64", "String"), newBlock(t, "UInt64")},
+		"NULL in non Nullable":    {newRecord("Nullable(UInt64)"), newBlock(t, "UInt64")},
+		"NULL in LowCardinality":  {newRecord("LowCardinality(Nullable(String))"), newBlock(t, "LowCardinality(String)")},
+		"string into number":      {newRecord("String"), newBlock(t, "UInt64")},
+		"timestamp into UUID":     {newRecord("DateTime('UTC')"), newBlock(t, "UUID")},
+		"fixed binary size":       {newRecord("FixedString(4)"), newBlock(t, "UUID")},
+		"list into scalar column": {newRecord("Array(UInt64)"), newBlock(t, "UInt64")},
+		"unknown field name": {newRecord("UInt64"), func() *proto.Block {
+			block := &proto.Block{}
+			require.NoError(t, block.AddColumn("other", "UInt64"))
+			return block
+		}()},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer tt.record.Release()
+			assert.Error(t, Append(&testBatch{block: tt.target}, tt.record))
+			// failed conversions leave the batch empty
+			assert.Equal(t, 0, tt.target.Rows())
+		})
+	}
+}
+
+// testRows returns the blocks of a result one by one
+type testRows struct {
+	driver.Rows
+	blocks []*proto.Block
+	closed bool
+}
+
+func (r *testRows) Block() *proto.Block {
+	return r.blocks[0]
+}
+
+func (r *testRows) NextBlock() bool {
+	if len(r.blocks) < 2 {
+		return false
+	}
+	r.blocks = r.blocks[1:]
+	return true
+}
+
+func (r *testRows) Err() error {
+	return nil
+}
+
+func (r *testRows) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	header := newBlock(t, "UInt64", "String")
+	rows := &testRows{blocks: []*proto.Block{header}}
+	for i := 0; i < 3; i++ {
+		block := newBlock(t, "UInt64", "String")
+		require.NoError(t, block.Append(uint64(i), "a"))
+		require.NoError(t, block.Append(uint64(i), "b"))
+		rows.blocks = append(rows.blocks, block)
+	}
+
+	reader, err := NewReader(mem, rows)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, []string{reader.Schema().Field(0).Name, reader.Schema().Field(1).Name})
+	var records int
+	for reader.Next() {
+		record := reader.Record()
+		assert.Equal(t, int64(2), record.NumRows())
+		assert.Equal(t, uint64(records), record.Column(0).(*array.Uint64).Value(0))
+		records++
+	}
+	require.NoError(t, reader.Err())
+	assert.Equal(t, 3, records)
+	reader.Release()
+	assert.True(t, rows.closed)
+}
@@ -0,0 +1,85 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
+)
+
+// Re-export chcol types and constructors so they can be used without importing lib/chcol
+
+type (
+	Variant = chcol.Variant
+	Dynamic = chcol.Dynamic
+	JSON    = chcol.JSON
+	Time    = chcol.Time
+
+	Interval     = chcol.Interval
+	IntervalUnit = chcol.IntervalUnit
+)
+
+const (
+	IntervalNanosecond  = chcol.IntervalNanosecond
+	IntervalMicrosecond = chcol.IntervalMicrosecond
+	IntervalMillisecond = chcol.IntervalMillisecond
+	IntervalSecond      = chcol.IntervalSecond
+	IntervalMinute      = chcol.IntervalMinute
+	IntervalHour        = chcol.IntervalHour
+	IntervalDay         = chcol.IntervalDay
+	IntervalWeek        = chcol.IntervalWeek
+	IntervalMonth       = chcol.IntervalMonth
+	IntervalQuarter     = chcol.IntervalQuarter
+	IntervalYear        = chcol.IntervalYear
+)
+
+// NewVariant creates a new Variant with the given value
+func NewVariant(v any) Variant {
+	return chcol.NewVariant(v)
+}
+
+// NewVariantWithType creates a new Variant with the given value and ClickHouse member type
+func NewVariantWithType(v any, chType string) Variant {
+	return chcol.NewVariantWithType(v, chType)
+}
+
+// NewDynamic creates a new Dynamic with the given value
+func NewDynamic(v any) Dynamic {
+	return chcol.NewDynamic(v)
+}
+
+// NewDynamicWithType creates a new Dynamic with the given value and ClickHouse type
+func NewDynamicWithType(v any, chType string) Dynamic {
+	return chcol.NewDynamicWithType(v, chType)
+}
+
+// NewJSON creates a new empty JSON value
+func NewJSON() *JSON {
+	return chcol.NewJSON()
+}
+
+// NewTime creates a new Time from a duration since midnight
+func NewTime(d time.Duration) Time {
+	return chcol.NewTime(d)
+}
+
+// NewInterval creates a new Interval of value units
+func NewInterval(value int64, unit IntervalUnit) Interval {
+	return chcol.NewInterval(value, unit)
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"sync/atomic"
 	"time"
@@ -32,10 +33,13 @@ import (
 	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type Conn = driver.Conn
 
+type QueryStats = driver.QueryStats
+
 type (
 	Progress      = proto.Progress
 	Exception     = proto.Exception
@@ -52,6 +56,8 @@ var (
 	ErrBindMixedParamsFormats    = errors.New("clickhouse [bind]: mixed named, numeric or positional parameters")
 	ErrAcquireConnNoAddress      = errors.New("clickhouse: no valid address supplied")
 	ErrServerUnexpectedData      = errors.New("code: 101, message: Unexpected packet Data received from client")
+	ErrSSHAuthOverHTTP           = errors.New("clickhouse: SSH key authentication is only supported by the native protocol")
+	ErrInsertFromTooLarge        = errors.New("clickhouse: InsertFrom data is too large to send over the native protocol")
 )
 
 type OpError struct {
@@ -78,27 +84,40 @@ func (e *OpError) Error() string {
 	return fmt.Sprintf("clickhouse [%s]: %s", e.Op, e.Err)
 }
 
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
 func Open(opt *Options) (driver.Conn, error) {
 	if opt == nil {
 		opt = &Options{}
 	}
 	o := opt.setDefaults()
 	conn := &clickhouse{
-		opt:  o,
-		idle: make(chan *connect, o.MaxIdleConns),
-		open: make(chan struct{}, o.MaxOpenConns),
-		exit: make(chan struct{}),
+		opt:   o,
+		idle:  make(chan *connect, o.MaxIdleConns),
+		open:  make(chan struct{}, o.MaxOpenConns),
+		exit:  make(chan struct{}),
+		stats: &poolStats{},
 	}
+	if o.LoadBalancer != nil && o.DialStrategy == nil {
+		conn.balancer = newBalancer(o)
+		conn.balancer.onEject = conn.drainHost
+	}
+	conn.metrics = o.telemetry.observePool(conn.Stats)
 	go conn.startAutoCloseIdleConnections()
 	return conn, nil
 }
 
 type clickhouse struct {
-	opt    *Options
-	idle   chan *connect
-	open   chan struct{}
-	exit   chan struct{}
-	connID int64
+	opt      *Options
+	idle     chan *connect
+	open     chan struct{}
+	exit     chan struct{}
+	connID   int64
+	stats    *poolStats
+	balancer *balancer
+	metrics  metric.Registration // pool usage reported to the MeterProvider
 }
 
 func (clickhouse) Contributors() []string {
@@ -123,44 +142,68 @@ func (ch *clickhouse) ServerVersion() (*driver.ServerVersion, error) {
 }
 
 func (ch *clickhouse) Query(ctx context.Context, query string, args ...any) (rows driver.Rows, err error) {
-	conn, err := ch.acquire(ctx)
+	err = ch.retry(ctx, func() error {
+		conn, err := ch.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		conn.debugf("[acquired] connection [%d]", conn.id)
+		rows, err = conn.query(ctx, ch.release, query, args...)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	conn.debugf("[acquired] connection [%d]", conn.id)
-	return conn.query(ctx, ch.release, query, args...)
+	return rows, nil
 }
 
 func (ch *clickhouse) QueryRow(ctx context.Context, query string, args ...any) (rows driver.Row) {
-	conn, err := ch.acquire(ctx)
-	if err != nil {
-		return &row{
-			err: err,
+	var result *row
+	ch.retry(ctx, func() error {
+		conn, err := ch.acquire(ctx)
+		if err != nil {
+			result = &row{
+				err: err,
+			}
+			return err
 		}
-	}
-	conn.debugf("[acquired] connection [%d]", conn.id)
-	return conn.queryRow(ctx, ch.release, query, args...)
+		conn.debugf("[acquired] connection [%d]", conn.id)
+		result = conn.queryRow(ctx, ch.release, query, args...)
+		return result.err
+	})
+	return result
 }
 
 func (ch *clickhouse) Exec(ctx context.Context, query string, args ...any) error {
-	conn, err := ch.acquire(ctx)
-	if err != nil {
-		return err
-	}
-	if err := conn.exec(ctx, query, args...); err != nil {
-		ch.release(conn, err)
-		return err
-	}
-	ch.release(conn, nil)
-	return nil
+	_, err := ch.ExecWithStats(ctx, query, args...)
+	return err
 }
 
-func (ch *clickhouse) PrepareBatch(ctx context.Context, query string, opts ...driver.PrepareBatchOption) (driver.Batch, error) {
-	conn, err := ch.acquire(ctx)
-	if err != nil {
-		return nil, err
-	}
-	batch, err := conn.prepareBatch(ctx, query, getPrepareBatchOptions(opts...), ch.release, ch.acquire)
+func (ch *clickhouse) ExecWithStats(ctx context.Context, query string, args ...any) (stats QueryStats, err error) {
+	err = ch.retry(ctx, func() error {
+		conn, err := ch.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		if stats, err = conn.execWithStats(ctx, query, args...); err != nil {
+			ch.release(conn, err)
+			return err
+		}
+		ch.release(conn, nil)
+		return nil
+	})
+	return stats, err
+}
+
+func (ch *clickhouse) PrepareBatch(ctx context.Context, query string, opts ...driver.PrepareBatchOption) (batch driver.Batch, err error) {
+	err = ch.retry(ctx, func() error {
+		conn, err := ch.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		batch, err = conn.prepareBatch(ctx, query, getPrepareBatchOptions(opts...), ch.release, ch.acquire)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -190,6 +233,27 @@ func (ch *clickhouse) AsyncInsert(ctx context.Context, query string, wait bool,
 	return nil
 }
 
+var (
+	_ driver.StatsExecer = (*clickhouse)(nil)
+	_ driver.RawInserter = (*clickhouse)(nil)
+)
+
+func (ch *clickhouse) InsertFrom(ctx context.Context, query string, reader io.Reader) error {
+	conn, err := ch.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	if err := conn.insertFrom(ctx, query, reader); err != nil {
+		ch.release(conn, err)
+		return &OpError{
+			Op:  "InsertFrom",
+			Err: err,
+		}
+	}
+	ch.release(conn, nil)
+	return nil
+}
+
 func (ch *clickhouse) Ping(ctx context.Context) (err error) {
 	conn, err := ch.acquire(ctx)
 	if err != nil {
@@ -204,26 +268,38 @@ func (ch *clickhouse) Ping(ctx context.Context) (err error) {
 }
 
 func (ch *clickhouse) Stats() driver.Stats {
-	return driver.Stats{
+	stats := driver.Stats{
 		Open:         len(ch.open),
 		Idle:         len(ch.idle),
 		MaxOpenConns: cap(ch.open),
 		MaxIdleConns: cap(ch.idle),
 	}
+	ch.stats.fill(&stats)
+	return stats
 }
 
 func (ch *clickhouse) dial(ctx context.Context) (conn *connect, err error) {
 	connID := int(atomic.AddInt64(&ch.connID, 1))
 
 	dialFunc := func(ctx context.Context, addr string, opt *Options) (DialResult, error) {
+		start := time.Now()
 		conn, err := dial(ctx, addr, connID, opt)
+		ch.stats.dialed(addr, err)
+		if ch.opt.OnDial != nil {
+			ch.opt.OnDial(addr, time.Since(start), err)
+		}
 
 		return DialResult{conn}, err
 	}
 
 	dialStrategy := DefaultDialStrategy
-	if ch.opt.DialStrategy != nil {
+	switch {
+	case ch.opt.DialStrategy != nil:
 		dialStrategy = ch.opt.DialStrategy
+	case ch.balancer != nil:
+		dialStrategy = func(ctx context.Context, connID int, _ *Options, dial Dial) (DialResult, error) {
+			return ch.balancer.dial(ctx, connID, dial)
+		}
 	}
 
 	result, err := dialStrategy(ctx, connID, ch.opt, dialFunc)
@@ -259,6 +335,23 @@ func DefaultDialStrategy(ctx context.Context, connID int, opt *Options, dial Dia
 }
 
 func (ch *clickhouse) acquire(ctx context.Context) (conn *connect, err error) {
+	ctx, op := ch.opt.telemetry.start(ctx, operationAcquire, "")
+	start := time.Now()
+	conn, err = ch.acquireConn(ctx)
+	if errors.Is(err, ErrAcquireConnTimeout) {
+		ch.stats.acquireTimeouts.Add(1)
+	}
+	if conn != nil {
+		op.setServer(conn.addr)
+	}
+	op.end(err)
+	if ch.opt.OnAcquire != nil {
+		ch.opt.OnAcquire(time.Since(start), err)
+	}
+	return conn, err
+}
+
+func (ch *clickhouse) acquireConn(ctx context.Context) (conn *connect, err error) {
 	timer := time.NewTimer(ch.opt.DialTimeout)
 	defer timer.Stop()
 	select {
@@ -267,11 +360,20 @@ func (ch *clickhouse) acquire(ctx context.Context) (conn *connect, err error) {
 	default:
 	}
 	select {
-	case <-timer.C:
-		return nil, ErrAcquireConnTimeout
-	case <-ctx.Done():
-		return nil, ctx.Err()
 	case ch.open <- struct{}{}:
+	default:
+		// all connection slots are in use, wait for one to be released
+		waitStart := time.Now()
+		select {
+		case <-timer.C:
+			ch.stats.waited(time.Since(waitStart))
+			return nil, ErrAcquireConnTimeout
+		case <-ctx.Done():
+			ch.stats.waited(time.Since(waitStart))
+			return nil, ctx.Err()
+		case ch.open <- struct{}{}:
+			ch.stats.waited(time.Since(waitStart))
+		}
 	}
 	select {
 	case <-timer.C:
@@ -281,8 +383,15 @@ func (ch *clickhouse) acquire(ctx context.Context) (conn *connect, err error) {
 		}
 		return nil, ErrAcquireConnTimeout
 	case conn := <-ch.idle:
-		if conn.isBad() {
-			conn.close()
+		if conn.isBad() || !ch.healthy(conn) {
+			reason := ConnCloseBad
+			switch {
+			case !ch.healthy(conn):
+				reason = ConnCloseUnhealthy
+			case time.Since(conn.connectedAt) >= ch.opt.ConnMaxLifetime:
+				reason = ConnCloseMaxLifetime
+			}
+			ch.closeConn(conn, reason)
 			if conn, err = ch.dial(ctx); err != nil {
 				select {
 				case <-ch.open:
@@ -309,10 +418,19 @@ func (ch *clickhouse) startAutoCloseIdleConnections() {
 	ticker := time.NewTicker(ch.opt.ConnMaxLifetime)
 	defer ticker.Stop()
 
+	var probe <-chan time.Time
+	if ch.balancer != nil {
+		probeTicker := time.NewTicker(ch.balancer.ProbeInterval)
+		defer probeTicker.Stop()
+		probe = probeTicker.C
+	}
+
 	for {
 		select {
 		case <-ticker.C:
 			ch.closeIdleExpired()
+		case <-probe:
+			ch.balancer.probe()
 		case <-ch.exit:
 			return
 		}
@@ -325,12 +443,12 @@ func (ch *clickhouse) closeIdleExpired() {
 		select {
 		case conn := <-ch.idle:
 			if conn.connectedAt.Before(cutoff) {
-				conn.close()
+				ch.closeConn(conn, ConnCloseMaxLifetime)
 			} else {
 				select {
 				case ch.idle <- conn:
 				default:
-					conn.close()
+					ch.closeConn(conn, ConnCloseMaxIdle)
 				}
 				return
 			}
@@ -345,12 +463,31 @@ func (ch *clickhouse) release(conn *connect, err error) {
 		return
 	}
 	conn.released = true
+	if ch.opt.OnRelease != nil {
+		ch.opt.OnRelease(conn.id, err)
+	}
+	if ch.balancer != nil {
+		switch {
+		case err == nil:
+			ch.balancer.succeeded(conn.addr)
+		case ClassifyError(err) == ErrorClassTransient:
+			ch.balancer.failed(conn.addr)
+		}
+	}
 	select {
 	case <-ch.open:
 	default:
 	}
-	if err != nil || time.Since(conn.connectedAt) >= ch.opt.ConnMaxLifetime {
-		conn.close()
+	if err != nil {
+		ch.closeConn(conn, ConnCloseError)
+		return
+	}
+	if time.Since(conn.connectedAt) >= ch.opt.ConnMaxLifetime {
+		ch.closeConn(conn, ConnCloseMaxLifetime)
+		return
+	}
+	if !ch.healthy(conn) {
+		ch.closeConn(conn, ConnCloseUnhealthy)
 		return
 	}
 	if ch.opt.FreeBufOnConnRelease {
@@ -360,15 +497,55 @@ func (ch *clickhouse) release(conn *connect, err error) {
 	select {
 	case ch.idle <- conn:
 	default:
-		conn.close()
+		ch.closeConn(conn, ConnCloseMaxIdle)
+	}
+}
+
+// closeConn closes a connection of the pool, counting it under reason
+func (ch *clickhouse) closeConn(conn *connect, reason ConnCloseReason) {
+	conn.close()
+	ch.stats.closed(reason)
+	if ch.balancer != nil {
+		ch.balancer.closed(conn.addr)
+	}
+	if ch.opt.OnClose != nil {
+		ch.opt.OnClose(conn.id, reason)
+	}
+}
+
+// healthy reports whether the host of conn is not ejected by the load balancer
+func (ch *clickhouse) healthy(conn *connect) bool {
+	return ch.balancer == nil || ch.balancer.healthy(conn.addr)
+}
+
+// drainHost closes the idle connections to addr
+func (ch *clickhouse) drainHost(addr string) {
+	for i := len(ch.idle); i > 0; i-- {
+		select {
+		case conn := <-ch.idle:
+			if conn.addr == addr {
+				ch.closeConn(conn, ConnCloseUnhealthy)
+				continue
+			}
+			select {
+			case ch.idle <- conn:
+			default:
+				ch.closeConn(conn, ConnCloseMaxIdle)
+			}
+		default:
+			return
+		}
 	}
 }
 
 func (ch *clickhouse) Close() error {
+	if ch.metrics != nil {
+		ch.metrics.Unregister()
+	}
 	for {
 		select {
 		case c := <-ch.idle:
-			c.close()
+			ch.closeConn(c, ConnClosePoolClosed)
 		default:
 			ch.exit <- struct{}{}
 			return nil
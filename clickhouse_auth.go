@@ -0,0 +1,130 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strconv"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// jwtAuthMarker is sent as the user name when the password field holds a JSON Web Token
+	jwtAuthMarker = " JWT AUTHENTICATION "
+	// sshKeyAuthMarker prefixes the user name when the connection authenticates with an SSH key
+	sshKeyAuthMarker = " SSH KEY AUTHENTICATION "
+)
+
+// Authenticator supplies the credentials used when a new connection is established.
+// When Options.Authenticator is set the user name and password of Options.Auth are ignored,
+// Options.Auth.Database is still used.
+type Authenticator interface {
+	credentials(ctx context.Context) (*credentials, error)
+}
+
+type credentials struct {
+	username string
+	password string
+	token    string
+	signer   ssh.Signer
+}
+
+type passwordAuth struct {
+	username string
+	password string
+}
+
+// PasswordAuth authenticates with a static user name and password, it's the equivalent of setting Options.Auth.
+func PasswordAuth(username, password string) Authenticator {
+	return &passwordAuth{username: username, password: password}
+}
+
+func (a *passwordAuth) credentials(context.Context) (*credentials, error) {
+	return &credentials{username: a.username, password: a.password}, nil
+}
+
+type tokenAuth struct {
+	provider func(ctx context.Context) (string, error)
+}
+
+// TokenAuth authenticates with a JSON Web Token. The provider is called every time a connection is
+// established, so it can refresh an expired token before the pool reconnects.
+func TokenAuth(provider func(ctx context.Context) (string, error)) Authenticator {
+	return &tokenAuth{provider: provider}
+}
+
+func (a *tokenAuth) credentials(ctx context.Context) (*credentials, error) {
+	token, err := a.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(token) == 0 {
+		return nil, errors.New("clickhouse: token provider returned an empty token")
+	}
+	return &credentials{token: token}, nil
+}
+
+type sshKeyAuth struct {
+	username string
+	signer   ssh.Signer
+}
+
+// SSHKeyAuth authenticates the user with an SSH private key, the server signs a challenge with it during the handshake.
+// It's only available over the native protocol.
+func SSHKeyAuth(username string, signer ssh.Signer) Authenticator {
+	return &sshKeyAuth{username: username, signer: signer}
+}
+
+func (a *sshKeyAuth) credentials(context.Context) (*credentials, error) {
+	if a.signer == nil {
+		return nil, errors.New("clickhouse: SSH key authenticator requires a signer")
+	}
+	return &credentials{username: a.username, signer: a.signer}, nil
+}
+
+func (o *Options) credentials(ctx context.Context) (*credentials, error) {
+	if o.Authenticator == nil {
+		return &credentials{username: o.Auth.Username, password: o.Auth.Password}, nil
+	}
+	return o.Authenticator.credentials(ctx)
+}
+
+// sshChallengeMessage is the payload the server expects to be signed, see Connection::performHandshakeForSSHAuth
+func sshChallengeMessage(revision uint64, database, username, challenge string) []byte {
+	return []byte(strconv.FormatUint(revision, 10) + database + username + challenge)
+}
+
+func signSSHChallenge(signer ssh.Signer, message []byte) ([]byte, error) {
+	var (
+		signature *ssh.Signature
+		err       error
+	)
+	// prefer SHA-512 for RSA keys, the legacy ssh-rsa (SHA-1) signature is rejected by recent servers
+	if as, ok := signer.(ssh.AlgorithmSigner); ok && signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		signature, err = as.SignWithAlgorithm(rand.Reader, message, ssh.KeyAlgoRSASHA512)
+	} else {
+		signature, err = signer.Sign(rand.Reader, message)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ssh.Marshal(signature), nil
+}
@@ -0,0 +1,260 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	chproto "github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+type fakeHello struct {
+	revision uint64
+	database string
+	username string
+	password string
+	// signature is set when the client answered an SSH challenge
+	signature *ssh.Signature
+	challenge string
+	// addendum is set when the client sent the addendum that follows the server hello
+	addendum bool
+	quotaKey string
+}
+
+// serveFakeHandshake accepts a single native connection, records the client hello and answers it
+// with a server hello of the given revision.
+func serveFakeHandshake(t *testing.T, revision uint64) (string, <-chan fakeHello) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	hellos := make(chan fakeHello, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var (
+			hello  fakeHello
+			reader = chproto.NewReader(conn)
+			buffer = new(chproto.Buffer)
+		)
+		defer func() { hellos <- hello }()
+		if packet, err := reader.ReadByte(); err != nil || packet != proto.ClientHello {
+			return
+		}
+		reader.Str()     // client name
+		reader.UVarInt() // major
+		reader.UVarInt() // minor
+		hello.revision, _ = reader.UVarInt()
+		hello.database, _ = reader.Str()
+		hello.username, _ = reader.Str()
+		hello.password, _ = reader.Str()
+
+		if strings.HasPrefix(hello.username, sshKeyAuthMarker) {
+			if packet, err := reader.ReadByte(); err != nil || packet != proto.ClientSSHChallengeRequest {
+				return
+			}
+			hello.challenge = "challenge"
+			buffer.PutByte(proto.ServerSSHChallenge)
+			buffer.PutString(hello.challenge)
+			if _, err := conn.Write(buffer.Buf); err != nil {
+				return
+			}
+			buffer.Reset()
+			if packet, err := reader.ReadByte(); err != nil || packet != proto.ClientSSHChallengeResponse {
+				return
+			}
+			blob, err := reader.Str()
+			if err != nil {
+				return
+			}
+			var signature ssh.Signature
+			if err := ssh.Unmarshal([]byte(blob), &signature); err != nil {
+				return
+			}
+			hello.signature = &signature
+		}
+
+		if revision > hello.revision {
+			revision = hello.revision
+		}
+		buffer.PutByte(proto.ServerHello)
+		buffer.PutString("ClickHouse")
+		buffer.PutUVarInt(24)
+		buffer.PutUVarInt(8)
+		buffer.PutUVarInt(revision)
+		buffer.PutString("UTC")
+		buffer.PutString("fake")
+		buffer.PutUVarInt(1)
+		if revision >= proto.DBMS_MIN_PROTOCOL_VERSION_WITH_PASSWORD_COMPLEXITY_RULES {
+			buffer.PutUVarInt(1)
+			buffer.PutString(".{12}")
+			buffer.PutString("at least 12 characters")
+		}
+		if revision >= proto.DBMS_MIN_REVISION_WITH_INTERSERVER_SECRET_V2 {
+			buffer.PutUInt64(42)
+		}
+		if _, err := conn.Write(buffer.Buf); err != nil {
+			return
+		}
+		if revision >= proto.DBMS_MIN_PROTOCOL_VERSION_WITH_QUOTA_KEY {
+			hello.quotaKey, err = reader.Str()
+			hello.addendum = err == nil
+		}
+	}()
+	return listener.Addr().String(), hellos
+}
+
+func dialFakeHandshake(t *testing.T, revision uint64, auth Authenticator) (*connect, fakeHello) {
+	return dialFakeHandshakeWithOptions(t, revision, Options{
+		Auth:          Auth{Database: "db", Username: "default", Password: "secret"},
+		Authenticator: auth,
+	})
+}
+
+func dialFakeHandshakeWithOptions(t *testing.T, revision uint64, options Options) (*connect, fakeHello) {
+	addr, hellos := serveFakeHandshake(t, revision)
+	options.DialTimeout = 5 * time.Second
+	options.ReadTimeout = 5 * time.Second
+	opt := options.setDefaults()
+	conn, err := dial(context.Background(), addr, 1, opt)
+	require.NoError(t, err)
+	require.NoError(t, conn.close())
+	return conn, <-hellos
+}
+
+func TestNativePasswordAuth(t *testing.T) {
+	_, hello := dialFakeHandshake(t, proto.DBMS_TCP_PROTOCOL_VERSION, nil)
+	assert.Equal(t, "db", hello.database)
+	assert.Equal(t, "default", hello.username)
+	assert.Equal(t, "secret", hello.password)
+
+	_, hello = dialFakeHandshake(t, proto.DBMS_TCP_PROTOCOL_VERSION, PasswordAuth("alice", "pass"))
+	assert.Equal(t, "db", hello.database)
+	assert.Equal(t, "alice", hello.username)
+	assert.Equal(t, "pass", hello.password)
+}
+
+func TestNativeTokenAuth(t *testing.T) {
+	var calls atomic.Int32
+	auth := TokenAuth(func(ctx context.Context) (string, error) {
+		return fmt.Sprintf("token-%d", calls.Add(1)), nil
+	})
+	for i := 1; i <= 2; i++ {
+		_, hello := dialFakeHandshake(t, proto.DBMS_TCP_PROTOCOL_VERSION, auth)
+		assert.Equal(t, jwtAuthMarker, hello.username)
+		// every new connection asks the provider for a fresh token
+		assert.Equal(t, fmt.Sprintf("token-%d", i), hello.password)
+	}
+
+	_, err := TokenAuth(func(ctx context.Context) (string, error) {
+		return "", fmt.Errorf("expired")
+	}).credentials(context.Background())
+	assert.EqualError(t, err, "expired")
+}
+
+func TestNativeSSHKeyAuth(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	for name, key := range map[string]any{"ed25519": ed25519Key, "rsa": rsaKey} {
+		t.Run(name, func(t *testing.T) {
+			signer, err := ssh.NewSignerFromKey(key)
+			require.NoError(t, err)
+			conn, hello := dialFakeHandshake(t, proto.DBMS_MIN_REVISION_WITH_SSH_AUTHENTICATION, SSHKeyAuth("alice", signer))
+			assert.Equal(t, uint64(proto.DBMS_MIN_REVISION_WITH_SSH_AUTHENTICATION), hello.revision)
+			assert.Equal(t, uint64(proto.DBMS_MIN_REVISION_WITH_SSH_AUTHENTICATION), conn.revision)
+			assert.Equal(t, sshKeyAuthMarker+"alice", hello.username)
+			assert.Empty(t, hello.password)
+			require.NotNil(t, hello.signature)
+			message := sshChallengeMessage(hello.revision, "db", "alice", hello.challenge)
+			assert.NoError(t, signer.PublicKey().Verify(message, hello.signature))
+			if name == "rsa" {
+				assert.Equal(t, ssh.KeyAlgoRSASHA512, hello.signature.Format)
+			}
+		})
+	}
+}
+
+func TestNativeSSHKeyAuthOldServer(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	// the client downgrades to the revision announced by the server
+	conn, hello := dialFakeHandshake(t, proto.DBMS_TCP_PROTOCOL_VERSION, SSHKeyAuth("alice", signer))
+	require.NotNil(t, hello.signature)
+	assert.Equal(t, uint64(proto.DBMS_TCP_PROTOCOL_VERSION), conn.revision)
+}
+
+func TestHttpTokenAuth(t *testing.T) {
+	var authorization atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r.Header.Get("Authorization"))
+		_, user, _ := r.BasicAuth()
+		assert.Empty(t, user)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("Code: 516. DB::Exception: invalid token. (AUTHENTICATION_FAILED)"))
+	}))
+	defer server.Close()
+
+	var calls atomic.Int32
+	opt := (&Options{
+		Protocol: HTTP,
+		Auth:     Auth{Username: "default", Password: "secret"},
+		Authenticator: TokenAuth(func(ctx context.Context) (string, error) {
+			return fmt.Sprintf("token-%d", calls.Add(1)), nil
+		}),
+	}).setDefaults()
+	addr := strings.TrimPrefix(server.URL, "http://")
+	for i := 1; i <= 2; i++ {
+		_, err := dialHttp(context.Background(), addr, 1, opt)
+		require.Error(t, err)
+		assert.Equal(t, fmt.Sprintf("Bearer token-%d", i), authorization.Load())
+	}
+}
+
+func TestHttpSSHKeyAuth(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	opt := (&Options{
+		Protocol:      HTTP,
+		Authenticator: SSHKeyAuth("alice", signer),
+	}).setDefaults()
+	_, err = dialHttp(context.Background(), "127.0.0.1:1", 1, opt)
+	assert.ErrorIs(t, err, ErrSSHAuthOverHTTP)
+}
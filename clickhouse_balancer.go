@@ -0,0 +1,314 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LoadBalancePolicy orders the healthy hosts of Options.Addr when the LoadBalancer opens a connection.
+type LoadBalancePolicy uint8
+
+const (
+	LoadBalanceOpenStrategy     LoadBalancePolicy = iota // in the order of Options.ConnOpenStrategy
+	LoadBalancePreferLocal                               // hosts of this machine first, then by ConnOpenStrategy
+	LoadBalanceLeastConnections                          // hosts with the fewest open connections first
+	LoadBalanceLowestLatency                             // hosts that opened connections the fastest first
+)
+
+// LoadBalancer spreads the connections of Open over Options.Addr and keeps track of the health of every host.
+// A host with MaxFailures consecutive dial or transient query failures is ejected: its pooled connections are
+// closed and it is dialed only after every healthy host failed to open a connection, which includes the case
+// of all hosts being ejected. After EjectionTime it is probed by opening a connection and pinging the server,
+// and becomes healthy again if that succeeds.
+// The LoadBalancer is not used when Options.DialStrategy is set.
+type LoadBalancer struct {
+	Policy        LoadBalancePolicy
+	MaxFailures   int           // default 3
+	EjectionTime  time.Duration // default 30 second
+	ProbeInterval time.Duration // default 5 second - how often ejected hosts are checked, and latencies refreshed
+}
+
+func (lb LoadBalancer) setDefaults() LoadBalancer {
+	if lb.MaxFailures <= 0 {
+		lb.MaxFailures = 3
+	}
+	if lb.EjectionTime <= 0 {
+		lb.EjectionTime = 30 * time.Second
+	}
+	if lb.ProbeInterval <= 0 {
+		lb.ProbeInterval = 5 * time.Second
+	}
+	return lb
+}
+
+// hostHealth is the state of one address of Options.Addr
+type hostHealth struct {
+	addr      string
+	failures  int // consecutive failures
+	ejected   bool
+	ejectedAt time.Time
+	conns     int           // open connections
+	latency   time.Duration // moving average of the time it takes to open a connection
+	local     bool
+}
+
+type balancer struct {
+	LoadBalancer
+	opt       *Options
+	mutex     sync.Mutex
+	hosts     []*hostHealth
+	localOnce sync.Once
+	onEject   func(addr string)
+}
+
+func newBalancer(opt *Options) *balancer {
+	b := &balancer{
+		LoadBalancer: opt.LoadBalancer.setDefaults(),
+		opt:          opt,
+	}
+	for _, addr := range opt.Addr {
+		b.hosts = append(b.hosts, &hostHealth{
+			addr: addr,
+		})
+	}
+	return b
+}
+
+// host returns the state of addr, or nil if it is not in Options.Addr. The mutex must be held.
+func (b *balancer) host(addr string) *hostHealth {
+	for _, h := range b.hosts {
+		if h.addr == addr {
+			return h
+		}
+	}
+	return nil
+}
+
+// order returns the addresses to dial, the healthy hosts by policy followed by the ejected ones
+func (b *balancer) order(ctx context.Context, connID int) []string {
+	if b.Policy == LoadBalancePreferLocal {
+		b.localOnce.Do(func() {
+			b.resolveLocal(ctx)
+		})
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	var (
+		random = rand.Int()
+		hosts  = make([]*hostHealth, 0, len(b.hosts))
+	)
+	for i := range b.hosts {
+		var num int
+		switch b.opt.ConnOpenStrategy {
+		case ConnOpenInOrder:
+			num = i
+		case ConnOpenRoundRobin:
+			num = (connID + i) % len(b.hosts)
+		case ConnOpenRandom:
+			num = (random + i) % len(b.hosts)
+		}
+		hosts = append(hosts, b.hosts[num])
+	}
+	sort.SliceStable(hosts, func(i, j int) bool {
+		a, c := hosts[i], hosts[j]
+		if a.ejected != c.ejected {
+			return !a.ejected
+		}
+		switch b.Policy {
+		case LoadBalancePreferLocal:
+			return a.local && !c.local
+		case LoadBalanceLeastConnections:
+			return a.conns < c.conns
+		case LoadBalanceLowestLatency:
+			return a.latency < c.latency
+		}
+		return false
+	})
+	addrs := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		addrs = append(addrs, h.addr)
+	}
+	return addrs
+}
+
+// resolveLocal marks the hosts that resolve to an address of this machine
+func (b *balancer) resolveLocal(ctx context.Context) {
+	hostname, _ := os.Hostname()
+	local := make(map[string]bool)
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				local[ipNet.IP.String()] = true
+			}
+		}
+	}
+	for _, h := range b.hosts {
+		host, _, err := net.SplitHostPort(h.addr)
+		if err != nil {
+			host = h.addr
+		}
+		if host == "localhost" || (hostname != "" && host == hostname) {
+			h.local = true
+			continue
+		}
+		ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			continue
+		}
+		for _, ip := range ips {
+			if ip.IP.IsLoopback() || local[ip.IP.String()] {
+				h.local = true
+				break
+			}
+		}
+	}
+}
+
+// dial opens a connection to the first host in order that accepts it
+func (b *balancer) dial(ctx context.Context, connID int, dial Dial) (r DialResult, err error) {
+	for _, addr := range b.order(ctx, connID) {
+		start := time.Now()
+		if r, err = dial(ctx, addr, b.opt); err == nil {
+			b.opened(addr, time.Since(start))
+			return r, nil
+		}
+		if ctx.Err() != nil {
+			// the caller gave up, this says nothing about the host
+			return r, err
+		}
+		b.failed(addr)
+	}
+	if err == nil {
+		err = ErrAcquireConnNoAddress
+	}
+	return r, err
+}
+
+// opened records a new connection to addr, which makes the host healthy
+func (b *balancer) opened(addr string, latency time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if h := b.host(addr); h != nil {
+		h.conns++
+		h.recover(latency)
+	}
+}
+
+func (h *hostHealth) recover(latency time.Duration) {
+	h.failures, h.ejected = 0, false
+	if h.latency == 0 {
+		h.latency = latency
+		return
+	}
+	h.latency = (4*h.latency + latency) / 5
+}
+
+// failed records a failure of addr, and ejects the host once it failed MaxFailures times in a row
+func (b *balancer) failed(addr string) {
+	b.mutex.Lock()
+	h := b.host(addr)
+	if h == nil {
+		b.mutex.Unlock()
+		return
+	}
+	h.failures++
+	if h.ejected {
+		// restart the cool-down of a host that is still failing
+		h.ejectedAt = time.Now()
+		b.mutex.Unlock()
+		return
+	}
+	eject := h.failures >= b.MaxFailures
+	if eject {
+		h.ejected, h.ejectedAt = true, time.Now()
+	}
+	b.mutex.Unlock()
+	if eject && b.onEject != nil {
+		b.onEject(addr)
+	}
+}
+
+// succeeded records a successful query on addr
+func (b *balancer) succeeded(addr string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if h := b.host(addr); h != nil {
+		h.failures = 0
+	}
+}
+
+// closed records that a connection to addr was closed
+func (b *balancer) closed(addr string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if h := b.host(addr); h != nil && h.conns > 0 {
+		h.conns--
+	}
+}
+
+// healthy reports whether connections to addr can be used
+func (b *balancer) healthy(addr string) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	h := b.host(addr)
+	return h == nil || !h.ejected
+}
+
+// probe opens a connection to, and pings, every ejected host whose cool-down ended. With the lowest latency
+// policy the healthy hosts are probed as well, to keep their latency up to date.
+func (b *balancer) probe() {
+	b.mutex.Lock()
+	var addrs []string
+	for _, h := range b.hosts {
+		if (h.ejected && time.Since(h.ejectedAt) >= b.EjectionTime) || (!h.ejected && b.Policy == LoadBalanceLowestLatency) {
+			addrs = append(addrs, h.addr)
+		}
+	}
+	b.mutex.Unlock()
+	for _, addr := range addrs {
+		latency, err := b.probeHost(addr)
+		b.mutex.Lock()
+		if h := b.host(addr); h != nil && err == nil {
+			h.recover(latency)
+		}
+		b.mutex.Unlock()
+		if err != nil {
+			b.failed(addr)
+		}
+	}
+}
+
+func (b *balancer) probeHost(addr string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.opt.DialTimeout)
+	defer cancel()
+	start := time.Now()
+	conn, err := dial(ctx, addr, 0, b.opt)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.close()
+	latency := time.Since(start)
+	return latency, conn.ping(ctx)
+}
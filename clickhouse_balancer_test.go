@@ -0,0 +1,119 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBalancer(policy LoadBalancePolicy, addrs ...string) *balancer {
+	return newBalancer(&Options{
+		Addr: addrs,
+		LoadBalancer: &LoadBalancer{
+			Policy:       policy,
+			MaxFailures:  2,
+			EjectionTime: time.Hour,
+		},
+	})
+}
+
+func TestBalancerEjection(t *testing.T) {
+	b := newTestBalancer(LoadBalanceOpenStrategy, "a:9000", "b:9000", "c:9000")
+	var ejected []string
+	b.onEject = func(addr string) {
+		ejected = append(ejected, addr)
+	}
+	var dialed []string
+	dial := func(ctx context.Context, addr string, opt *Options) (DialResult, error) {
+		dialed = append(dialed, addr)
+		if addr == "a:9000" {
+			return DialResult{}, errors.New("connection refused")
+		}
+		return DialResult{}, nil
+	}
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, err := b.dial(ctx, 1, dial)
+		require.NoError(t, err)
+	}
+	// a is dialed until it failed MaxFailures times, then skipped
+	assert.Equal(t, []string{"a:9000", "b:9000", "a:9000", "b:9000", "b:9000"}, dialed)
+	assert.Equal(t, []string{"a:9000"}, ejected)
+	assert.False(t, b.healthy("a:9000"))
+	assert.Equal(t, []string{"b:9000", "c:9000", "a:9000"}, b.order(ctx, 1))
+
+	// transient query failures eject a host as well, successes reset the count
+	b.failed("b:9000")
+	b.succeeded("b:9000")
+	b.failed("b:9000")
+	assert.True(t, b.healthy("b:9000"))
+	b.failed("b:9000")
+	assert.False(t, b.healthy("b:9000"))
+	assert.Equal(t, []string{"c:9000", "a:9000", "b:9000"}, b.order(ctx, 1))
+
+	// a host is healthy again once a connection is opened to it
+	b.opened("a:9000", time.Millisecond)
+	assert.True(t, b.healthy("a:9000"))
+}
+
+func TestBalancerPolicies(t *testing.T) {
+	ctx := context.Background()
+
+	b := newTestBalancer(LoadBalanceLeastConnections, "a:9000", "b:9000", "c:9000")
+	b.opened("a:9000", time.Millisecond)
+	b.opened("a:9000", time.Millisecond)
+	b.opened("b:9000", time.Millisecond)
+	b.opened("c:9000", time.Millisecond)
+	b.closed("c:9000")
+	assert.Equal(t, []string{"c:9000", "b:9000", "a:9000"}, b.order(ctx, 1))
+
+	b = newTestBalancer(LoadBalanceLowestLatency, "a:9000", "b:9000", "c:9000")
+	b.opened("a:9000", 30*time.Millisecond)
+	b.opened("b:9000", 10*time.Millisecond)
+	b.opened("c:9000", 20*time.Millisecond)
+	assert.Equal(t, []string{"b:9000", "c:9000", "a:9000"}, b.order(ctx, 1))
+
+	b = newTestBalancer(LoadBalancePreferLocal, "192.0.2.1:9000", "127.0.0.1:9000", "localhost:9000")
+	assert.Equal(t, []string{"127.0.0.1:9000", "localhost:9000", "192.0.2.1:9000"}, b.order(ctx, 1))
+}
+
+func TestDrainHost(t *testing.T) {
+	ch := &clickhouse{
+		opt:   &Options{Addr: []string{"a:9000", "b:9000"}, LoadBalancer: &LoadBalancer{}},
+		idle:  make(chan *connect, 4),
+		stats: &poolStats{},
+	}
+	ch.balancer = newBalancer(ch.opt)
+	for i, addr := range []string{"a:9000", "b:9000", "a:9000"} {
+		client, server := net.Pipe()
+		defer server.Close()
+		ch.balancer.opened(addr, time.Millisecond)
+		ch.idle <- &connect{id: i, addr: addr, conn: client}
+	}
+	ch.drainHost("a:9000")
+	require.Len(t, ch.idle, 1)
+	assert.Equal(t, "b:9000", (<-ch.idle).addr)
+	assert.Equal(t, int64(2), ch.Stats().UnhealthyClosed)
+}
@@ -30,6 +30,8 @@ import (
 
 	"github.com/ClickHouse/ch-go/compress"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type CompressionMethod byte
@@ -131,6 +133,7 @@ type Options struct {
 	TLS                  *tls.Config
 	Addr                 []string
 	Auth                 Auth
+	Authenticator        Authenticator // overrides the user name and password of Auth, see PasswordAuth, TokenAuth and SSHKeyAuth
 	DialContext          func(ctx context.Context, addr string) (net.Conn, error)
 	DialStrategy         func(ctx context.Context, connID int, options *Options, dial Dial) (DialResult, error)
 	Debug                bool
@@ -145,12 +148,46 @@ type Options struct {
 	FreeBufOnConnRelease bool              // drop preserved memory buffer after each query
 	HttpHeaders          map[string]string // set additional headers on HTTP requests
 	HttpUrlPath          string            // set additional URL path for HTTP requests
+	HttpSession          bool              // give each HTTP connection a server session, so temporary tables and SET persist
+	HttpSessionTimeout   time.Duration     // default 60 second - server side timeout of idle HTTP sessions
+	QuotaKey             string            // connection level quota key, WithQuotaKey overrides it per query
 	BlockBufferSize      uint8             // default 2 - can be overwritten on query
 	MaxCompressionBuffer int               // default 10485760 - measured in bytes  i.e.
+	MaxInsertFromSize    int64             // default 67108864 - most bytes InsertFrom buffers over the native protocol, which can't stream them
 
 	// HTTPProxy specifies an HTTP proxy URL to use for requests made by the client.
 	HTTPProxyURL *url.URL
 
+	// Transactions makes database/sql transactions use ClickHouse transactions: BeginTx issues BEGIN TRANSACTION,
+	// and Commit and Rollback issue COMMIT and ROLLBACK. Requires a server with experimental transactions enabled,
+	// which are only supported by MergeTree tables. HTTP connections use a session per connection.
+	Transactions bool
+
+	// Connection pool hooks of connections opened with Open, e.g. to export pool metrics. They are called
+	// synchronously by the pool, so they must be fast and must not use the connection.
+	OnDial    func(addr string, duration time.Duration, err error) // after every connection attempt
+	OnAcquire func(duration time.Duration, err error)              // after a connection is taken from the pool, or acquiring failed
+	OnRelease func(connID int, err error)                          // when a connection is returned to the pool, with the error of its last operation
+	OnClose   func(connID int, reason ConnCloseReason)             // when the pool closes a connection
+
+	// RetryPolicy retries failed queries, execs and batch sends on a new connection. Disabled when nil,
+	// and can be overridden per query with WithRetryPolicy.
+	RetryPolicy *RetryPolicy
+
+	// LoadBalancer tracks the health of the hosts of Addr, ejecting failing ones, and orders them by its policy.
+	// Disabled when nil.
+	LoadBalancer *LoadBalancer
+
+	// TracerProvider creates client side OpenTelemetry spans for acquire, dial, handshake, query, batch send and
+	// ping. Over the native protocol query spans are also sent to the server, as WithSpan does, unless the query
+	// sets its own span. Disabled when nil.
+	TracerProvider trace.TracerProvider
+	// MeterProvider records the duration of the same operations, and the connection pool usage of Open.
+	// Disabled when nil.
+	MeterProvider metric.MeterProvider
+
+	telemetry *telemetry
+
 	scheme      string
 	ReadTimeout time.Duration
 }
@@ -225,6 +262,12 @@ func (o *Options) fromDSN(in string) error {
 				return errors.Wrap(err, "max_compression_buffer invalid value")
 			}
 			o.MaxCompressionBuffer = max
+		case "max_insert_from_size":
+			max, err := strconv.ParseInt(params.Get(v), 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "max_insert_from_size invalid value")
+			}
+			o.MaxInsertFromSize = max
 		case "dial_timeout":
 			duration, err := time.ParseDuration(params.Get(v))
 			if err != nil {
@@ -308,6 +351,26 @@ func (o *Options) fromDSN(in string) error {
 					version,
 				})
 			}
+		case "http_session":
+			httpSession, err := strconv.ParseBool(params.Get(v))
+			if err != nil {
+				return errors.Wrap(err, "http_session invalid value")
+			}
+			o.HttpSession = httpSession
+		case "http_session_timeout":
+			duration, err := time.ParseDuration(params.Get(v))
+			if err != nil {
+				return fmt.Errorf("clickhouse [dsn parse]: http session timeout: %s", err)
+			}
+			o.HttpSessionTimeout = duration
+		case "transactions":
+			transactions, err := strconv.ParseBool(params.Get(v))
+			if err != nil {
+				return errors.Wrap(err, "transactions invalid value")
+			}
+			o.Transactions = transactions
+		case "quota_key":
+			o.QuotaKey = params.Get(v)
 		case "http_proxy":
 			proxyURL, err := url.Parse(params.Get(v))
 			if err != nil {
@@ -378,6 +441,12 @@ func (o Options) setDefaults() *Options {
 	if o.MaxCompressionBuffer <= 0 {
 		o.MaxCompressionBuffer = 10485760
 	}
+	if o.MaxInsertFromSize <= 0 {
+		o.MaxInsertFromSize = 64 << 20
+	}
+	if o.telemetry == nil {
+		o.telemetry = newTelemetry(o.TracerProvider, o.MeterProvider)
+	}
 	if o.Addr == nil || len(o.Addr) == 0 {
 		switch o.Protocol {
 		case Native:
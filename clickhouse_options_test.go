@@ -403,6 +403,22 @@ func TestParseDSN(t *testing.T) {
 			},
 			"",
 		},
+		{
+			"native protocol with 1KiB max insert from size",
+			"clickhouse://127.0.0.1/test_database?max_insert_from_size=1024",
+			&Options{
+				Protocol:          Native,
+				TLS:               nil,
+				Addr:              []string{"127.0.0.1"},
+				Settings:          Settings{},
+				MaxInsertFromSize: 1024,
+				Auth: Auth{
+					Database: "test_database",
+				},
+				scheme: "clickhouse",
+			},
+			"",
+		},
 		{
 			"native protocol with invalid numeric max compression buffer",
 			"clickhouse://127.0.0.1/test_database?max_compression_buffer=onebyte",
@@ -482,6 +498,32 @@ func TestParseDSN(t *testing.T) {
 			},
 			"",
 		},
+		{
+			"native protocol with transactions",
+			"clickhouse://127.0.0.1/?transactions=true",
+			&Options{
+				Protocol:     Native,
+				TLS:          nil,
+				Addr:         []string{"127.0.0.1"},
+				Settings:     Settings{},
+				scheme:       "clickhouse",
+				Transactions: true,
+			},
+			"",
+		},
+		{
+			"native protocol with quota key",
+			"clickhouse://127.0.0.1/?quota_key=tenant_1",
+			&Options{
+				Protocol: Native,
+				TLS:      nil,
+				Addr:     []string{"127.0.0.1"},
+				Settings: Settings{},
+				scheme:   "clickhouse",
+				QuotaKey: "tenant_1",
+			},
+			"",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -0,0 +1,108 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+// ConnCloseReason is the reason the connection pool closed a connection, as passed to Options.OnClose.
+type ConnCloseReason string
+
+const (
+	ConnCloseBad         ConnCloseReason = "bad"          // an idle connection failed the health check on acquire
+	ConnCloseError       ConnCloseReason = "error"        // the connection was released after an error
+	ConnCloseMaxLifetime ConnCloseReason = "max_lifetime" // the connection exceeded ConnMaxLifetime
+	ConnCloseMaxIdle     ConnCloseReason = "max_idle"     // the idle pool was full
+	ConnClosePoolClosed  ConnCloseReason = "pool_closed"  // the pool was closed with Close
+	ConnCloseUnhealthy   ConnCloseReason = "unhealthy"    // the LoadBalancer ejected the host of the connection
+)
+
+// poolStats holds the connection pool counters reported by Stats
+type poolStats struct {
+	waitCount       atomic.Int64
+	waitDuration    atomic.Int64
+	acquireTimeouts atomic.Int64
+	dialCount       atomic.Int64
+
+	badClosed         atomic.Int64
+	errorClosed       atomic.Int64
+	maxLifetimeClosed atomic.Int64
+	maxIdleClosed     atomic.Int64
+	unhealthyClosed   atomic.Int64
+
+	dialFailuresMutex sync.Mutex
+	dialFailures      map[string]int64
+}
+
+func (s *poolStats) waited(d time.Duration) {
+	s.waitCount.Add(1)
+	s.waitDuration.Add(int64(d))
+}
+
+func (s *poolStats) dialed(addr string, err error) {
+	s.dialCount.Add(1)
+	if err == nil {
+		return
+	}
+	s.dialFailuresMutex.Lock()
+	defer s.dialFailuresMutex.Unlock()
+	if s.dialFailures == nil {
+		s.dialFailures = make(map[string]int64)
+	}
+	s.dialFailures[addr]++
+}
+
+func (s *poolStats) closed(reason ConnCloseReason) {
+	switch reason {
+	case ConnCloseBad:
+		s.badClosed.Add(1)
+	case ConnCloseError:
+		s.errorClosed.Add(1)
+	case ConnCloseMaxLifetime:
+		s.maxLifetimeClosed.Add(1)
+	case ConnCloseMaxIdle:
+		s.maxIdleClosed.Add(1)
+	case ConnCloseUnhealthy:
+		s.unhealthyClosed.Add(1)
+	}
+}
+
+// fill copies the counters into stats
+func (s *poolStats) fill(stats *driver.Stats) {
+	stats.WaitCount = s.waitCount.Load()
+	stats.WaitDuration = time.Duration(s.waitDuration.Load())
+	stats.AcquireTimeouts = s.acquireTimeouts.Load()
+	stats.DialCount = s.dialCount.Load()
+	stats.BadClosed = s.badClosed.Load()
+	stats.ErrorClosed = s.errorClosed.Load()
+	stats.MaxLifetimeClosed = s.maxLifetimeClosed.Load()
+	stats.MaxIdleClosed = s.maxIdleClosed.Load()
+	stats.UnhealthyClosed = s.unhealthyClosed.Load()
+
+	s.dialFailuresMutex.Lock()
+	defer s.dialFailuresMutex.Unlock()
+	stats.DialFailures = make(map[string]int64, len(s.dialFailures))
+	for addr, count := range s.dialFailures {
+		stats.DialFailures[addr] = count
+	}
+}
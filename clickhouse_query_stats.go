@@ -0,0 +1,161 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// queryStats sums the progress, profile info and profile events of a query into its QueryStats. The packets of a
+// query are handled by the goroutine that reads its result, so the stats are guarded by a mutex.
+type queryStats struct {
+	mutex    sync.Mutex
+	stats    QueryStats
+	started  time.Time
+	finished time.Time
+	profiled bool
+}
+
+func newQueryStats() *queryStats {
+	return &queryStats{
+		started: time.Now(),
+	}
+}
+
+// reset drops the statistics of a failed attempt before a query is resent
+func (s *queryStats) reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stats, s.finished, s.profiled = QueryStats{}, time.Time{}, false
+	s.started = time.Now()
+}
+
+func (s *queryStats) progress(p *Progress) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stats.ReadRows += p.Rows
+	s.stats.ReadBytes += p.Bytes
+	s.stats.WrittenRows += p.WroteRows
+	s.stats.WrittenBytes += p.WroteBytes
+	// the server sends the time elapsed since its last progress packet
+	s.stats.Elapsed += p.Elapsed
+}
+
+func (s *queryStats) profileInfo(p *ProfileInfo) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stats.ResultRows += p.Rows
+	s.stats.ResultBytes += p.Bytes
+	s.profiled = true
+}
+
+// profileEvents sums the query level events, the server also sends the events of each of its threads, which are
+// already counted by the events of thread 0
+func (s *queryStats) profileEvents(events []ProfileEvent) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, event := range events {
+		if event.ThreadID != 0 {
+			continue
+		}
+		if s.stats.ProfileEvents == nil {
+			s.stats.ProfileEvents = make(map[string]int64)
+		}
+		switch event.Type {
+		case "gauge":
+			s.stats.ProfileEvents[event.Name] = event.Value
+		default:
+			s.stats.ProfileEvents[event.Name] += event.Value
+		}
+	}
+}
+
+// summary sets the statistics from the X-ClickHouse-Summary header of an HTTP response. A header that can't be
+// parsed leaves the statistics empty rather than failing the query.
+func (s *queryStats) summary(header string) {
+	summary, ok := parseHttpProgress(header)
+	if !ok {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stats = QueryStats{
+		ReadRows:     summary.ReadRows,
+		ReadBytes:    summary.ReadBytes,
+		WrittenRows:  summary.WrittenRows,
+		WrittenBytes: summary.WrittenBytes,
+		ResultRows:   summary.ResultRows,
+		ResultBytes:  summary.ResultBytes,
+		Elapsed:      time.Duration(summary.ElapsedNs),
+	}
+	s.profiled = true
+}
+
+// finish records the end of the query, for the elapsed time of servers that don't report it
+func (s *queryStats) finish() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.finished.IsZero() {
+		s.finished = time.Now()
+	}
+}
+
+// observe wraps the progress, profile info and profile events handlers of a query to sum them into s
+func (s *queryStats) observe(on *onProcess) {
+	progress, profileInfo, profileEvents := on.progress, on.profileInfo, on.profileEvents
+	on.progress = func(p *Progress) {
+		s.progress(p)
+		progress(p)
+	}
+	on.profileInfo = func(p *ProfileInfo) {
+		s.profileInfo(p)
+		profileInfo(p)
+	}
+	on.profileEvents = func(events []ProfileEvent) {
+		s.profileEvents(events)
+		profileEvents(events)
+	}
+}
+
+// Stats returns a copy of the statistics summed so far
+func (s *queryStats) Stats() QueryStats {
+	if s == nil {
+		return QueryStats{}
+	}
+	stats, _ := s.snapshot()
+	return stats
+}
+
+// snapshot returns a copy of the statistics and whether the server described the result of the query
+func (s *queryStats) snapshot() (QueryStats, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats := s.stats
+	stats.ProfileEvents = maps.Clone(s.stats.ProfileEvents)
+	if stats.Elapsed == 0 {
+		switch {
+		case s.finished.IsZero():
+			stats.Elapsed = time.Since(s.started)
+		default:
+			stats.Elapsed = s.finished.Sub(s.started)
+		}
+	}
+	return stats, s.profiled
+}
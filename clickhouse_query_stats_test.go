@@ -0,0 +1,110 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryStats(t *testing.T) {
+	stats := newQueryStats()
+	on := (&QueryOptions{}).onProcess()
+	stats.observe(on)
+	on.progress(&Progress{Rows: 10, Bytes: 100, Elapsed: time.Millisecond})
+	on.progress(&Progress{Rows: 5, Bytes: 50, WroteRows: 2, WroteBytes: 20, Elapsed: time.Millisecond})
+	on.profileInfo(&ProfileInfo{Rows: 3, Bytes: 24})
+	on.profileEvents([]ProfileEvent{
+		{ThreadID: 0, Type: "increment", Name: "SelectedRows", Value: 10},
+		{ThreadID: 42, Type: "increment", Name: "SelectedRows", Value: 10},
+		{ThreadID: 0, Type: "gauge", Name: "MemoryTrackerUsage", Value: 1024},
+	})
+	on.profileEvents([]ProfileEvent{
+		{ThreadID: 0, Type: "increment", Name: "SelectedRows", Value: 5},
+		{ThreadID: 0, Type: "gauge", Name: "MemoryTrackerUsage", Value: 512},
+	})
+	stats.finish()
+
+	result := stats.Stats()
+	assert.Equal(t, QueryStats{
+		ReadRows:     15,
+		ReadBytes:    150,
+		WrittenRows:  2,
+		WrittenBytes: 20,
+		ResultRows:   3,
+		ResultBytes:  24,
+		Elapsed:      2 * time.Millisecond,
+		ProfileEvents: map[string]int64{
+			"SelectedRows":       15,
+			"MemoryTrackerUsage": 512,
+		},
+	}, result)
+	result.ProfileEvents["SelectedRows"] = 0
+	assert.Equal(t, int64(15), stats.Stats().ProfileEvents["SelectedRows"])
+
+	stats.reset()
+	assert.Zero(t, stats.Stats().ReadRows)
+}
+
+func TestQueryStatsElapsed(t *testing.T) {
+	stats := newQueryStats()
+	time.Sleep(10 * time.Millisecond)
+	stats.finish()
+	elapsed := stats.Stats().Elapsed
+	assert.GreaterOrEqual(t, elapsed, 10*time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, elapsed, stats.Stats().Elapsed)
+
+	var empty *queryStats
+	assert.Equal(t, QueryStats{}, empty.Stats())
+}
+
+func TestQueryStatsSummary(t *testing.T) {
+	stats := newQueryStats()
+	stats.summary(`{"read_rows":"10","read_bytes":"80","written_rows":"10","written_bytes":"80","total_rows_to_read":"0","result_rows":"10","result_bytes":"80","elapsed_ns":"1500000"}`)
+	assert.Equal(t, QueryStats{
+		ReadRows:     10,
+		ReadBytes:    80,
+		WrittenRows:  10,
+		WrittenBytes: 80,
+		ResultRows:   10,
+		ResultBytes:  80,
+		Elapsed:      1500 * time.Microsecond,
+	}, stats.Stats())
+
+	stats = newQueryStats()
+	stats.summary("not json")
+	assert.Zero(t, stats.Stats().ReadRows)
+}
+
+func TestHttpExecWithStats(t *testing.T) {
+	conn := newTestHttpConnect(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-ClickHouse-Summary", `{"read_rows":"0","read_bytes":"0","written_rows":"3","written_bytes":"24","total_rows_to_read":"0","result_rows":"3","result_bytes":"24"}`)
+	})
+	stats, err := conn.execWithStats(context.Background(), "INSERT INTO t SELECT number FROM numbers(3)")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), stats.WrittenRows)
+	assert.Equal(t, uint64(24), stats.WrittenBytes)
+	assert.Equal(t, uint64(3), stats.ResultRows)
+	assert.NotZero(t, stats.Elapsed)
+}
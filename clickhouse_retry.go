@@ -0,0 +1,138 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultRetryBackoff    = 100 * time.Millisecond
+	defaultRetryMaxBackoff = 10 * time.Second
+)
+
+// RetryPolicy retries failed queries, execs and batch sends of connections opened with Open. Every attempt
+// acquires a new connection from the pool, which may be opened to another address of Options.Addr depending
+// on ConnOpenStrategy. Only use it for statements that are safe to run more than once.
+type RetryPolicy struct {
+	MaxAttempts int           // attempts including the first one, retries are disabled below 2
+	Backoff     time.Duration // default 100 millisecond - delay before the first retry, doubled for every next one
+	MaxBackoff  time.Duration // default 10 second - upper bound of the delay
+	Jitter      float64       // fraction of the delay, between 0 and 1, that is randomly added or removed
+	// Retryable decides whether an error is retried. Defaults to retrying transient errors, see ClassifyError.
+	Retryable func(err error, class ErrorClass) bool
+	// DeduplicationToken sets a random insert_deduplication_token on batches that don't have one, so the server
+	// drops the data of a retried batch it has already written. Non replicated tables also need the
+	// non_replicated_deduplication_window setting.
+	DeduplicationToken bool
+}
+
+// WithRetryPolicy overrides Options.RetryPolicy for a query, nil disables retries.
+func WithRetryPolicy(policy *RetryPolicy) QueryOption {
+	return func(o *QueryOptions) error {
+		o.retry.ok, o.retry.policy = true, policy
+		return nil
+	}
+}
+
+// retryPolicy returns the retry policy of a query, or nil if it should not be retried
+func retryPolicy(options *QueryOptions, opt *Options) *RetryPolicy {
+	policy := opt.RetryPolicy
+	if options.retry.ok {
+		policy = options.retry.policy
+	}
+	if policy == nil || policy.MaxAttempts < 2 {
+		return nil
+	}
+	return policy
+}
+
+func (p *RetryPolicy) retryable(err error) bool {
+	class := ClassifyError(err)
+	if p.Retryable != nil {
+		return p.Retryable(err, class)
+	}
+	return class == ErrorClassTransient
+}
+
+// delay returns how long to wait before the given retry, counted from 1
+func (p *RetryPolicy) delay(retry int) time.Duration {
+	backoff, maxBackoff := p.Backoff, p.MaxBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+	delay := backoff
+	for i := 1; i < retry && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	if p.Jitter > 0 {
+		delay += time.Duration(float64(delay) * p.Jitter * (2*rand.Float64() - 1))
+	}
+	return delay
+}
+
+// wait sleeps before the given retry if err is retryable, and reports whether to retry
+func (p *RetryPolicy) wait(ctx context.Context, retry int, err error) bool {
+	if p == nil || retry >= p.MaxAttempts || !p.retryable(err) {
+		return false
+	}
+	timer := time.NewTimer(p.delay(retry))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// retry calls fn until it succeeds or the retry policy of the query gives up
+func (ch *clickhouse) retry(ctx context.Context, fn func() error) error {
+	options := queryOptions(ctx)
+	policy := retryPolicy(&options, ch.opt)
+	for retry := 1; ; retry++ {
+		err := fn()
+		if err == nil || !policy.wait(ctx, retry, err) {
+			return err
+		}
+	}
+}
+
+// withDeduplicationToken sets a random insert_deduplication_token on the query, unless it already has one
+func withDeduplicationToken(ctx context.Context) context.Context {
+	options := queryOptions(ctx)
+	if _, ok := options.settings["insert_deduplication_token"]; ok {
+		return ctx
+	}
+	settings := make(Settings, len(options.settings)+1)
+	for k, v := range options.settings {
+		settings[k] = v
+	}
+	settings["insert_deduplication_token"] = uuid.NewString()
+	return Context(ctx, WithSettings(settings))
+}
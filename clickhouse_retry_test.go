@@ -0,0 +1,134 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts: 10,
+		Backoff:     10 * time.Millisecond,
+		MaxBackoff:  50 * time.Millisecond,
+	}
+	assert.Equal(t, 10*time.Millisecond, policy.delay(1))
+	assert.Equal(t, 20*time.Millisecond, policy.delay(2))
+	assert.Equal(t, 40*time.Millisecond, policy.delay(3))
+	assert.Equal(t, 50*time.Millisecond, policy.delay(4))
+	assert.Equal(t, 50*time.Millisecond, policy.delay(100))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.delay(1)
+		assert.GreaterOrEqual(t, delay, 5*time.Millisecond)
+		assert.LessOrEqual(t, delay, 15*time.Millisecond)
+	}
+
+	assert.Equal(t, defaultRetryBackoff, (&RetryPolicy{}).delay(1))
+}
+
+func TestRetryPolicyResolution(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3}
+	opt := &Options{RetryPolicy: policy}
+
+	options := queryOptions(context.Background())
+	assert.Same(t, policy, retryPolicy(&options, opt))
+
+	options = queryOptions(Context(context.Background(), WithRetryPolicy(nil)))
+	assert.Nil(t, retryPolicy(&options, opt))
+
+	override := &RetryPolicy{MaxAttempts: 5}
+	options = queryOptions(Context(context.Background(), WithRetryPolicy(override)))
+	assert.Same(t, override, retryPolicy(&options, opt))
+
+	options = queryOptions(context.Background())
+	assert.Nil(t, retryPolicy(&options, &Options{RetryPolicy: &RetryPolicy{MaxAttempts: 1}}))
+}
+
+func TestRetry(t *testing.T) {
+	ch := &clickhouse{
+		opt: &Options{
+			RetryPolicy: &RetryPolicy{
+				MaxAttempts: 3,
+				Backoff:     time.Millisecond,
+			},
+		},
+	}
+	var attempts int
+	err := ch.retry(context.Background(), func() error {
+		attempts++
+		return io.EOF
+	})
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 3, attempts)
+
+	// permanent errors are not retried
+	attempts = 0
+	err = ch.retry(context.Background(), func() error {
+		attempts++
+		return &Exception{Code: 62, Message: "Syntax error"}
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+
+	// the predicate decides when set
+	attempts = 0
+	ctx := Context(context.Background(), WithRetryPolicy(&RetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     time.Millisecond,
+		Retryable: func(err error, class ErrorClass) bool {
+			return class == ErrorClassPermanent
+		},
+	}))
+	err = ch.retry(ctx, func() error {
+		attempts++
+		if attempts == 2 {
+			return nil
+		}
+		return errors.New("failure")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+
+	// a cancelled context stops retrying
+	attempts = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = ch.retry(ctx, func() error {
+		attempts++
+		return io.EOF
+	})
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWithDeduplicationToken(t *testing.T) {
+	ctx := withDeduplicationToken(context.Background())
+	token := queryOptions(ctx).settings["insert_deduplication_token"]
+	assert.NotEmpty(t, token)
+	// an existing token is kept
+	assert.Equal(t, token, queryOptions(withDeduplicationToken(ctx)).settings["insert_deduplication_token"])
+}
@@ -19,8 +19,10 @@ package clickhouse
 
 import (
 	"database/sql"
+	"fmt"
 	"io"
 
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
 )
 
@@ -29,10 +31,12 @@ type rows struct {
 	row       int
 	block     *proto.Block
 	totals    *proto.Block
+	extremes  *proto.Block
 	errors    chan error
 	stream    chan *proto.Block
 	columns   []string
 	structMap *structMap
+	stats     *queryStats
 }
 
 func (r *rows) Next() (result bool) {
@@ -44,11 +48,52 @@ func (r *rows) Next() (result bool) {
 	if r.block == nil {
 		return false
 	}
-next:
-	if r.row >= r.block.Rows() {
-		if r.stream == nil {
+	for r.row >= r.block.Rows() {
+		if !r.readBlock() {
 			return false
 		}
+	}
+	r.row++
+	return true
+}
+
+// NextBlock moves to the next block of the result that has rows, skipping the rows of the current block that were
+// not read with Next. The columns of the block returned by Block hold the values as they were decoded, and the
+// block is not reused by rows, so they stay valid after the following call to NextBlock. Data of a String column
+// shares memory with the block, see String.Data.
+func (r *rows) NextBlock() (result bool) {
+	defer func() {
+		if !result {
+			r.Close()
+		}
+	}()
+	if r.block == nil {
+		return false
+	}
+	// the current block is only returned when none of its rows were read
+	if r.row > 0 && !r.readBlock() {
+		return false
+	}
+	for r.row >= r.block.Rows() {
+		if !r.readBlock() {
+			return false
+		}
+	}
+	r.row = r.block.Rows()
+	return true
+}
+
+// Block returns the block the last call to Next or NextBlock moved to
+func (r *rows) Block() *proto.Block {
+	return r.block
+}
+
+// readBlock replaces the current block with the next data block of the stream
+func (r *rows) readBlock() bool {
+	if r.stream == nil {
+		return false
+	}
+	for {
 		select {
 		case err := <-r.errors:
 			if err != nil {
@@ -59,16 +104,19 @@ next:
 			if block == nil {
 				return false
 			}
-			if block.Packet == proto.ServerTotals {
-				r.row, r.block, r.totals = 0, nil, block
-				return false
+			// totals and extremes follow the data blocks, keep them for Totals and Extremes
+			switch block.Packet {
+			case proto.ServerTotals:
+				r.totals = block
+				continue
+			case proto.ServerExtremes:
+				r.extremes = block
+				continue
 			}
 			r.row, r.block = 0, block
+			return true
 		}
-		goto next
 	}
-	r.row++
-	return r.row <= r.block.Rows()
 }
 
 func (r *rows) Scan(dest ...any) error {
@@ -86,6 +134,7 @@ func (r *rows) ScanStruct(dest any) error {
 	return r.Scan(values...)
 }
 
+// Totals scans the totals row of a query WITH TOTALS, once Next returned false. Over HTTP there are never totals.
 func (r *rows) Totals(dest ...any) error {
 	if r.totals == nil {
 		return sql.ErrNoRows
@@ -93,10 +142,40 @@ func (r *rows) Totals(dest ...any) error {
 	return scan(r.totals, 1, dest...)
 }
 
+var (
+	_ driver.BlockRows    = (*rows)(nil)
+	_ driver.ExtremesRows = (*rows)(nil)
+	_ driver.StatsRows    = (*rows)(nil)
+)
+
+// Extremes scans the minimum and maximum rows of a query run with the extremes setting, once Next returned false.
+// dest holds the destinations for the minimum row followed by the ones for the maximum row. Over HTTP there are
+// never extremes.
+func (r *rows) Extremes(dest ...any) error {
+	if r.extremes == nil {
+		return sql.ErrNoRows
+	}
+	columns := len(r.extremes.Columns)
+	if len(dest) != 2*columns {
+		return &OpError{
+			Op:  "Extremes",
+			Err: fmt.Errorf("expected %d destination arguments in Extremes, not %d", 2*columns, len(dest)),
+		}
+	}
+	if err := scan(r.extremes, 1, dest[:columns]...); err != nil {
+		return err
+	}
+	return scan(r.extremes, 2, dest[columns:]...)
+}
+
 func (r *rows) Columns() []string {
 	return r.columns
 }
 
+func (r *rows) Stats() QueryStats {
+	return r.stats.Stats()
+}
+
 func (r *rows) Close() error {
 	if r.errors == nil && r.stream == nil {
 		return r.err
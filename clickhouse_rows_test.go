@@ -1,6 +1,12 @@
 package clickhouse
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
 	"github.com/stretchr/testify/assert"
 	"strconv"
@@ -114,3 +120,183 @@ func TestReadWithEmptyBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestRowsTotalsAndExtremes(t *testing.T) {
+	newRows := func() *rows {
+		newBlock := func(packet byte, values ...int64) *proto.Block {
+			block := &proto.Block{Packet: packet}
+			block.AddColumn("n", "Int64")
+			for _, v := range values {
+				block.Append(v)
+			}
+			return block
+		}
+		stream := make(chan *proto.Block)
+		go func() {
+			stream <- newBlock(proto.ServerData, 3, 4)
+			stream <- newBlock(proto.ServerTotals, 10)
+			stream <- newBlock(proto.ServerExtremes, 1, 4)
+			close(stream)
+		}()
+		return &rows{
+			block:   newBlock(proto.ServerData, 1, 2),
+			stream:  stream,
+			columns: []string{"n"},
+		}
+	}
+
+	t.Run("native", func(t *testing.T) {
+		r := newRows()
+		var count int
+		for r.Next() {
+			count++
+		}
+		assert.Equal(t, 4, count)
+		var total, min, max int64
+		assert.NoError(t, r.Totals(&total))
+		assert.Equal(t, int64(10), total)
+		assert.NoError(t, r.Extremes(&min, &max))
+		assert.Equal(t, int64(1), min)
+		assert.Equal(t, int64(4), max)
+		assert.Error(t, r.Extremes(&min))
+	})
+
+	t.Run("result sets", func(t *testing.T) {
+		r := &stdRows{rows: newRows(), debugf: func(string, ...any) {}}
+		read := func() (values []int64) {
+			dest := make([]driver.Value, 1)
+			for r.Next(dest) == nil {
+				values = append(values, dest[0].(int64))
+			}
+			return values
+		}
+		assert.Equal(t, []int64{1, 2, 3, 4}, read())
+		assert.True(t, r.HasNextResultSet())
+		assert.NoError(t, r.NextResultSet())
+		assert.Equal(t, []int64{10}, read())
+		assert.True(t, r.HasNextResultSet())
+		assert.NoError(t, r.NextResultSet())
+		assert.Equal(t, []int64{1, 4}, read())
+		assert.False(t, r.HasNextResultSet())
+		assert.Equal(t, io.EOF, r.NextResultSet())
+	})
+
+	t.Run("no totals", func(t *testing.T) {
+		r := &rows{block: &proto.Block{}}
+		assert.False(t, r.Next())
+		assert.ErrorIs(t, r.Totals(), sql.ErrNoRows)
+		assert.ErrorIs(t, r.Extremes(), sql.ErrNoRows)
+	})
+}
+
+func TestRowsNextBlock(t *testing.T) {
+	newBlock := func(values ...uint64) *proto.Block {
+		block := &proto.Block{}
+		block.AddColumn("n", "UInt64")
+		block.AddColumn("s", "String")
+		block.AddColumn("a", "Array(Nullable(UInt8))")
+		for _, v := range values {
+			var item *uint8
+			if v%2 == 0 {
+				item = new(uint8)
+				*item = uint8(v)
+			}
+			block.Append(v, strconv.FormatUint(v, 10), []*uint8{item, item})
+		}
+		return block
+	}
+	stream := make(chan *proto.Block)
+	go func() {
+		stream <- newBlock()
+		stream <- newBlock(2, 3)
+		close(stream)
+	}()
+	r := &rows{
+		block:   newBlock(0, 1),
+		stream:  stream,
+		columns: []string{"n", "s", "a"},
+	}
+	assert.True(t, r.Next())
+	// the rest of the first block is skipped
+	assert.True(t, r.NextBlock())
+	block := r.Block()
+	assert.Equal(t, 2, block.Rows())
+	assert.Equal(t, []uint64{2, 3}, block.Columns[0].(*column.UInt64).Data())
+	assert.Equal(t, []string{"2", "3"}, block.Columns[1].(*column.String).Data())
+	array := block.Columns[2].(*column.Array)
+	assert.Equal(t, 1, array.Depth())
+	assert.Equal(t, []uint64{2, 4}, array.Offsets(0))
+	nullable := array.Base().(*column.Nullable)
+	assert.Equal(t, []uint8{0, 0, 1, 1}, nullable.Nulls())
+	assert.Equal(t, []uint8{2, 2, 0, 0}, nullable.Base().(*column.UInt8).Data())
+	assert.False(t, r.NextBlock())
+	assert.NoError(t, r.Err())
+}
+
+func BenchmarkRowsRead(b *testing.B) {
+	const blocks, blockRows = 10, 10_000
+	newRows := func() *rows {
+		stream := make(chan *proto.Block, blocks)
+		for i := 0; i < blocks; i++ {
+			block := &proto.Block{}
+			block.AddColumn("n", "UInt64")
+			block.AddColumn("s", "String")
+			for j := 0; j < blockRows; j++ {
+				block.Append(uint64(j), strconv.Itoa(j))
+			}
+			stream <- block
+		}
+		close(stream)
+		return &rows{block: &proto.Block{}, stream: stream}
+	}
+	b.Run("Scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			r := newRows()
+			b.StartTimer()
+			var (
+				n uint64
+				s string
+			)
+			for r.Next() {
+				if err := r.Scan(&n, &s); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("NextBlock", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			r := newRows()
+			b.StartTimer()
+			for r.NextBlock() {
+				block := r.Block()
+				_ = block.Columns[0].(*column.UInt64).Data()
+				_ = block.Columns[1].(*column.String).Data()
+			}
+		}
+	})
+}
+
+func TestStdRowsInterval(t *testing.T) {
+	block := &proto.Block{}
+	block.AddColumn("s", "IntervalSecond")
+	block.AddColumn("m", "IntervalMonth")
+	block.AddColumn("d", "Nullable(IntervalDay)")
+	assert.NoError(t, block.Append(int64(90), int64(3), nil))
+	assert.NoError(t, block.Append(int64(1), int64(1), int64(2)))
+	stream := make(chan *proto.Block)
+	close(stream)
+	r := &stdRows{rows: &rows{block: block, stream: stream, columns: []string{"s", "m", "d"}}, debugf: func(string, ...any) {}}
+
+	// database/sql reads intervals as their text
+	assert.Equal(t, reflect.TypeOf(""), r.ColumnTypeScanType(0))
+	assert.Equal(t, reflect.TypeOf((*string)(nil)), r.ColumnTypeScanType(2))
+	dest := make([]driver.Value, 3)
+	assert.NoError(t, r.Next(dest))
+	assert.Equal(t, []driver.Value{"90 Seconds", "3 Months", nil}, dest)
+	assert.NoError(t, r.Next(dest))
+	assert.Equal(t, []driver.Value{"1 Second", "1 Month", "2 Days"}, dest)
+	assert.Equal(t, io.EOF, r.Next(dest))
+}
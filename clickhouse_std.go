@@ -33,6 +33,7 @@ import (
 	"sync/atomic"
 	"syscall"
 
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
 	ldriver "github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 )
@@ -104,8 +105,9 @@ func (o *stdConnOpener) Connect(ctx context.Context) (_ driver.Conn, err error)
 				}
 			}
 			return &stdDriver{
-				conn:   conn,
-				debugf: debugf,
+				conn:         conn,
+				debugf:       debugf,
+				transactions: o.opt.Transactions,
 			}, nil
 		} else {
 			o.debugf("[connect] error connecting to %s on connection %d: %v\n", o.opt.Addr[num], connID, err)
@@ -125,7 +127,7 @@ func init() {
 // isConnBrokenError returns true if the error class indicates that the
 // db connection is no longer usable and should be marked bad
 func isConnBrokenError(err error) bool {
-	if errors.Is(err, io.EOF) || errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) {
+	if errors.Is(err, io.EOF) || errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, driver.ErrBadConn) {
 		return true
 	}
 	if _, ok := err.(*net.OpError); ok {
@@ -195,15 +197,20 @@ type stdConnect interface {
 	close() error
 	query(ctx context.Context, release func(*connect, error), query string, args ...any) (*rows, error)
 	exec(ctx context.Context, query string, args ...any) error
+	execWithStats(ctx context.Context, query string, args ...any) (QueryStats, error)
 	ping(ctx context.Context) (err error)
 	prepareBatch(ctx context.Context, query string, options ldriver.PrepareBatchOptions, release func(*connect, error), acquire func(context.Context) (*connect, error)) (ldriver.Batch, error)
 	asyncInsert(ctx context.Context, query string, wait bool, args ...any) error
+	insertFrom(ctx context.Context, query string, reader io.Reader) error
 }
 
 type stdDriver struct {
 	conn   stdConnect
-	commit func() error
+	batch  ldriver.Batch
 	debugf func(format string, v ...any)
+	// transactions maps database/sql transactions to server side transactions, see Options.Transactions
+	transactions bool
+	inTx         bool
 }
 
 var _ driver.Conn = (*stdDriver)(nil)
@@ -251,12 +258,7 @@ func (std *stdDriver) Ping(ctx context.Context) error {
 var _ driver.Pinger = (*stdDriver)(nil)
 
 func (std *stdDriver) Begin() (driver.Tx, error) {
-	if std.conn.isBad() {
-		std.debugf("Begin: connection is bad")
-		return nil, driver.ErrBadConn
-	}
-
-	return std, nil
+	return std.BeginTx(context.Background(), driver.TxOptions{})
 }
 
 func (std *stdDriver) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
@@ -264,19 +266,75 @@ func (std *stdDriver) BeginTx(ctx context.Context, opts driver.TxOptions) (drive
 		std.debugf("BeginTx: connection is bad")
 		return nil, driver.ErrBadConn
 	}
-
+	if !std.transactions {
+		return std, nil
+	}
+	// ClickHouse transactions can't be made read-only, so refuse it rather than letting writes through
+	if opts.ReadOnly {
+		return nil, errors.New("clickhouse [BeginTx]: read-only transactions are not supported")
+	}
+	if std.inTx {
+		return nil, errors.New("clickhouse [BeginTx]: a transaction is already in progress on this connection")
+	}
+	// ClickHouse transactions provide snapshot isolation, which satisfies every weaker level
+	switch level := sql.IsolationLevel(opts.Isolation); level {
+	case sql.LevelDefault, sql.LevelReadUncommitted, sql.LevelReadCommitted, sql.LevelRepeatableRead, sql.LevelSnapshot:
+	default:
+		return nil, fmt.Errorf("clickhouse [BeginTx]: isolation level %s is not supported, transactions use snapshot isolation", level)
+	}
+	if err := std.conn.exec(ctx, "BEGIN TRANSACTION"); err != nil {
+		if isConnBrokenError(err) {
+			std.debugf("BeginTx got a fatal error, resetting connection: %v\n", err)
+			return nil, driver.ErrBadConn
+		}
+		std.debugf("BeginTx error: %v\n", err)
+		return nil, err
+	}
+	std.inTx = true
 	return std, nil
 }
 
 func (std *stdDriver) Commit() error {
-	if std.commit == nil {
+	if std.transactions && std.inTx {
+		return std.commitTx()
+	}
+	if std.batch == nil {
 		return nil
 	}
 	defer func() {
-		std.commit = nil
+		std.batch = nil
 	}()
 
-	if err := std.commit(); err != nil {
+	if err := std.batch.Send(); err != nil {
+		if isConnBrokenError(err) {
+			std.debugf("Commit got EOF error: resetting connection")
+			return driver.ErrBadConn
+		}
+		std.debugf("Commit error: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// commitTx sends the batch prepared in the transaction, if any, and commits the server transaction.
+// The transaction is rolled back if the batch cannot be sent.
+func (std *stdDriver) commitTx() error {
+	batch := std.batch
+	std.batch, std.inTx = nil, false
+	if batch != nil {
+		if err := batch.Send(); err != nil {
+			if isConnBrokenError(err) {
+				std.debugf("Commit got EOF error: resetting connection")
+				return driver.ErrBadConn
+			}
+			std.debugf("Commit batch error: %v\n", err)
+			if rollbackErr := std.conn.exec(context.Background(), "ROLLBACK"); rollbackErr != nil {
+				std.debugf("Commit rollback error: %v\n", rollbackErr)
+			}
+			return err
+		}
+	}
+	if err := std.conn.exec(context.Background(), "COMMIT"); err != nil {
 		if isConnBrokenError(err) {
 			std.debugf("Commit got EOF error: resetting connection")
 			return driver.ErrBadConn
@@ -288,8 +346,26 @@ func (std *stdDriver) Commit() error {
 }
 
 func (std *stdDriver) Rollback() error {
-	std.commit = nil
-	std.conn.close()
+	if !std.transactions || !std.inTx {
+		std.batch = nil
+		std.conn.close()
+		return nil
+	}
+	batch := std.batch
+	std.batch, std.inTx = nil, false
+	if batch != nil && !batch.IsSent() {
+		if err := batch.Abort(); err != nil {
+			std.debugf("Rollback batch abort error: %v\n", err)
+		}
+	}
+	if err := std.conn.exec(context.Background(), "ROLLBACK"); err != nil {
+		if isConnBrokenError(err) {
+			std.debugf("Rollback got EOF error: resetting connection")
+			return driver.ErrBadConn
+		}
+		std.debugf("Rollback error: %v\n", err)
+		return err
+	}
 	return nil
 }
 
@@ -344,6 +420,91 @@ func (std *stdDriver) QueryContext(ctx context.Context, query string, args []dri
 	}, nil
 }
 
+// QueryStreamer streams query results as raw bytes in any output format, e.g. CSV, Parquet, Arrow or JSONEachRow.
+// It is implemented by database/sql connections using the HTTP protocol, which can be reached with sql.Conn.Raw.
+// The stream must be closed before the connection is used again.
+type QueryStreamer interface {
+	QueryStream(ctx context.Context, format string, query string, args ...any) (io.ReadCloser, error)
+}
+
+var _ QueryStreamer = (*stdDriver)(nil)
+
+// QueryStream returns the decompressed response of a query in the given format. Exceptions raised by the server
+// after the response has started are returned by Read.
+func (std *stdDriver) QueryStream(ctx context.Context, format string, query string, args ...any) (io.ReadCloser, error) {
+	if std.conn.isBad() {
+		std.debugf("QueryStream: connection is bad")
+		return nil, driver.ErrBadConn
+	}
+	conn, ok := std.conn.(*httpConnect)
+	if !ok {
+		return nil, errors.New("clickhouse [QueryStream]: only supported by the HTTP protocol")
+	}
+	stream, err := conn.queryStream(ctx, format, query, args...)
+	if err != nil {
+		if isConnBrokenError(err) {
+			std.debugf("QueryStream got a fatal error, resetting connection: %v\n", err)
+			return nil, driver.ErrBadConn
+		}
+		std.debugf("QueryStream error: %v\n", err)
+		return nil, err
+	}
+	return stream, nil
+}
+
+// StatsExecer executes a query and returns its statistics, which database/sql results don't have.
+// It is implemented by database/sql connections, which can be reached with sql.Conn.Raw.
+// The Conn of Open implements it too.
+type StatsExecer = ldriver.StatsExecer
+
+var _ StatsExecer = (*stdDriver)(nil)
+
+// ExecWithStats executes a query like ExecContext and returns the statistics of the query
+func (std *stdDriver) ExecWithStats(ctx context.Context, query string, args ...any) (QueryStats, error) {
+	if std.conn.isBad() {
+		std.debugf("ExecWithStats: connection is bad")
+		return QueryStats{}, driver.ErrBadConn
+	}
+	stats, err := std.conn.execWithStats(ctx, query, args...)
+	if err != nil {
+		if isConnBrokenError(err) {
+			std.debugf("ExecWithStats got a fatal error, resetting connection: %v\n", err)
+			return QueryStats{}, driver.ErrBadConn
+		}
+		std.debugf("ExecWithStats error: %v\n", err)
+		return QueryStats{}, err
+	}
+	return stats, nil
+}
+
+// RawInserter inserts pre-formatted data, e.g. CSV, TSV, JSONEachRow or Parquet, without converting it to Go values.
+// It is implemented by database/sql connections, which can be reached with sql.Conn.Raw, and by the Conn of Open.
+// Over HTTP the data is streamed. The native protocol can't stream it, so the data is read into memory, up to the
+// MaxInsertFromSize option.
+type RawInserter = ldriver.RawInserter
+
+var _ RawInserter = (*stdDriver)(nil)
+
+// InsertFrom sends the data of an INSERT ... FORMAT query read from reader
+func (std *stdDriver) InsertFrom(ctx context.Context, query string, reader io.Reader) error {
+	if std.conn.isBad() {
+		std.debugf("InsertFrom: connection is bad")
+		return driver.ErrBadConn
+	}
+	if err := std.conn.insertFrom(ctx, query, reader); err != nil {
+		if isConnBrokenError(err) {
+			std.debugf("InsertFrom got a fatal error, resetting connection: %v\n", err)
+			return driver.ErrBadConn
+		}
+		std.debugf("InsertFrom error: %v\n", err)
+		return &OpError{
+			Op:  "InsertFrom",
+			Err: err,
+		}
+	}
+	return nil
+}
+
 func (std *stdDriver) Prepare(query string) (driver.Stmt, error) {
 	return std.PrepareContext(context.Background(), query)
 }
@@ -363,7 +524,7 @@ func (std *stdDriver) PrepareContext(ctx context.Context, query string) (driver.
 		std.debugf("PrepareContext error: %v\n", err)
 		return nil, err
 	}
-	std.commit = batch.Send
+	std.batch = batch
 	return &stdBatch{
 		batch:  batch,
 		debugf: std.debugf,
@@ -427,6 +588,14 @@ func (r *stdRows) Columns() []string {
 }
 
 func (r *stdRows) ColumnTypeScanType(idx int) reflect.Type {
+	switch col := r.rows.block.Columns[idx].(type) {
+	case *column.Interval:
+		return reflect.TypeOf("")
+	case *column.Nullable:
+		if _, ok := col.Base().(*column.Interval); ok {
+			return reflect.TypeOf((*string)(nil))
+		}
+	}
 	return r.rows.block.Columns[idx].ScanType()
 }
 
@@ -471,6 +640,24 @@ func (r *stdRows) Next(dest []driver.Value) error {
 	}
 	if r.rows.Next() {
 		for i := range dest {
+			// Row of a JSON column can't report a row that fails to decode, ScanRow does
+			if col, ok := r.rows.block.Columns[i].(*column.JSONColumn); ok {
+				var value chcol.JSON
+				if err := col.ScanRow(&value, r.rows.row-1); err != nil {
+					r.debugf("Next row error: %v\n", err)
+					return err
+				}
+				dest[i] = value
+				continue
+			}
+			if value, ok, err := r.intervalText(i); ok {
+				if err != nil {
+					r.debugf("Next row error: %v\n", err)
+					return err
+				}
+				dest[i] = value
+				continue
+			}
 			nullable, ok := r.ColumnTypeNullable(i)
 			switch value := r.rows.block.Columns[i].Row(r.rows.row-1, nullable && ok).(type) {
 			case driver.Valuer:
@@ -508,15 +695,38 @@ func (r *stdRows) Next(dest []driver.Value) error {
 	return io.EOF
 }
 
+// intervalText reads a row of an Interval column as its text, e.g. "90 Seconds", which is what database/sql
+// scanned before the native interface returned intervals as time.Duration and chcol.Interval
+func (r *stdRows) intervalText(idx int) (value driver.Value, ok bool, err error) {
+	switch col := r.rows.block.Columns[idx].(type) {
+	case *column.Interval:
+		var text string
+		err = col.ScanRow(&text, r.rows.row-1)
+		return text, true, err
+	case *column.Nullable:
+		if _, ok := col.Base().(*column.Interval); !ok {
+			return nil, false, nil
+		}
+		var text *string
+		if err = col.ScanRow(&text, r.rows.row-1); err != nil || text == nil {
+			return nil, true, err
+		}
+		return *text, true, nil
+	}
+	return nil, false, nil
+}
+
 func (r *stdRows) HasNextResultSet() bool {
-	return r.rows.totals != nil
+	return r.rows.totals != nil || r.rows.extremes != nil
 }
 
+// NextResultSet moves to the totals and then the extremes of the query, when the server sent them
 func (r *stdRows) NextResultSet() error {
 	switch {
 	case r.rows.totals != nil:
-		r.rows.block = r.rows.totals
-		r.rows.totals = nil
+		r.rows.row, r.rows.block, r.rows.totals = 0, r.rows.totals, nil
+	case r.rows.extremes != nil:
+		r.rows.row, r.rows.block, r.rows.extremes = 0, r.rows.extremes, nil
 	default:
 		return io.EOF
 	}
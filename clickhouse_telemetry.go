@@ -0,0 +1,275 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+)
+
+const instrumentationName = "github.com/ClickHouse/clickhouse-go/v2"
+
+// Names of the driver operations traced by the client, as used in span names and the db.operation.name attribute
+const (
+	operationAcquire   = "acquire"
+	operationDial      = "dial"
+	operationHandshake = "handshake"
+	operationQuery     = "query"
+	operationBatchSend = "batch.send"
+	operationPing      = "ping"
+)
+
+var (
+	attributeSystem          = attribute.String("db.system", "clickhouse")
+	attributeQueryID         = attribute.Key("clickhouse.query_id")
+	attributeReadRows        = attribute.Key("clickhouse.read_rows")
+	attributeReadBytes       = attribute.Key("clickhouse.read_bytes")
+	attributeWrittenRows     = attribute.Key("clickhouse.written_rows")
+	attributeWrittenBytes    = attribute.Key("clickhouse.written_bytes")
+	attributeResultRows      = attribute.Key("clickhouse.result_rows")
+	attributeStatusCode      = attribute.Key("db.response.status_code")
+	attributeErrorType       = attribute.Key("error.type")
+	attributeOperation       = attribute.Key("db.operation.name")
+	attributeServerAddress   = attribute.Key("server.address")
+	attributeServerPort      = attribute.Key("server.port")
+	attributeConnectionState = attribute.Key("db.client.connection.state")
+)
+
+// telemetry creates the client side spans and metrics of the Options TracerProvider and MeterProvider
+type telemetry struct {
+	// tracing is set when a TracerProvider is configured, so queries also send their span to the server
+	tracing  bool
+	tracer   trace.Tracer
+	meter    metric.Meter
+	duration metric.Float64Histogram
+}
+
+func newTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) *telemetry {
+	if tracerProvider == nil && meterProvider == nil {
+		return nil
+	}
+	t := &telemetry{
+		tracing: tracerProvider != nil,
+	}
+	if tracerProvider != nil {
+		t.tracer = tracerProvider.Tracer(instrumentationName)
+	}
+	if meterProvider != nil {
+		t.meter = meterProvider.Meter(instrumentationName)
+		var err error
+		if t.duration, err = t.meter.Float64Histogram("db.client.operation.duration",
+			metric.WithUnit("s"),
+			metric.WithDescription("Duration of the driver operations"),
+		); err != nil {
+			// the provider reports the error to the otel error handler, the measures are dropped
+			t.duration = nil
+		}
+	}
+	return t
+}
+
+// observePool reports the usage of a connection pool until the returned registration is unregistered
+func (t *telemetry) observePool(stats func() driver.Stats) metric.Registration {
+	if t == nil || t.meter == nil {
+		return nil
+	}
+	count, err := t.meter.Int64ObservableUpDownCounter("db.client.connection.count",
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("Number of connections by state"),
+	)
+	if err != nil {
+		return nil
+	}
+	maxOpen, err := t.meter.Int64ObservableUpDownCounter("db.client.connection.max",
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("Maximum number of open connections"),
+	)
+	if err != nil {
+		return nil
+	}
+	timeouts, err := t.meter.Int64ObservableCounter("db.client.connection.timeouts",
+		metric.WithUnit("{timeout}"),
+		metric.WithDescription("Number of connection acquires that timed out"),
+	)
+	if err != nil {
+		return nil
+	}
+	waitTime, err := t.meter.Float64ObservableCounter("db.client.connection.wait_time",
+		metric.WithUnit("s"),
+		metric.WithDescription("Total time spent waiting for a free connection slot"),
+	)
+	if err != nil {
+		return nil
+	}
+	registration, err := t.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		s := stats()
+		o.ObserveInt64(count, int64(s.Idle), metric.WithAttributes(attributeSystem, attributeConnectionState.String("idle")))
+		o.ObserveInt64(count, int64(s.Open-s.Idle), metric.WithAttributes(attributeSystem, attributeConnectionState.String("used")))
+		o.ObserveInt64(maxOpen, int64(s.MaxOpenConns), metric.WithAttributes(attributeSystem))
+		o.ObserveInt64(timeouts, s.AcquireTimeouts, metric.WithAttributes(attributeSystem))
+		o.ObserveFloat64(waitTime, s.WaitDuration.Seconds(), metric.WithAttributes(attributeSystem))
+		return nil
+	}, count, maxOpen, timeouts, waitTime)
+	if err != nil {
+		return nil
+	}
+	return registration
+}
+
+// operation is the span of a driver operation and the measure of its duration
+type operation struct {
+	telemetry *telemetry
+	name      string
+	start     time.Time
+	span      trace.Span
+	// attributes are the metric attributes, which leave out the per call values of the span
+	attributes []attribute.KeyValue
+}
+
+// start begins an operation. ctx carries the span of the operation, to make it the parent of nested operations.
+func (t *telemetry) start(ctx context.Context, name, addr string) (context.Context, *operation) {
+	op := &operation{
+		telemetry:  t,
+		name:       name,
+		span:       trace.SpanFromContext(context.Background()),
+		attributes: append([]attribute.KeyValue{attributeSystem, attributeOperation.String(name)}, serverAttributes(addr)...),
+	}
+	if t == nil {
+		return ctx, op
+	}
+	op.start = time.Now()
+	if t.tracer != nil {
+		ctx, op.span = t.tracer.Start(ctx, "clickhouse."+name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(op.attributes...),
+		)
+	}
+	return ctx, op
+}
+
+// serverAttributes splits the address of a server into the server.address and server.port attributes
+func serverAttributes(addr string) []attribute.KeyValue {
+	if addr == "" {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return []attribute.KeyValue{attributeServerAddress.String(addr)}
+	}
+	attributes := []attribute.KeyValue{attributeServerAddress.String(host)}
+	if port, err := strconv.Atoi(port); err == nil {
+		attributes = append(attributes, attributeServerPort.Int(port))
+	}
+	return attributes
+}
+
+// setServer sets the address of the server once an operation knows which host it uses
+func (op *operation) setServer(addr string) {
+	attributes := serverAttributes(addr)
+	op.attributes = append(op.attributes, attributes...)
+	op.span.SetAttributes(attributes...)
+}
+
+func (op *operation) setQueryID(queryID string) {
+	if queryID != "" {
+		op.span.SetAttributes(attributeQueryID.String(queryID))
+	}
+}
+
+// setRows sets the number of rows a batch sends
+func (op *operation) setRows(rows int) {
+	op.span.SetAttributes(attributeWrittenRows.Int(rows))
+}
+
+// traceQuery sets the query id of the query on the span, and sends the span to the server when the query has none
+func (op *operation) traceQuery(options *QueryOptions) {
+	op.setQueryID(options.queryID)
+	if op.telemetry == nil || !op.telemetry.tracing || options.span.IsValid() {
+		return
+	}
+	if span := op.span.SpanContext(); span.IsValid() {
+		options.span = span
+	}
+}
+
+// end ends the span of the operation with the outcome of err, and records its duration
+func (op *operation) end(err error) {
+	if op.telemetry == nil {
+		return
+	}
+	attributes := op.attributes
+	if err != nil {
+		errorAttributes := errorAttributes(err)
+		attributes = append(attributes[:len(attributes):len(attributes)], errorAttributes[0])
+		op.span.SetAttributes(errorAttributes...)
+		op.span.RecordError(err)
+		op.span.SetStatus(codes.Error, err.Error())
+	}
+	op.span.End()
+	if op.telemetry.duration != nil {
+		op.telemetry.duration.Record(context.Background(), time.Since(op.start).Seconds(), metric.WithAttributes(attributes...))
+	}
+}
+
+// errorAttributes describes an error by its error.type, the ClickHouse error code of exceptions or the Go type of
+// other errors, followed by the db.response.status_code of exceptions
+func errorAttributes(err error) []attribute.KeyValue {
+	var exception *proto.Exception
+	if errors.As(err, &exception) {
+		code := strconv.Itoa(int(exception.Code))
+		return []attribute.KeyValue{attributeErrorType.String(code), attributeStatusCode.String(code)}
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return []attribute.KeyValue{attributeErrorType.String("canceled")}
+	case errors.Is(err, context.DeadlineExceeded):
+		return []attribute.KeyValue{attributeErrorType.String("timeout")}
+	}
+	return []attribute.KeyValue{attributeErrorType.String(fmt.Sprintf("%T", err))}
+}
+
+// setStats sets the rows and bytes the query read and wrote on the span
+func (op *operation) setStats(s *queryStats) {
+	stats, profiled := s.snapshot()
+	attributes := []attribute.KeyValue{
+		attributeReadRows.Int64(int64(stats.ReadRows)),
+		attributeReadBytes.Int64(int64(stats.ReadBytes)),
+	}
+	if stats.WrittenRows != 0 || stats.WrittenBytes != 0 {
+		attributes = append(attributes,
+			attributeWrittenRows.Int64(int64(stats.WrittenRows)),
+			attributeWrittenBytes.Int64(int64(stats.WrittenBytes)),
+		)
+	}
+	if profiled {
+		attributes = append(attributes, attributeResultRows.Int64(int64(stats.ResultRows)))
+	}
+	op.span.SetAttributes(attributes...)
+}
@@ -0,0 +1,274 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+// testTracer records the spans it starts
+type testTracer struct {
+	tracenoop.Tracer
+	mutex sync.Mutex
+	spans []*testSpan
+}
+
+type testTracerProvider struct {
+	tracenoop.TracerProvider
+	tracer *testTracer
+}
+
+type testSpan struct {
+	tracenoop.Span
+	name       string
+	context    trace.SpanContext
+	parent     trace.SpanContext
+	attributes map[attribute.Key]attribute.Value
+	status     codes.Code
+	ended      bool
+}
+
+func (p testTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return p.tracer
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	var (
+		traceID trace.TraceID
+		spanID  trace.SpanID
+	)
+	rand.Read(traceID[:])
+	rand.Read(spanID[:])
+	span := &testSpan{
+		name:       name,
+		parent:     trace.SpanContextFromContext(ctx),
+		context:    trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}),
+		attributes: make(map[attribute.Key]attribute.Value),
+	}
+	config := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(config.Attributes()...)
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.spans = append(t.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+func (s *testSpan) SpanContext() trace.SpanContext { return s.context }
+func (s *testSpan) IsRecording() bool              { return !s.ended }
+func (s *testSpan) End(...trace.SpanEndOption)     { s.ended = true }
+func (s *testSpan) SetStatus(code codes.Code, _ string) {
+	s.status = code
+}
+func (s *testSpan) SetAttributes(attributes ...attribute.KeyValue) {
+	for _, a := range attributes {
+		s.attributes[a.Key] = a.Value
+	}
+}
+
+// testMeter records the durations and keeps the pool callback
+type testMeter struct {
+	metricnoop.Meter
+	durations []attribute.Set
+	callback  metric.Callback
+}
+
+type testMeterProvider struct {
+	metricnoop.MeterProvider
+	meter *testMeter
+}
+
+type testHistogram struct {
+	metricnoop.Float64Histogram
+	meter *testMeter
+}
+
+func (p testMeterProvider) Meter(string, ...metric.MeterOption) metric.Meter {
+	return p.meter
+}
+
+func (m *testMeter) Float64Histogram(string, ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	return testHistogram{meter: m}, nil
+}
+
+func (h testHistogram) Record(_ context.Context, _ float64, opts ...metric.RecordOption) {
+	h.meter.durations = append(h.meter.durations, metric.NewRecordConfig(opts).Attributes())
+}
+
+func (m *testMeter) RegisterCallback(callback metric.Callback, _ ...metric.Observable) (metric.Registration, error) {
+	m.callback = callback
+	return metricnoop.Registration{}, nil
+}
+
+// testObserver records the observed int64 values by their connection state
+type testObserver struct {
+	metricnoop.Observer
+	values map[string]int64
+}
+
+func (o *testObserver) ObserveInt64(_ metric.Int64Observable, value int64, opts ...metric.ObserveOption) {
+	attributes := metric.NewObserveConfig(opts).Attributes()
+	state, _ := attributes.Value(attributeConnectionState)
+	o.values[state.AsString()] += value
+}
+
+func TestTelemetryOperation(t *testing.T) {
+	var (
+		tracer = &testTracer{}
+		meter  = &testMeter{}
+		tel    = newTelemetry(testTracerProvider{tracer: tracer}, testMeterProvider{meter: meter})
+	)
+	ctx, op := tel.start(context.Background(), operationQuery, "clickhouse:9000")
+	_, child := tel.start(ctx, operationHandshake, "")
+	child.end(nil)
+	stats := newQueryStats()
+	stats.progress(&Progress{Rows: 10, Bytes: 100})
+	stats.progress(&Progress{Rows: 5, Bytes: 50})
+	stats.profileInfo(&ProfileInfo{Rows: 3})
+	op.setStats(stats)
+	op.end(&OpError{Op: "query", Err: &Exception{Code: 60, Message: "Unknown table"}})
+
+	require.Len(t, tracer.spans, 2)
+	span := tracer.spans[0]
+	assert.Equal(t, "clickhouse.query", span.name)
+	assert.True(t, span.ended)
+	assert.Equal(t, codes.Error, span.status)
+	assert.Equal(t, span.context, tracer.spans[1].parent)
+	assert.Equal(t, "clickhouse", span.attributes["db.system"].AsString())
+	assert.Equal(t, "clickhouse", span.attributes[attributeServerAddress].AsString())
+	assert.Equal(t, int64(9000), span.attributes[attributeServerPort].AsInt64())
+	assert.Equal(t, int64(15), span.attributes[attributeReadRows].AsInt64())
+	assert.Equal(t, int64(150), span.attributes[attributeReadBytes].AsInt64())
+	assert.Equal(t, int64(3), span.attributes[attributeResultRows].AsInt64())
+	assert.Equal(t, "60", span.attributes[attributeStatusCode].AsString())
+
+	require.Len(t, meter.durations, 2)
+	errorType, ok := meter.durations[1].Value(attributeErrorType)
+	require.True(t, ok)
+	assert.Equal(t, "60", errorType.AsString())
+	_, ok = meter.durations[0].Value(attributeErrorType)
+	assert.False(t, ok)
+	operation, _ := meter.durations[0].Value(attributeOperation)
+	assert.Equal(t, operationHandshake, operation.AsString())
+}
+
+func TestTelemetryDisabled(t *testing.T) {
+	var tel *telemetry
+	require.Nil(t, newTelemetry(nil, nil))
+	ctx, op := tel.start(context.Background(), operationPing, "localhost:9000")
+	require.NotNil(t, ctx)
+	op.setStats(newQueryStats())
+	op.end(errors.New("failed"))
+
+	options := queryOptions(context.Background())
+	op.traceQuery(&options)
+	assert.False(t, options.span.IsValid())
+	assert.Nil(t, tel.observePool(func() driver.Stats { return driver.Stats{} }))
+}
+
+func TestTelemetryTraceQuery(t *testing.T) {
+	tel := newTelemetry(testTracerProvider{tracer: &testTracer{}}, nil)
+	_, op := tel.start(context.Background(), operationQuery, "")
+	options := queryOptions(Context(context.Background(), WithQueryID("test-query")))
+	op.traceQuery(&options)
+	assert.Equal(t, op.span.SpanContext(), options.span)
+	assert.Equal(t, "test-query", op.span.(*testSpan).attributes[attributeQueryID].AsString())
+
+	// a span set with WithSpan is kept
+	var own trace.SpanContext
+	{
+		_, span := (&testTracer{}).Start(context.Background(), "own")
+		own = span.SpanContext()
+	}
+	options = queryOptions(Context(context.Background(), WithSpan(own)))
+	op.traceQuery(&options)
+	assert.Equal(t, own, options.span)
+
+	// without a TracerProvider nothing is sent to the server
+	_, op = newTelemetry(nil, testMeterProvider{meter: &testMeter{}}).start(context.Background(), operationQuery, "")
+	options = queryOptions(context.Background())
+	op.traceQuery(&options)
+	assert.False(t, options.span.IsValid())
+}
+
+func TestTelemetryPool(t *testing.T) {
+	meter := &testMeter{}
+	conn, err := Open(&Options{MeterProvider: testMeterProvider{meter: meter}, MaxIdleConns: 2, MaxOpenConns: 4})
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NotNil(t, meter.callback)
+	observer := &testObserver{values: make(map[string]int64)}
+	require.NoError(t, meter.callback(context.Background(), observer))
+	assert.Equal(t, int64(0), observer.values["idle"])
+	assert.Equal(t, int64(0), observer.values["used"])
+	// the max connections are observed without a state
+	assert.Equal(t, int64(4), observer.values[""])
+}
+
+func TestTelemetryHttpExec(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first query succeeds and the second fails
+		if requests++; requests > 1 {
+			w.Header().Set("X-ClickHouse-Exception-Code", "62")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Code: 62. DB::Exception: Syntax error"))
+			return
+		}
+		w.Header().Set("X-ClickHouse-Query-Id", "server-query-id")
+	}))
+	defer server.Close()
+
+	compressionPool, err := createCompressionPool(&Compression{Method: CompressionNone})
+	require.NoError(t, err)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	tracer := &testTracer{}
+	conn := &httpConnect{
+		client:          server.Client(),
+		url:             u,
+		compressionPool: compressionPool,
+		telemetry:       newTelemetry(testTracerProvider{tracer: tracer}, nil),
+	}
+	require.NoError(t, conn.exec(context.Background(), "SELECT 1"))
+	require.Error(t, conn.exec(context.Background(), "SELECT fail"))
+
+	require.Len(t, tracer.spans, 2)
+	assert.Equal(t, "clickhouse.query", tracer.spans[0].name)
+	assert.Equal(t, "server-query-id", tracer.spans[0].attributes[attributeQueryID].AsString())
+	assert.Equal(t, codes.Unset, tracer.spans[0].status)
+	assert.Equal(t, codes.Error, tracer.spans[1].status)
+	assert.Equal(t, "62", tracer.spans[1].attributes[attributeStatusCode].AsString())
+}
@@ -37,9 +37,12 @@ import (
 	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
 )
 
-func dial(ctx context.Context, addr string, num int, opt *Options) (*connect, error) {
+func dial(ctx context.Context, addr string, num int, opt *Options) (_ *connect, err error) {
+	ctx, op := opt.telemetry.start(ctx, operationDial, addr)
+	defer func() {
+		op.end(err)
+	}()
 	var (
-		err    error
 		conn   net.Conn
 		debugf = func(format string, v ...any) {}
 	)
@@ -86,6 +89,7 @@ func dial(ctx context.Context, addr string, num int, opt *Options) (*connect, er
 	var (
 		connect = &connect{
 			id:                   num,
+			addr:                 addr,
 			opt:                  opt,
 			conn:                 conn,
 			debugf:               debugf,
@@ -102,7 +106,20 @@ func dial(ctx context.Context, addr string, num int, opt *Options) (*connect, er
 		}
 	)
 
-	if err := connect.handshake(opt.Auth.Database, opt.Auth.Username, opt.Auth.Password); err != nil {
+	auth, err := opt.credentials(ctx)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if auth.signer != nil {
+		// the SSH challenge exchange is only understood by newer servers
+		connect.revision = proto.DBMS_MIN_REVISION_WITH_SSH_AUTHENTICATION
+	}
+
+	_, handshake := opt.telemetry.start(ctx, operationHandshake, addr)
+	err = connect.handshake(opt.Auth.Database, auth)
+	handshake.end(err)
+	if err != nil {
 		return nil, err
 	}
 
@@ -123,6 +140,7 @@ func dial(ctx context.Context, addr string, num int, opt *Options) (*connect, er
 // https://github.com/ClickHouse/ClickHouse/blob/master/src/Client/Connection.cpp
 type connect struct {
 	id                   int
+	addr                 string
 	opt                  *Options
 	conn                 net.Conn
 	debugf               func(format string, v ...any)
@@ -171,6 +189,14 @@ func (c *connect) settings(querySettings Settings) []proto.Setting {
 	return settings
 }
 
+// telemetry returns the spans and metrics of the connection, nil when they are disabled
+func (c *connect) telemetry() *telemetry {
+	if c.opt == nil {
+		return nil
+	}
+	return c.opt.telemetry
+}
+
 func (c *connect) isBad() bool {
 	if c.isClosed() {
 		return true
@@ -37,12 +37,21 @@ var insertMatch = regexp.MustCompile(`(?i)(INSERT\s+INTO\s+[^( ]+(?:\s*\([^()]*(
 var columnMatch = regexp.MustCompile(`INSERT INTO .+\s\((?P<Columns>.+)\)$`)
 
 func (c *connect) prepareBatch(ctx context.Context, query string, opts driver.PrepareBatchOptions, release func(*connect, error), acquire func(context.Context) (*connect, error)) (driver.Batch, error) {
-	query, _, queryColumns, verr := extractNormalizedInsertQueryAndColumns(query)
+	query, table, queryColumns, verr := extractNormalizedInsertQueryAndColumns(query)
 	if verr != nil {
 		return nil, verr
 	}
 
 	options := queryOptions(ctx)
+	retry := retryPolicy(&options, c.opt)
+	if retry != nil && retry.DeduplicationToken {
+		ctx = withDeduplicationToken(ctx)
+		options = queryOptions(ctx)
+	}
+	if err := options.bindParams(query, c.server.Timezone); err != nil {
+		release(c, err)
+		return nil, err
+	}
 	if deadline, ok := ctx.Deadline(); ok {
 		c.conn.SetDeadline(deadline)
 		defer c.conn.SetDeadline(time.Time{})
@@ -52,9 +61,15 @@ func (c *connect) prepareBatch(ctx context.Context, query string, opts driver.Pr
 		return nil, err
 	}
 	var (
-		onProcess  = options.onProcess()
-		block, err = c.firstBlock(ctx, onProcess)
+		tableColumns []driver.TableColumn
+		onProcess    = options.onProcess()
+		stats        = newQueryStats()
 	)
+	stats.observe(onProcess)
+	onProcess.tableColumns = func(t *proto.TableColumns) {
+		tableColumns = t.Columns
+	}
+	block, err := c.firstBlock(ctx, onProcess)
 	if err != nil {
 		release(c, err)
 		return nil, err
@@ -67,13 +82,18 @@ func (c *connect) prepareBatch(ctx context.Context, query string, opts driver.Pr
 	b := &batch{
 		ctx:          ctx,
 		query:        query,
+		table:        table,
+		tableColumns: tableColumns,
 		conn:         c,
 		block:        block,
 		released:     false,
 		connRelease:  release,
 		connAcquire:  acquire,
 		onProcess:    onProcess,
+		stats:        stats,
 		closeOnFlush: opts.CloseOnFlush,
+		omitDefaults: opts.OmitDefaulted,
+		retry:        retry,
 	}
 
 	if opts.ReleaseConnection {
@@ -87,14 +107,20 @@ type batch struct {
 	err          error
 	ctx          context.Context
 	query        string
+	table        string
+	tableColumns []driver.TableColumn
 	conn         *connect
 	sent         bool // sent signalize that batch is send to ClickHouse.
 	released     bool // released signalize that conn was returned to pool and can't be used.
 	closeOnFlush bool // closeOnFlush signalize that batch should close query and release conn when use Flush
+	flushed      bool // flushed signalize that data was sent by Flush, so the batch can't be resent as a whole.
+	omitDefaults bool // omitDefaults signalize that AppendStruct may leave columns with defaults out of the INSERT.
 	block        *proto.Block
 	connRelease  func(*connect, error)
 	connAcquire  func(context.Context) (*connect, error)
 	onProcess    *onProcess
+	stats        *queryStats
+	retry        *RetryPolicy
 }
 
 func (b *batch) release(err error) {
@@ -171,6 +197,9 @@ func (b *batch) AppendStruct(v any) error {
 	if b.err != nil {
 		return b.err
 	}
+	if err := b.omitColumns(v); err != nil {
+		return err
+	}
 	values, err := b.conn.structMap.Map("AppendStruct", b.block.ColumnsNames(), v, false)
 	if err != nil {
 		return err
@@ -178,6 +207,45 @@ func (b *batch) AppendStruct(v any) error {
 	return b.Append(values...)
 }
 
+// omitColumns restarts an empty batch without the columns the struct has no field for, when the server can fill
+// them with their default expressions and the batch was prepared WithOmitDefaultedColumns
+func (b *batch) omitColumns(v any) error {
+	if !b.omitDefaults || b.sent || b.flushed || b.block.Rows() != 0 {
+		return nil
+	}
+	columns := b.block.ColumnsNames()
+	omitted := omittedColumns(b.conn.structMap, b.tableColumns, columns, v)
+	if len(omitted) == 0 {
+		return nil
+	}
+	b.conn.debugf("[batch] omit columns with defaults %v", omitted)
+	// the insert started with every column, end it without data and start one that leaves out the omitted columns
+	if !b.released {
+		b.release(b.closeQuery())
+	}
+	b.query = insertQuery(b.table, withoutColumns(columns, omitted))
+	block, err := b.reset()
+	if err != nil {
+		b.err = err
+		return err
+	}
+	b.block = block
+	return nil
+}
+
+var (
+	_ driver.StatsBatch        = (*batch)(nil)
+	_ driver.TableColumnsBatch = (*batch)(nil)
+)
+
+func (b *batch) TableColumns() []driver.TableColumn {
+	return slices.Clone(b.tableColumns)
+}
+
+func (b *batch) DefaultedColumns() []string {
+	return defaultedColumns(b.tableColumns, b.block.ColumnsNames())
+}
+
 func (b *batch) IsSent() bool {
 	return b.sent
 }
@@ -206,6 +274,11 @@ func (b *batch) Column(idx int) driver.BatchColumn {
 }
 
 func (b *batch) Send() (err error) {
+	_, op := b.conn.telemetry().start(b.ctx, operationBatchSend, b.conn.addr)
+	op.setRows(b.block.Rows())
+	defer func() {
+		op.end(err)
+	}()
 	stopCW := contextWatchdog(b.ctx, func() {
 		// close TCP connection on context cancel. There is no other way simple way to interrupt underlying operations.
 		// as verified in the test, this is safe to do and cleanups resources later on
@@ -227,34 +300,65 @@ func (b *batch) Send() (err error) {
 			return err
 		}
 	}
-	if b.block.Rows() != 0 {
-		if err = b.conn.sendData(b.block, ""); err != nil {
-			// there might be an error caused by context cancellation
-			// in this case we should return context error instead of net.OpError
-			if ctxErr := b.ctx.Err(); ctxErr != nil {
-				return ctxErr
-			}
+	for retry := 1; ; retry++ {
+		if err = b.send(); err == nil {
+			b.stats.finish()
+			return nil
+		}
+		// there might be an error caused by context cancellation
+		// in this case we should return context error instead of net.OpError
+		if ctxErr := b.ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		// data sent by Flush may already be stored, so only a batch that is sent at once is resent
+		if b.flushed || !b.retry.wait(b.ctx, retry, err) {
+			return err
+		}
+		b.release(err)
+		b.stats.reset()
+	}
+}
 
+func (b *batch) SendWithStats() (QueryStats, error) {
+	if err := b.Send(); err != nil {
+		return QueryStats{}, err
+	}
+	return b.stats.Stats(), nil
+}
+
+// send writes the retained block and ends the insert, on a new connection if the last one was released
+func (b *batch) send() error {
+	if b.released {
+		if err := b.resetConnection(); err != nil {
 			return err
 		}
 	}
-	if err = b.closeQuery(); err != nil {
-		return err
+	if b.block.Rows() != 0 {
+		if err := b.conn.sendData(b.block, ""); err != nil {
+			return err
+		}
 	}
-	return nil
+	return b.closeQuery()
 }
 
-func (b *batch) resetConnection() (err error) {
+func (b *batch) resetConnection() error {
+	_, err := b.reset()
+	return err
+}
+
+// reset starts the insert on a new connection and returns the header block sent by the server
+func (b *batch) reset() (_ *proto.Block, err error) {
 	// acquire a new conn
 	if b.conn, err = b.connAcquire(b.ctx); err != nil {
-		return err
+		return nil, err
 	}
-
-	defer func() {
-		b.released = false
-	}()
+	b.released = false
 
 	options := queryOptions(b.ctx)
+	if err = options.bindParams(b.query, b.conn.server.Timezone); err != nil {
+		b.release(err)
+		return nil, err
+	}
 	if deadline, ok := b.ctx.Deadline(); ok {
 		b.conn.conn.SetDeadline(deadline)
 		defer b.conn.conn.SetDeadline(time.Time{})
@@ -262,15 +366,16 @@ func (b *batch) resetConnection() (err error) {
 
 	if err = b.conn.sendQuery(b.query, &options); err != nil {
 		b.release(err)
-		return err
+		return nil, err
 	}
 
-	if _, err = b.conn.firstBlock(b.ctx, b.onProcess); err != nil {
+	block, err := b.conn.firstBlock(b.ctx, b.onProcess)
+	if err != nil {
 		b.release(err)
-		return err
+		return nil, err
 	}
 
-	return nil
+	return block, nil
 }
 
 func (b *batch) Flush() error {
@@ -286,6 +391,7 @@ func (b *batch) Flush() error {
 		}
 	}
 	if b.block.Rows() != 0 {
+		b.flushed = true
 		if err := b.conn.sendData(b.block, ""); err != nil {
 			// broken pipe/conn reset aren't generally recoverable on retry
 			if errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) {
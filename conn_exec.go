@@ -24,14 +24,28 @@ import (
 )
 
 func (c *connect) exec(ctx context.Context, query string, args ...any) error {
+	_, err := c.execWithStats(ctx, query, args...)
+	return err
+}
+
+func (c *connect) execWithStats(ctx context.Context, query string, args ...any) (_ QueryStats, err error) {
+	ctx, op := c.telemetry().start(ctx, operationQuery, c.addr)
 	var (
 		options                    = queryOptions(ctx)
+		onProcess                  = options.onProcess()
+		stats                      = newQueryStats()
 		queryParamsProtocolSupport = c.revision >= proto.DBMS_MIN_PROTOCOL_VERSION_WITH_PARAMETERS
-		body, err                  = bindQueryOrAppendParameters(queryParamsProtocolSupport, &options, query, c.server.Timezone, args...)
+		body                       string
 	)
-	if err != nil {
-		return err
+	stats.observe(onProcess)
+	defer func() {
+		op.setStats(stats)
+		op.end(err)
+	}()
+	if body, err = bindQueryOrAppendParameters(queryParamsProtocolSupport, &options, query, c.server.Timezone, args...); err != nil {
+		return QueryStats{}, err
 	}
+	op.traceQuery(&options)
 	// set a read deadline - alternative to context.Read operation will fail if no data is received after deadline.
 	c.conn.SetReadDeadline(time.Now().Add(c.readTimeout))
 	defer c.conn.SetReadDeadline(time.Time{})
@@ -40,8 +54,12 @@ func (c *connect) exec(ctx context.Context, query string, args ...any) error {
 		c.conn.SetDeadline(deadline)
 		defer c.conn.SetDeadline(time.Time{})
 	}
-	if err := c.sendQuery(body, &options); err != nil {
-		return err
+	if err = c.sendQuery(body, &options); err != nil {
+		return QueryStats{}, err
+	}
+	if err = c.process(ctx, onProcess); err != nil {
+		return QueryStats{}, err
 	}
-	return c.process(ctx, options.onProcess())
+	stats.finish()
+	return stats.Stats(), nil
 }
@@ -25,7 +25,7 @@ import (
 	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
 )
 
-func (c *connect) handshake(database, username, password string) error {
+func (c *connect) handshake(database string, auth *credentials) error {
 	defer c.buffer.Reset()
 	c.debugf("[handshake] -> %s", proto.ClientHandshake{})
 	// set a read deadline - alternative to context.Read operation will fail if no data is received after deadline.
@@ -37,20 +37,34 @@ func (c *connect) handshake(database, username, password string) error {
 	{
 		c.buffer.PutByte(proto.ClientHello)
 		handshake := &proto.ClientHandshake{
-			ProtocolVersion: ClientTCPProtocolVersion,
+			ProtocolVersion: c.revision,
 			ClientName:      c.opt.ClientInfo.String(),
 			ClientVersion:   proto.Version{ClientVersionMajor, ClientVersionMinor, ClientVersionPatch}, //nolint:govet
 		}
 		handshake.Encode(c.buffer)
 		{
 			c.buffer.PutString(database)
-			c.buffer.PutString(username)
-			c.buffer.PutString(password)
+			switch {
+			case auth.signer != nil:
+				c.buffer.PutString(sshKeyAuthMarker + auth.username)
+				c.buffer.PutString("")
+			case len(auth.token) != 0:
+				c.buffer.PutString(jwtAuthMarker)
+				c.buffer.PutString(auth.token)
+			default:
+				c.buffer.PutString(auth.username)
+				c.buffer.PutString(auth.password)
+			}
 		}
 		if err := c.flush(); err != nil {
 			return err
 		}
 	}
+	if auth.signer != nil {
+		if err := c.sshChallenge(database, auth); err != nil {
+			return err
+		}
+	}
 	{
 		packet, err := c.reader.ReadByte()
 		if err != nil {
@@ -63,6 +77,9 @@ func (c *connect) handshake(database, username, password string) error {
 			if err := c.server.Decode(c.reader); err != nil {
 				return err
 			}
+			if err := c.readHelloExtensions(); err != nil {
+				return err
+			}
 		case proto.ServerEndOfStream:
 			c.debugf("[handshake] <- end of stream")
 			return nil
@@ -82,9 +99,67 @@ func (c *connect) handshake(database, username, password string) error {
 	return nil
 }
 
+// sshChallenge asks the server for a challenge and answers it with a signature made by the user's SSH key.
+// It runs between the client hello and the server hello.
+func (c *connect) sshChallenge(database string, auth *credentials) error {
+	c.debugf("[handshake] -> ssh challenge request")
+	c.buffer.PutByte(proto.ClientSSHChallengeRequest)
+	if err := c.flush(); err != nil {
+		return err
+	}
+	packet, err := c.reader.ReadByte()
+	if err != nil {
+		return err
+	}
+	var challenge string
+	switch packet {
+	case proto.ServerException:
+		return c.exception()
+	case proto.ServerSSHChallenge:
+		if challenge, err = c.reader.Str(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("[handshake] unexpected packet [%d] from server, expected ssh challenge", packet)
+	}
+	signature, err := signSSHChallenge(auth.signer, sshChallengeMessage(c.revision, database, auth.username, challenge))
+	if err != nil {
+		return fmt.Errorf("[handshake] sign ssh challenge: %w", err)
+	}
+	c.debugf("[handshake] -> ssh challenge response")
+	c.buffer.PutByte(proto.ClientSSHChallengeResponse)
+	c.buffer.PutString(string(signature))
+	return c.flush()
+}
+
+// readHelloExtensions reads the server hello fields that depend on the revision announced by the client.
+func (c *connect) readHelloExtensions() error {
+	revision := c.revision
+	if c.server.Revision < revision {
+		revision = c.server.Revision
+	}
+	if revision >= proto.DBMS_MIN_PROTOCOL_VERSION_WITH_PASSWORD_COMPLEXITY_RULES {
+		rules, err := c.reader.UVarInt()
+		if err != nil {
+			return fmt.Errorf("could not read password complexity rules: %v", err)
+		}
+		for i := uint64(0); i < rules*2; i++ {
+			if _, err := c.reader.Str(); err != nil {
+				return fmt.Errorf("could not read password complexity rules: %v", err)
+			}
+		}
+	}
+	if revision >= proto.DBMS_MIN_REVISION_WITH_INTERSERVER_SECRET_V2 {
+		if _, err := c.reader.UInt64(); err != nil {
+			return fmt.Errorf("could not read server nonce: %v", err)
+		}
+	}
+	return nil
+}
+
 func (c *connect) sendAddendum() error {
 	if c.revision >= proto.DBMS_MIN_PROTOCOL_VERSION_WITH_QUOTA_KEY {
-		c.buffer.PutString("") // todo quota key support
+		c.buffer.PutString(c.opt.QuotaKey)
 	}
 
 	return c.flush()
@@ -0,0 +1,51 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddendumQuotaKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		revision uint64
+		addendum bool
+	}{
+		{"before quota key", proto.DBMS_MIN_PROTOCOL_VERSION_WITH_QUOTA_KEY - 1, false},
+		{"with quota key", proto.DBMS_MIN_PROTOCOL_VERSION_WITH_QUOTA_KEY, true},
+		{"current revision", proto.DBMS_TCP_PROTOCOL_VERSION, true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			conn, hello := dialFakeHandshakeWithOptions(t, testCase.revision, Options{QuotaKey: "tenant_1"})
+			assert.Equal(t, testCase.revision, conn.revision)
+			assert.Equal(t, testCase.addendum, hello.addendum)
+			if testCase.addendum {
+				assert.Equal(t, "tenant_1", hello.quotaKey)
+			}
+		})
+	}
+
+	_, hello := dialFakeHandshakeWithOptions(t, proto.DBMS_TCP_PROTOCOL_VERSION, Options{})
+	assert.True(t, hello.addendum)
+	assert.Empty(t, hello.quotaKey)
+}
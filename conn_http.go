@@ -32,6 +32,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -42,12 +43,16 @@ import (
 	chproto "github.com/ClickHouse/ch-go/proto"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
 	"github.com/andybalholm/brotli"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
 const (
-	quotaKeyParamName = "quota_key"
-	queryIDParamName  = "query_id"
+	quotaKeyParamName       = "quota_key"
+	queryIDParamName        = "query_id"
+	sessionIDParamName      = "session_id"
+	sessionTimeoutParamName = "session_timeout"
+	sessionCheckParamName   = "session_check"
 )
 
 type Pool[T any] struct {
@@ -119,7 +124,11 @@ func (rw *HTTPReaderWriter) reset(pw *io.PipeWriter) io.WriteCloser {
 	}
 }
 
-func dialHttp(ctx context.Context, addr string, num int, opt *Options) (*httpConnect, error) {
+func dialHttp(ctx context.Context, addr string, num int, opt *Options) (_ *httpConnect, err error) {
+	ctx, op := opt.telemetry.start(ctx, operationDial, addr)
+	defer func() {
+		op.end(err)
+	}()
 	var debugf = func(format string, v ...any) {}
 	if opt.Debug {
 		if opt.Debugf != nil {
@@ -156,16 +165,25 @@ func dialHttp(ctx context.Context, addr string, num int, opt *Options) (*httpCon
 		headers[k] = v
 	}
 
-	if opt.TLS == nil && len(opt.Auth.Username) > 0 {
-		if len(opt.Auth.Password) > 0 {
-			u.User = url.UserPassword(opt.Auth.Username, opt.Auth.Password)
+	auth, err := opt.credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case auth.signer != nil:
+		return nil, ErrSSHAuthOverHTTP
+	case len(auth.token) != 0:
+		headers["Authorization"] = "Bearer " + auth.token
+	case opt.TLS == nil && len(auth.username) > 0:
+		if len(auth.password) > 0 {
+			u.User = url.UserPassword(auth.username, auth.password)
 		} else {
-			u.User = url.User(opt.Auth.Username)
+			u.User = url.User(auth.username)
 		}
-	} else if opt.TLS != nil && len(opt.Auth.Username) > 0 {
-		headers["X-ClickHouse-User"] = opt.Auth.Username
-		if len(opt.Auth.Password) > 0 {
-			headers["X-ClickHouse-Key"] = opt.Auth.Password
+	case opt.TLS != nil && len(auth.username) > 0:
+		headers["X-ClickHouse-User"] = auth.username
+		if len(auth.password) > 0 {
+			headers["X-ClickHouse-Key"] = auth.password
 			headers["X-ClickHouse-SSL-Certificate-Auth"] = "off"
 		} else {
 			headers["X-ClickHouse-SSL-Certificate-Auth"] = "on"
@@ -178,6 +196,9 @@ func dialHttp(ctx context.Context, addr string, num int, opt *Options) (*httpCon
 	if len(opt.Auth.Database) > 0 {
 		query.Set("database", opt.Auth.Database)
 	}
+	if len(opt.QuotaKey) > 0 {
+		query.Set(quotaKeyParamName, opt.QuotaKey)
+	}
 
 	if opt.Compression == nil {
 		opt.Compression = &Compression{
@@ -199,6 +220,15 @@ func dialHttp(ctx context.Context, addr string, num int, opt *Options) (*httpCon
 	}
 
 	query.Set("default_format", "Native")
+	// transactions are bound to the server session
+	var sessionID string
+	if opt.HttpSession || opt.Transactions {
+		sessionID = uuid.NewString()
+		query.Set(sessionIDParamName, sessionID)
+		if opt.HttpSessionTimeout > 0 {
+			query.Set(sessionTimeoutParamName, fmt.Sprint(int(opt.HttpSessionTimeout.Seconds())))
+		}
+	}
 	u.RawQuery = query.Encode()
 
 	httpProxy := http.ProxyFromEnvironment
@@ -235,19 +265,29 @@ func dialHttp(ctx context.Context, addr string, num int, opt *Options) (*httpCon
 		blockBufferSize: opt.BlockBufferSize,
 		headers:         headers,
 	}
+	// the first queries take the place of the handshake of the native protocol
+	_, handshake := opt.telemetry.start(ctx, operationHandshake, addr)
 	location, err := conn.readTimeZone(ctx)
 	if err != nil {
+		handshake.end(err)
 		return nil, err
 	}
+	if sessionID != "" {
+		// the first query created the session, later queries must fail rather than silently start a new one
+		query.Set(sessionCheckParamName, "1")
+		u.RawQuery = query.Encode()
+	}
 	if num == 1 {
 		version, err := conn.readVersion(ctx)
 		if err != nil {
+			handshake.end(err)
 			return nil, err
 		}
 		if !resources.ClientMeta.IsSupportedClickHouseVersion(version) {
 			debugf("WARNING: version %v of ClickHouse is not supported by this client\n", version)
 		}
 	}
+	handshake.end(nil)
 
 	return &httpConnect{
 		client: &http.Client{
@@ -261,6 +301,8 @@ func dialHttp(ctx context.Context, addr string, num int, opt *Options) (*httpCon
 		location:        location,
 		blockBufferSize: opt.BlockBufferSize,
 		headers:         headers,
+		sessionID:       sessionID,
+		telemetry:       opt.telemetry,
 	}, nil
 }
 
@@ -274,6 +316,8 @@ type httpConnect struct {
 	compressionPool Pool[HTTPReaderWriter]
 	blockBufferSize uint8
 	headers         map[string]string
+	sessionID       string
+	telemetry       *telemetry
 }
 
 func (h *httpConnect) isBad() bool {
@@ -412,6 +456,7 @@ func (h *httpConnect) sendStreamQuery(ctx context.Context, r io.Reader, options
 	if err != nil {
 		return nil, err
 	}
+	onProgress(res, options)
 
 	return res, nil
 }
@@ -426,6 +471,7 @@ func (h *httpConnect) sendQuery(ctx context.Context, query string, options *Quer
 	if err != nil {
 		return nil, err
 	}
+	onProgress(res, options)
 	return res, nil
 }
 
@@ -480,6 +526,10 @@ func (h *httpConnect) createRequest(ctx context.Context, requestUrl string, read
 		for key, value := range options.parameters {
 			query.Set(fmt.Sprintf("param_%s", key), value)
 		}
+		// the progress handler is called with the progress headers, unless the query turned them off
+		if options.events.progress != nil && !query.Has(sendProgressSetting) {
+			query.Set(sendProgressSetting, "1")
+		}
 		req.URL.RawQuery = query.Encode()
 	}
 	return req, nil
@@ -545,12 +595,62 @@ func (h *httpConnect) executeRequest(req *http.Request) (*http.Response, error)
 		if err != nil {
 			return nil, fmt.Errorf("clickhouse [execute]:: %d code: failed to read the response: %w", resp.StatusCode, err)
 		}
-		return nil, fmt.Errorf("clickhouse [execute]:: %d code: %s", resp.StatusCode, string(msg))
+		if h.sessionID != "" && isSessionExpiredError(msg) {
+			// the session and everything bound to it is gone, the connection can not be used anymore
+			h.close()
+			return nil, fmt.Errorf("clickhouse [execute]:: session %s expired: %w", h.sessionID, driver.ErrBadConn)
+		}
+		return nil, newHttpError(resp, msg)
 	}
 	return resp, nil
 }
 
-func (h *httpConnect) ping(ctx context.Context) error {
+// httpError is a request the server answered with an error status. It unwraps to the server exception
+// if the response has one, so the error code can be matched with errors.Is and errors.As.
+type httpError struct {
+	statusCode int
+	message    string
+	exception  *Exception
+}
+
+func newHttpError(resp *http.Response, msg []byte) *httpError {
+	err := &httpError{
+		statusCode: resp.StatusCode,
+		message:    string(msg),
+	}
+	if _, err.exception = parseHttpStreamException(msg); err.exception == nil {
+		if code, parseErr := strconv.ParseInt(resp.Header.Get("X-ClickHouse-Exception-Code"), 10, 32); parseErr == nil {
+			err.exception = &Exception{
+				Code:    int32(code),
+				Name:    "DB::Exception",
+				Message: strings.TrimSpace(string(msg)),
+			}
+		}
+	}
+	return err
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("clickhouse [execute]:: %d code: %s", e.statusCode, e.message)
+}
+
+func (e *httpError) Unwrap() error {
+	if e.exception == nil {
+		return nil
+	}
+	return e.exception
+}
+
+// isSessionExpiredError reports whether the server no longer knows the session of the request
+func isSessionExpiredError(msg []byte) bool {
+	return bytes.Contains(msg, []byte("SESSION_NOT_FOUND")) || bytes.Contains(msg, []byte("Code: 372."))
+}
+
+func (h *httpConnect) ping(ctx context.Context) (err error) {
+	ctx, op := h.telemetry.start(ctx, operationPing, h.url.Host)
+	defer func() {
+		op.end(err)
+	}()
 	rows, err := h.query(Context(ctx, ignoreExternalTables()), nil, "SELECT 1")
 	if err != nil {
 		return err
@@ -45,18 +45,28 @@ func (h *httpConnect) prepareBatch(ctx context.Context, query string, opts drive
 	block := &proto.Block{}
 
 	columns := make(map[string]string)
-	var colNames []string
+	var (
+		colNames     []string
+		tableColumns []driver.TableColumn
+	)
 	for r.Next() {
 		var (
-			colName      string
-			colType      string
-			default_type string
-			ignore       string
+			colName           string
+			colType           string
+			default_type      string
+			defaultExpression string
+			ignore            string
 		)
 
-		if err = r.Scan(&colName, &colType, &default_type, &ignore, &ignore, &ignore, &ignore); err != nil {
+		if err = r.Scan(&colName, &colType, &default_type, &defaultExpression, &ignore, &ignore, &ignore); err != nil {
 			return nil, err
 		}
+		tableColumns = append(tableColumns, driver.TableColumn{
+			Name:              colName,
+			Type:              colType,
+			DefaultKind:       default_type,
+			DefaultExpression: defaultExpression,
+		})
 		// these column types cannot be specified in INSERT queries
 		if default_type == "MATERIALIZED" || default_type == "ALIAS" {
 			continue
@@ -86,22 +96,29 @@ func (h *httpConnect) prepareBatch(ctx context.Context, query string, opts drive
 	}
 
 	return &httpBatch{
-		ctx:       ctx,
-		conn:      h,
-		structMap: &structMap{},
-		block:     block,
-		query:     query,
+		ctx:          ctx,
+		conn:         h,
+		structMap:    &structMap{},
+		block:        block,
+		query:        query,
+		table:        tableName,
+		tableColumns: tableColumns,
+		omitDefaults: opts.OmitDefaulted,
 	}, nil
 }
 
 type httpBatch struct {
-	query     string
-	err       error
-	ctx       context.Context
-	conn      *httpConnect
-	structMap *structMap
-	sent      bool
-	block     *proto.Block
+	query        string
+	table        string
+	tableColumns []driver.TableColumn
+	err          error
+	ctx          context.Context
+	conn         *httpConnect
+	structMap    *structMap
+	sent         bool
+	omitDefaults bool
+	block        *proto.Block
+	stats        *queryStats
 }
 
 // Flush TODO: noop on http currently - requires streaming to be implemented
@@ -130,6 +147,9 @@ func (b *httpBatch) Append(v ...any) error {
 }
 
 func (b *httpBatch) AppendStruct(v any) error {
+	if err := b.omitColumns(v); err != nil {
+		return err
+	}
 	values, err := b.structMap.Map("AppendStruct", b.block.ColumnsNames(), v, false)
 	if err != nil {
 		return err
@@ -137,6 +157,39 @@ func (b *httpBatch) AppendStruct(v any) error {
 	return b.Append(values...)
 }
 
+// omitColumns leaves the columns the struct has no field for out of an empty batch, when the server can fill
+// them with their default expressions and the batch was prepared WithOmitDefaultedColumns
+func (b *httpBatch) omitColumns(v any) error {
+	if !b.omitDefaults || b.sent || b.block.Rows() != 0 {
+		return nil
+	}
+	columns := b.block.ColumnsNames()
+	omitted := omittedColumns(b.structMap, b.tableColumns, columns, v)
+	if len(omitted) == 0 {
+		return nil
+	}
+	block := &proto.Block{}
+	for _, c := range b.block.Columns {
+		if slices.Contains(omitted, c.Name()) {
+			continue
+		}
+		if err := block.AddColumn(c.Name(), c.Type()); err != nil {
+			return err
+		}
+	}
+	b.block = block
+	b.query = insertQuery(b.table, block.ColumnsNames())
+	return nil
+}
+
+func (b *httpBatch) TableColumns() []driver.TableColumn {
+	return slices.Clone(b.tableColumns)
+}
+
+func (b *httpBatch) DefaultedColumns() []string {
+	return defaultedColumns(b.tableColumns, b.block.ColumnsNames())
+}
+
 func (b *httpBatch) Column(idx int) driver.BatchColumn {
 	if len(b.block.Columns) <= idx {
 		return &batchColumn{
@@ -160,8 +213,12 @@ func (b *httpBatch) IsSent() bool {
 }
 
 func (b *httpBatch) Send() (err error) {
+	_, op := b.conn.telemetry.start(b.ctx, operationBatchSend, b.conn.url.Host)
+	op.setRows(b.block.Rows())
+	b.stats = newQueryStats()
 	defer func() {
 		b.sent = true
+		op.end(err)
 	}()
 	if b.sent {
 		return ErrBatchAlreadySent
@@ -170,6 +227,9 @@ func (b *httpBatch) Send() (err error) {
 		return b.err
 	}
 	options := queryOptions(b.ctx)
+	if err := options.bindParams(b.query, b.conn.location); err != nil {
+		return err
+	}
 
 	headers := make(map[string]string)
 
@@ -214,13 +274,22 @@ func (b *httpBatch) Send() (err error) {
 
 	if res != nil {
 		defer res.Body.Close()
+		b.stats.summary(res.Header.Get("X-ClickHouse-Summary"))
 		// we don't care about result, so just discard it to reuse connection
 		_, _ = io.Copy(io.Discard, res.Body)
 	}
+	b.stats.finish()
 
 	return err
 }
 
+func (b *httpBatch) SendWithStats() (QueryStats, error) {
+	if err := b.Send(); err != nil {
+		return QueryStats{}, err
+	}
+	return b.stats.Stats(), nil
+}
+
 func (b *httpBatch) Rows() int {
 	return b.block.Rows()
 }
@@ -229,4 +298,8 @@ func (b *httpBatch) Columns() []column.Interface {
 	return slices.Clone(b.block.Columns)
 }
 
-var _ driver.Batch = (*httpBatch)(nil)
+var (
+	_ driver.Batch             = (*httpBatch)(nil)
+	_ driver.StatsBatch        = (*httpBatch)(nil)
+	_ driver.TableColumnsBatch = (*httpBatch)(nil)
+)
@@ -0,0 +1,88 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	chproto "github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// describeTable encodes the result of DESCRIBE TABLE in the Native format
+func describeTable(t *testing.T, columns ...driver.TableColumn) []byte {
+	block := &proto.Block{}
+	for _, name := range []string{"name", "type", "default_type", "default_expression", "comment", "codec_expression", "ttl_expression"} {
+		require.NoError(t, block.AddColumn(name, "String"))
+	}
+	for _, c := range columns {
+		require.NoError(t, block.Append(c.Name, c.Type, c.DefaultKind, c.DefaultExpression, "", "", ""))
+	}
+	buffer := &chproto.Buffer{}
+	require.NoError(t, block.Encode(buffer, 0))
+	return buffer.Buf
+}
+
+func TestHttpBatchTableColumns(t *testing.T) {
+	tableColumns := []driver.TableColumn{
+		{Name: "id", Type: "UInt64"},
+		{Name: "created", Type: "DateTime", DefaultKind: proto.ColumnDefault, DefaultExpression: "now()"},
+		{Name: "day", Type: "Date", DefaultKind: proto.ColumnMaterialized, DefaultExpression: "toDate(created)"},
+		{Name: "name", Type: "String", DefaultKind: proto.ColumnAlias, DefaultExpression: "toString(id)"},
+	}
+	conn := newTestHttpConnect(t, func(w http.ResponseWriter, r *http.Request) {
+		query, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(query), "DESCRIBE TABLE test_http_batch"))
+		w.Write(describeTable(t, tableColumns...))
+	})
+	batch, err := conn.prepareBatch(context.Background(), "INSERT INTO test_http_batch", driver.PrepareBatchOptions{}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, tableColumns, batch.(driver.TableColumnsBatch).TableColumns())
+	assert.Equal(t, []string{"id", "created"}, columnNames(batch.Columns()))
+	assert.Equal(t, []string{"day"}, batch.(driver.TableColumnsBatch).DefaultedColumns())
+
+	type row struct {
+		ID uint64 `ch:"id"`
+	}
+	// columns are only omitted when the batch asks for it
+	assert.Error(t, batch.AppendStruct(&row{ID: 1}))
+	assert.Equal(t, []string{"id", "created"}, columnNames(batch.Columns()))
+
+	batch, err = conn.prepareBatch(context.Background(), "INSERT INTO test_http_batch", driver.PrepareBatchOptions{OmitDefaulted: true}, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, batch.AppendStruct(&row{ID: 1}))
+	assert.Equal(t, []string{"id"}, columnNames(batch.Columns()))
+	assert.Equal(t, []string{"created", "day"}, batch.(driver.TableColumnsBatch).DefaultedColumns())
+	assert.Equal(t, "INSERT INTO test_http_batch (`id`) FORMAT Native", batch.(*httpBatch).query)
+}
+
+func columnNames[T interface{ Name() string }](columns []T) []string {
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.Name())
+	}
+	return names
+}
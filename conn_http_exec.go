@@ -23,18 +23,37 @@ import (
 )
 
 func (h *httpConnect) exec(ctx context.Context, query string, args ...any) error {
+	_, err := h.execWithStats(ctx, query, args...)
+	return err
+}
+
+func (h *httpConnect) execWithStats(ctx context.Context, query string, args ...any) (_ QueryStats, err error) {
+	ctx, op := h.telemetry.start(ctx, operationQuery, h.url.Host)
+	stats := newQueryStats()
+	defer func() {
+		op.setStats(stats)
+		op.end(err)
+	}()
 	options := queryOptions(ctx)
-	query, err := bindQueryOrAppendParameters(true, &options, query, h.location, args...)
+	query, err = bindQueryOrAppendParameters(true, &options, query, h.location, args...)
 	if err != nil {
-		return err
+		return QueryStats{}, err
 	}
+	op.setQueryID(options.queryID)
 
 	res, err := h.sendQuery(ctx, query, &options, h.headers)
 	if res != nil {
+		if options.queryID == "" {
+			op.setQueryID(res.Header.Get("X-ClickHouse-Query-Id"))
+		}
+		stats.summary(res.Header.Get("X-ClickHouse-Summary"))
 		defer res.Body.Close()
 		// we don't care about result, so just discard it to reuse connection
 		_, _ = io.Copy(io.Discard, res.Body)
 	}
-
-	return err
+	if err != nil {
+		return QueryStats{}, err
+	}
+	stats.finish()
+	return stats.Stats(), nil
 }
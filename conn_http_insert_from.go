@@ -0,0 +1,102 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/ClickHouse/ch-go/compress"
+)
+
+// httpInsertChunkSize is the size of the blocks data is split into when using LZ4 or ZSTD compression
+const httpInsertChunkSize = 1024 * 1024
+
+// insertFrom streams pre-formatted data as the body of an INSERT ... FORMAT query
+func (h *httpConnect) insertFrom(ctx context.Context, query string, reader io.Reader) error {
+	options := queryOptions(ctx)
+
+	headers := make(map[string]string)
+
+	r, pw := io.Pipe()
+	defer r.Close()
+	crw := h.compressionPool.Get()
+	w := crw.reset(pw)
+
+	defer h.compressionPool.Put(crw)
+
+	switch h.compression {
+	case CompressionGZIP, CompressionDeflate, CompressionBrotli:
+		headers["Content-Encoding"] = h.compression.String()
+	case CompressionZSTD, CompressionLZ4:
+		options.settings["decompress"] = "1"
+	}
+
+	go func() {
+		if err := h.writeInsertData(w, reader); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if err := w.Close(); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.Close()
+	}()
+
+	options.settings["query"] = query
+	headers["Content-Type"] = "application/octet-stream"
+	for k, v := range h.headers {
+		headers[k] = v
+	}
+	res, err := h.sendStreamQuery(ctx, r, &options, headers)
+
+	if res != nil {
+		defer res.Body.Close()
+		// we don't care about result, so just discard it to reuse connection
+		_, _ = io.Copy(io.Discard, res.Body)
+	}
+
+	return err
+}
+
+func (h *httpConnect) writeInsertData(w io.Writer, reader io.Reader) error {
+	if h.compression != CompressionLZ4 && h.compression != CompressionZSTD {
+		_, err := io.Copy(w, reader)
+		return err
+	}
+	chunk := make([]byte, httpInsertChunkSize)
+	for {
+		n, err := io.ReadFull(reader, chunk)
+		if n > 0 {
+			if err := h.blockCompressor.Compress(compress.Method(h.compression), chunk[:n]); err != nil {
+				return err
+			}
+			if _, err := w.Write(h.blockCompressor.Data); err != nil {
+				return err
+			}
+		}
+		switch {
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+			return nil
+		case err != nil:
+			return err
+		}
+	}
+}
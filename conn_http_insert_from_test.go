@@ -0,0 +1,92 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/ch-go/compress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpInsertFrom(t *testing.T) {
+	data := strings.Repeat("1,\"a\"\n", 100000)
+	tests := []struct {
+		compression CompressionMethod
+		read        func(r *http.Request) (io.Reader, error)
+	}{
+		{
+			compression: CompressionNone,
+			read:        func(r *http.Request) (io.Reader, error) { return r.Body, nil },
+		},
+		{
+			compression: CompressionGZIP,
+			read: func(r *http.Request) (io.Reader, error) {
+				assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+				return gzip.NewReader(r.Body)
+			},
+		},
+		{
+			compression: CompressionLZ4,
+			read: func(r *http.Request) (io.Reader, error) {
+				assert.Equal(t, "1", r.URL.Query().Get("decompress"))
+				return compress.NewReader(r.Body), nil
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.compression.String(), func(t *testing.T) {
+			var received []byte
+			conn := newTestHttpConnect(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "INSERT INTO t FORMAT CSV", r.URL.Query().Get("query"))
+				reader, err := test.read(r)
+				require.NoError(t, err)
+				// the block reader reports a wrapped io.EOF once the body is exhausted
+				if received, err = io.ReadAll(reader); errors.Is(err, io.EOF) {
+					err = nil
+				}
+				require.NoError(t, err)
+			})
+			compressionPool, err := createCompressionPool(&Compression{Method: test.compression, Level: 3})
+			require.NoError(t, err)
+			conn.compression, conn.compressionPool = test.compression, compressionPool
+			conn.blockCompressor = compress.NewWriter()
+
+			require.NoError(t, conn.insertFrom(context.Background(), "INSERT INTO t FORMAT CSV", strings.NewReader(data)))
+			assert.Equal(t, data, string(received))
+		})
+	}
+}
+
+func TestHttpInsertFromException(t *testing.T) {
+	conn := newTestHttpConnect(t, func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Code: 27. DB::Exception: Cannot parse input. (CANNOT_PARSE_INPUT_ASSERTION_FAILED)"))
+	})
+	err := conn.insertFrom(context.Background(), "INSERT INTO t FORMAT CSV", strings.NewReader("x"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CANNOT_PARSE_INPUT_ASSERTION_FAILED")
+}
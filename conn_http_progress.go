@@ -0,0 +1,89 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+const sendProgressSetting = "send_progress_in_http_headers"
+
+// httpProgress is the progress of a query in the X-ClickHouse-Progress and X-ClickHouse-Summary headers, the server
+// sends the totals since the start of the query with the values as strings. elapsed_ns is only sent by newer servers.
+type httpProgress struct {
+	ReadRows     uint64 `json:"read_rows,string"`
+	ReadBytes    uint64 `json:"read_bytes,string"`
+	WrittenRows  uint64 `json:"written_rows,string"`
+	WrittenBytes uint64 `json:"written_bytes,string"`
+	TotalRows    uint64 `json:"total_rows_to_read,string"`
+	ResultRows   uint64 `json:"result_rows,string"`
+	ResultBytes  uint64 `json:"result_bytes,string"`
+	ElapsedNs    uint64 `json:"elapsed_ns,string"`
+}
+
+func parseHttpProgress(header string) (progress httpProgress, ok bool) {
+	if len(header) == 0 {
+		return progress, false
+	}
+	if err := json.Unmarshal([]byte(header), &progress); err != nil {
+		return progress, false
+	}
+	return progress, true
+}
+
+// increment returns the progress made since last, in the increments the native protocol sends
+func (p httpProgress) increment(last httpProgress) *Progress {
+	delta := func(value, last uint64) uint64 {
+		if value < last {
+			return 0
+		}
+		return value - last
+	}
+	return &Progress{
+		Rows:       delta(p.ReadRows, last.ReadRows),
+		Bytes:      delta(p.ReadBytes, last.ReadBytes),
+		TotalRows:  delta(p.TotalRows, last.TotalRows),
+		WroteRows:  delta(p.WrittenRows, last.WrittenRows),
+		WroteBytes: delta(p.WrittenBytes, last.WrittenBytes),
+		Elapsed:    time.Duration(delta(p.ElapsedNs, last.ElapsedNs)),
+	}
+}
+
+// onProgress calls the progress handler of a query with the progress of each X-ClickHouse-Progress header of the
+// response, followed by the X-ClickHouse-Summary header. The server sends them until the first block of the result
+// is ready, and Go reads them all at once, so the handler is called when the response starts rather than while the
+// query runs.
+func onProgress(res *http.Response, options *QueryOptions) {
+	if options == nil || options.events.progress == nil {
+		return
+	}
+	var last httpProgress
+	headers := append(res.Header.Values("X-ClickHouse-Progress"), res.Header.Get("X-ClickHouse-Summary"))
+	for _, header := range headers {
+		progress, ok := parseHttpProgress(header)
+		if !ok {
+			continue
+		}
+		if increment := progress.increment(last); *increment != (Progress{}) {
+			options.events.progress(increment)
+		}
+		last = progress
+	}
+}
@@ -0,0 +1,63 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpProgress(t *testing.T) {
+	conn := newTestHttpConnect(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1", r.URL.Query().Get("send_progress_in_http_headers"))
+		w.Header().Add("X-ClickHouse-Progress", `{"read_rows":"10","read_bytes":"80","written_rows":"0","written_bytes":"0","total_rows_to_read":"30","elapsed_ns":"1000"}`)
+		w.Header().Add("X-ClickHouse-Progress", `{"read_rows":"25","read_bytes":"200","written_rows":"0","written_bytes":"0","total_rows_to_read":"30","elapsed_ns":"3000"}`)
+		w.Header().Set("X-ClickHouse-Summary", `{"read_rows":"30","read_bytes":"240","written_rows":"0","written_bytes":"0","total_rows_to_read":"30","result_rows":"0","result_bytes":"0","elapsed_ns":"4000"}`)
+	})
+	var progress []Progress
+	ctx := Context(context.Background(), WithProgress(func(p *Progress) {
+		progress = append(progress, *p)
+	}))
+	require.NoError(t, conn.exec(ctx, "SELECT * FROM numbers(30) FORMAT Null"))
+	assert.Equal(t, []Progress{
+		{Rows: 10, Bytes: 80, TotalRows: 30, Elapsed: 1000 * time.Nanosecond},
+		{Rows: 15, Bytes: 120, Elapsed: 2000 * time.Nanosecond},
+		{Rows: 5, Bytes: 40, Elapsed: 1000 * time.Nanosecond},
+	}, progress)
+}
+
+func TestHttpProgressDisabled(t *testing.T) {
+	conn := newTestHttpConnect(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.False(t, r.URL.Query().Has("send_progress_in_http_headers"))
+	})
+	require.NoError(t, conn.exec(context.Background(), "SELECT 1"))
+
+	conn = newTestHttpConnect(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "0", r.URL.Query().Get("send_progress_in_http_headers"))
+	})
+	ctx := Context(context.Background(),
+		WithProgress(func(*Progress) {}),
+		WithSettings(Settings{"send_progress_in_http_headers": 0}),
+	)
+	require.NoError(t, conn.exec(ctx, "SELECT 1"))
+}
@@ -26,13 +26,8 @@ import (
 	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
 )
 
-// release is ignored, because http used by std with empty release function
-func (h *httpConnect) query(ctx context.Context, release func(*connect, error), query string, args ...any) (*rows, error) {
-	options := queryOptions(ctx)
-	query, err := bindQueryOrAppendParameters(true, &options, query, h.location, args...)
-	if err != nil {
-		return nil, err
-	}
+// queryHeaders returns the headers of a query and requests a compressed response when compression is enabled
+func (h *httpConnect) queryHeaders(options *QueryOptions) map[string]string {
 	headers := make(map[string]string)
 	switch h.compression {
 	case CompressionZSTD, CompressionLZ4:
@@ -45,11 +40,36 @@ func (h *httpConnect) query(ctx context.Context, release func(*connect, error),
 	for k, v := range h.headers {
 		headers[k] = v
 	}
+	return headers
+}
 
-	res, err := h.sendQuery(ctx, query, &options, headers)
+// release is ignored, because http used by std with empty release function
+func (h *httpConnect) query(ctx context.Context, release func(*connect, error), query string, args ...any) (_ *rows, err error) {
+	ctx, op := h.telemetry.start(ctx, operationQuery, h.url.Host)
+	stats := newQueryStats()
+	// the span of a query that returns rows ends once they are read
+	streaming := false
+	defer func() {
+		if !streaming {
+			stats.finish()
+			op.setStats(stats)
+			op.end(err)
+		}
+	}()
+	options := queryOptions(ctx)
+	query, err = bindQueryOrAppendParameters(true, &options, query, h.location, args...)
 	if err != nil {
 		return nil, err
 	}
+	op.setQueryID(options.queryID)
+	res, err := h.sendQuery(ctx, query, &options, h.queryHeaders(&options))
+	if err != nil {
+		return nil, err
+	}
+	if options.queryID == "" {
+		op.setQueryID(res.Header.Get("X-ClickHouse-Query-Id"))
+	}
+	stats.summary(res.Header.Get("X-ClickHouse-Summary"))
 
 	if res.ContentLength == 0 {
 		block := &proto.Block{}
@@ -57,6 +77,7 @@ func (h *httpConnect) query(ctx context.Context, release func(*connect, error),
 			block:     block,
 			columns:   block.ColumnsNames(),
 			structMap: &structMap{},
+			stats:     stats,
 		}, nil
 	}
 
@@ -89,23 +110,32 @@ func (h *httpConnect) query(ctx context.Context, release func(*connect, error),
 		errCh  = make(chan error)
 		stream = make(chan *proto.Block, bufferSize)
 	)
+	streaming = true
 	go func() {
+		var failed error
+		defer func() {
+			op.setStats(stats)
+			op.end(failed)
+		}()
 		for {
 			block, err := h.readData(chReader, options.userLocation)
 			if err != nil {
 				// ch-go wraps EOF errors
 				if !errors.Is(err, io.EOF) {
+					failed = err
 					errCh <- err
 				}
 				break
 			}
 			select {
 			case <-ctx.Done():
-				errCh <- ctx.Err()
+				failed = ctx.Err()
+				errCh <- failed
 				break
 			case stream <- block:
 			}
 		}
+		stats.finish()
 		res.Body.Close()
 		h.compressionPool.Put(rw)
 		close(stream)
@@ -115,11 +145,13 @@ func (h *httpConnect) query(ctx context.Context, release func(*connect, error),
 	if block == nil {
 		block = &proto.Block{}
 	}
+	// the Native output format leaves out the totals and extremes of a query, so over HTTP rows never has them
 	return &rows{
 		block:     block,
 		stream:    stream,
 		errors:    errCh,
 		columns:   block.ColumnsNames(),
 		structMap: &structMap{},
+		stats:     stats,
 	}, nil
 }
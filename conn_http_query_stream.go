@@ -0,0 +1,174 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	chproto "github.com/ClickHouse/ch-go/proto"
+)
+
+// httpStreamExceptionWindow is how much of the response is held back so an exception written by the server
+// at the end of a stream is not returned to the caller as data
+const httpStreamExceptionWindow = 16 * 1024
+
+// httpStreamExceptionTrailer is the trailer the server sets when a query fails after the response has started
+const httpStreamExceptionTrailer = "X-ClickHouse-Exception-Code"
+
+var httpStreamExceptionRe = regexp.MustCompile(`(?:^|\n)Code: (\d+)\. DB::Exception: `)
+
+// queryStream sends a query and returns the decompressed response body in the given output format.
+// A FORMAT clause in the query takes precedence over the format.
+func (h *httpConnect) queryStream(ctx context.Context, format string, query string, args ...any) (io.ReadCloser, error) {
+	options := queryOptions(ctx)
+	query, err := bindQueryOrAppendParameters(true, &options, query, h.location, args...)
+	if err != nil {
+		return nil, err
+	}
+	req, err := h.prepareRequest(ctx, query, &options, h.queryHeaders(&options))
+	if err != nil {
+		return nil, err
+	}
+	if format != "" {
+		params := req.URL.Query()
+		params.Set("default_format", format)
+		req.URL.RawQuery = params.Encode()
+	}
+	res, err := h.executeRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	onProgress(res, &options)
+	rw := h.compressionPool.Get()
+	reader, err := rw.NewReader(res)
+	if err != nil {
+		res.Body.Close()
+		h.compressionPool.Put(rw)
+		return nil, err
+	}
+	if h.compression == CompressionLZ4 || h.compression == CompressionZSTD {
+		chReader := chproto.NewReader(reader)
+		chReader.EnableCompression()
+		reader = chReader
+	}
+	return &httpQueryStream{
+		response: res,
+		reader:   reader,
+		release: func() {
+			h.compressionPool.Put(rw)
+		},
+		buf: make([]byte, 32*1024),
+	}, nil
+}
+
+// httpQueryStream reads a query response, returning an exception the server appends to the body after
+// the response has started as an error instead of data. The body is only searched for an exception when it ends
+// abnormally, i.e. the server cut the chunked encoding short or set the X-ClickHouse-Exception-Code trailer,
+// so data that looks like an exception is returned as is by a response that ends normally.
+type httpQueryStream struct {
+	response *http.Response
+	reader   io.Reader
+	release  func()
+	buf      []byte
+	pending  []byte
+	err      error
+}
+
+func (s *httpQueryStream) Read(p []byte) (int, error) {
+	for {
+		switch {
+		case s.err != nil:
+			if len(s.pending) == 0 {
+				return 0, s.err
+			}
+			n := copy(p, s.pending)
+			s.pending = s.pending[n:]
+			return n, nil
+		case len(s.pending) > httpStreamExceptionWindow:
+			n := copy(p, s.pending[:len(s.pending)-httpStreamExceptionWindow])
+			s.pending = s.pending[n:]
+			return n, nil
+		}
+		n, err := s.reader.Read(s.buf)
+		s.pending = append(s.pending, s.buf[:n]...)
+		switch {
+		case err == nil:
+		case errors.Is(err, io.EOF):
+			s.err = io.EOF
+			if code := s.response.Trailer.Get(httpStreamExceptionTrailer); code != "" {
+				s.err = trailerException(code)
+				if i, exception := parseHttpStreamException(s.pending); exception != nil {
+					s.pending, s.err = s.pending[:i], exception
+				}
+			}
+		default:
+			s.err = err
+			if i, exception := parseHttpStreamException(s.pending); exception != nil {
+				s.pending, s.err = s.pending[:i], exception
+			}
+		}
+	}
+}
+
+func (s *httpQueryStream) Close() error {
+	if s.response == nil {
+		return nil
+	}
+	err := s.response.Body.Close()
+	s.response = nil
+	s.release()
+	return err
+}
+
+// trailerException is the exception of a response whose body holds no exception text despite the trailer
+func trailerException(code string) *Exception {
+	n, _ := strconv.ParseInt(code, 10, 32)
+	return &Exception{
+		Code:    int32(n),
+		Name:    "DB::Exception",
+		Message: "query failed after the response started",
+	}
+}
+
+// parseHttpStreamException finds an exception at the end of a response body, returning where it starts
+func parseHttpStreamException(body []byte) (int, *Exception) {
+	matches := httpStreamExceptionRe.FindAllSubmatchIndex(body, -1)
+	if len(matches) == 0 {
+		return 0, nil
+	}
+	match := matches[len(matches)-1]
+	code, err := strconv.ParseInt(string(body[match[2]:match[3]]), 10, 32)
+	if err != nil {
+		return 0, nil
+	}
+	start := match[0]
+	if body[start] == '\n' {
+		start++
+	}
+	return start, &Exception{
+		Code:    int32(code),
+		Name:    "DB::Exception",
+		Message: string(bytes.TrimSpace(body[match[1]:])),
+	}
+}
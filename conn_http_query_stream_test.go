@@ -0,0 +1,114 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHttpConnect(t *testing.T, handler http.HandlerFunc) *httpConnect {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	compressionPool, err := createCompressionPool(&Compression{Method: CompressionNone})
+	require.NoError(t, err)
+	u, err := url.Parse(server.URL + "?default_format=Native")
+	require.NoError(t, err)
+	return &httpConnect{
+		client:          server.Client(),
+		url:             u,
+		compressionPool: compressionPool,
+		headers:         map[string]string{},
+	}
+}
+
+func TestHttpQueryStream(t *testing.T) {
+	data := strings.Repeat("1,a\n", 10000)
+	conn := newTestHttpConnect(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "CSV", r.URL.Query().Get("default_format"))
+		w.Write([]byte(data))
+	})
+	stream, err := conn.queryStream(context.Background(), "CSV", "SELECT 1, 'a' FROM numbers(10000)")
+	require.NoError(t, err)
+	body, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	require.NoError(t, stream.Close())
+	assert.Equal(t, data, string(body))
+}
+
+func TestHttpQueryStreamException(t *testing.T) {
+	data := strings.Repeat("1,a\n", 10000)
+	conn := newTestHttpConnect(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(data))
+		w.Write([]byte("Code: 395. DB::Exception: Value passed to 'throwIf' function is non-zero. (FUNCTION_THROW_IF_VALUE_IS_NON_ZERO)\n"))
+		// the server drops the connection without ending the chunked encoding
+		w.(http.Flusher).Flush()
+		c, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		c.Close()
+	})
+	stream, err := conn.queryStream(context.Background(), "CSV", "SELECT throwIf(number = 9999) FROM numbers(10000)")
+	require.NoError(t, err)
+	defer stream.Close()
+	body, err := io.ReadAll(stream)
+	var exception *Exception
+	require.ErrorAs(t, err, &exception)
+	assert.Equal(t, int32(395), exception.Code)
+	assert.Contains(t, exception.Message, "FUNCTION_THROW_IF_VALUE_IS_NON_ZERO")
+	assert.Equal(t, data, string(body))
+}
+
+func TestHttpQueryStreamExceptionTrailer(t *testing.T) {
+	data := strings.Repeat("1,a\n", 10000)
+	conn := newTestHttpConnect(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", httpStreamExceptionTrailer)
+		w.Write([]byte(data))
+		w.Write([]byte("Code: 395. DB::Exception: Value passed to 'throwIf' function is non-zero. (FUNCTION_THROW_IF_VALUE_IS_NON_ZERO)\n"))
+		w.Header().Set(httpStreamExceptionTrailer, "395")
+	})
+	stream, err := conn.queryStream(context.Background(), "CSV", "SELECT throwIf(number = 9999) FROM numbers(10000)")
+	require.NoError(t, err)
+	defer stream.Close()
+	body, err := io.ReadAll(stream)
+	var exception *Exception
+	require.ErrorAs(t, err, &exception)
+	assert.Equal(t, int32(395), exception.Code)
+	assert.Contains(t, exception.Message, "FUNCTION_THROW_IF_VALUE_IS_NON_ZERO")
+	assert.Equal(t, data, string(body))
+}
+
+func TestHttpQueryStreamExceptionLikeData(t *testing.T) {
+	data := strings.Repeat("1,a\n", 10000) + "Code: 395. DB::Exception: is a value of this row\n"
+	conn := newTestHttpConnect(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(data))
+	})
+	stream, err := conn.queryStream(context.Background(), "TSVRaw", "SELECT message FROM logs")
+	require.NoError(t, err)
+	defer stream.Close()
+	body, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	assert.Equal(t, data, string(body))
+}
@@ -0,0 +1,61 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpSessionExpired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-session", r.URL.Query().Get(sessionIDParamName))
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Code: 372. DB::Exception: Session test-session not found. (SESSION_NOT_FOUND)"))
+	}))
+	defer server.Close()
+
+	compressionPool, err := createCompressionPool(&Compression{Method: CompressionNone})
+	require.NoError(t, err)
+	u, err := url.Parse(server.URL + "?" + sessionIDParamName + "=test-session")
+	require.NoError(t, err)
+	conn := &httpConnect{
+		client:          server.Client(),
+		url:             u,
+		compressionPool: compressionPool,
+		sessionID:       "test-session",
+	}
+	_, err = conn.sendQuery(context.Background(), "SELECT 1", nil, nil)
+	require.ErrorIs(t, err, driver.ErrBadConn)
+	assert.True(t, conn.isBad())
+	assert.True(t, isConnBrokenError(err))
+}
+
+func TestHttpSessionDSN(t *testing.T) {
+	opts, err := ParseDSN("http://127.0.0.1/?http_session=true&http_session_timeout=2m")
+	require.NoError(t, err)
+	assert.True(t, opts.HttpSession)
+	assert.Equal(t, 120.0, opts.HttpSessionTimeout.Seconds())
+}
@@ -0,0 +1,46 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// insertFrom sends pre-formatted data as inline data of an INSERT ... FORMAT query, which the server parses with
+// its input format. The native protocol has no way to stream data in other formats, so it doesn't stream: the
+// data is read into memory, up to the MaxInsertFromSize option. Larger data has to be split by the caller or sent
+// over HTTP, which streams it.
+func (c *connect) insertFrom(ctx context.Context, query string, reader io.Reader) error {
+	var (
+		body    strings.Builder
+		maxSize = c.opt.MaxInsertFromSize
+	)
+	body.WriteString(query)
+	body.WriteByte('\n')
+	n, err := io.Copy(&body, io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return err
+	}
+	if n > maxSize {
+		return fmt.Errorf("%w: more than %d bytes", ErrInsertFromTooLarge, maxSize)
+	}
+	return c.exec(ctx, body.String())
+}
@@ -0,0 +1,33 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertFromTooLarge(t *testing.T) {
+	// the data is rejected before anything is sent, so the connection is never used
+	conn := &connect{opt: &Options{MaxInsertFromSize: 4}}
+	err := conn.insertFrom(context.Background(), "INSERT INTO t FORMAT CSV", strings.NewReader("1\n2\n3\n"))
+	assert.ErrorIs(t, err, ErrInsertFromTooLarge)
+}
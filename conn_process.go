@@ -32,6 +32,7 @@ type onProcess struct {
 	progress      func(*Progress)
 	profileInfo   func(*ProfileInfo)
 	profileEvents func([]ProfileEvent)
+	tableColumns  func(*proto.TableColumns)
 }
 
 func (c *connect) firstBlock(ctx context.Context, on *onProcess) (*proto.Block, error) {
@@ -193,7 +194,14 @@ func (c *connect) handle(ctx context.Context, packet byte, on *onProcess) error
 		if err := info.Decode(c.reader, c.revision); err != nil {
 			return err
 		}
-		c.debugf("[table columns]")
+		// a description this driver can't parse only costs the column defaults, the insert itself still works
+		if err := info.ParseColumns(); err != nil {
+			c.debugf("[table columns] parse %q: %v", info.Second, err)
+		}
+		c.debugf("[table columns] %d columns", len(info.Columns))
+		if on.tableColumns != nil {
+			on.tableColumns(&info)
+		}
 	case proto.ServerProfileEvents:
 		events, err := c.profileEvents(ctx)
 		if err != nil {
@@ -206,6 +214,12 @@ func (c *connect) handle(ctx context.Context, packet byte, on *onProcess) error
 			return err
 		}
 		on.logs(logs)
+	case proto.ServerTimezoneUpdate:
+		timezone, err := c.reader.Str()
+		if err != nil {
+			return err
+		}
+		c.debugf("[timezone update] %s", timezone)
 	case proto.ServerProgress:
 		progress, err := c.progress()
 		if err != nil {
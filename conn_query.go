@@ -25,16 +25,21 @@ import (
 )
 
 func (c *connect) query(ctx context.Context, release func(*connect, error), query string, args ...any) (*rows, error) {
+	ctx, op := c.telemetry().start(ctx, operationQuery, c.addr)
 	var (
 		options                    = queryOptions(ctx)
 		onProcess                  = options.onProcess()
+		stats                      = newQueryStats()
 		queryParamsProtocolSupport = c.revision >= proto.DBMS_MIN_PROTOCOL_VERSION_WITH_PARAMETERS
 		body, err                  = bindQueryOrAppendParameters(queryParamsProtocolSupport, &options, query, c.server.Timezone, args...)
 	)
+	stats.observe(onProcess)
+	op.traceQuery(&options)
 
 	if err != nil {
 		c.debugf("[bindQuery] error: %v", err)
 		release(c, err)
+		op.end(err)
 		return nil, err
 	}
 
@@ -49,6 +54,7 @@ func (c *connect) query(ctx context.Context, release func(*connect, error), quer
 
 	if err = c.sendQuery(body, &options); err != nil {
 		release(c, err)
+		op.end(err)
 		return nil, err
 	}
 
@@ -57,6 +63,7 @@ func (c *connect) query(ctx context.Context, release func(*connect, error), quer
 	if err != nil {
 		c.debugf("[query] first block error: %v", err)
 		release(c, err)
+		op.end(err)
 		return nil, err
 	}
 	bufferSize := c.blockBufferSize
@@ -78,9 +85,12 @@ func (c *connect) query(ctx context.Context, release func(*connect, error), quer
 			c.debugf("[query] process error: %v", err)
 			errors <- err
 		}
+		stats.finish()
 		close(stream)
 		close(errors)
 		release(c, err)
+		op.setStats(stats)
+		op.end(err)
 	}()
 
 	return &rows{
@@ -89,6 +99,7 @@ func (c *connect) query(ctx context.Context, release func(*connect, error), quer
 		errors:    errors,
 		columns:   init.ColumnsNames(),
 		structMap: c.structMap,
+		stats:     stats,
 	}, nil
 }
 
@@ -26,6 +26,10 @@ import (
 func (c *connect) sendQuery(body string, o *QueryOptions) error {
 	c.debugf("[send query] compression=%q %s", c.compression, body)
 	c.buffer.PutByte(proto.ClientQuery)
+	quotaKey := o.quotaKey
+	if len(quotaKey) == 0 {
+		quotaKey = c.opt.QuotaKey
+	}
 	q := proto.Query{
 		ClientTCPProtocolVersion: ClientTCPProtocolVersion,
 		ClientName:               c.opt.ClientInfo.String(),
@@ -33,7 +37,7 @@ func (c *connect) sendQuery(body string, o *QueryOptions) error {
 		ID:                       o.queryID,
 		Body:                     body,
 		Span:                     o.span,
-		QuotaKey:                 o.quotaKey,
+		QuotaKey:                 quotaKey,
 		Compression:              c.compression != CompressionNone,
 		InitialAddress:           c.conn.LocalAddr().String(),
 		Settings:                 c.settings(o.settings),
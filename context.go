@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2/ext"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -49,6 +50,10 @@ type (
 			ok   bool
 			wait bool
 		}
+		retry struct {
+			ok     bool
+			policy *RetryPolicy
+		}
 		queryID  string
 		quotaKey string
 		events   struct {
@@ -59,6 +64,7 @@ type (
 		}
 		settings        Settings
 		parameters      Parameters
+		params          []driver.NamedParam
 		external        []*ext.Table
 		blockBufferSize uint8
 		userLocation    *time.Location
@@ -107,6 +113,17 @@ func WithParameters(params Parameters) QueryOption {
 	}
 }
 
+// WithParams sets server side query parameters whose values are serialized for the type of their
+// {name:Type} placeholder, see Param. Unlike query arguments they also apply to PrepareBatch.
+func WithParams(params ...driver.NamedParam) QueryOption {
+	return func(o *QueryOptions) error {
+		o.params = append(o.params, params...)
+		return nil
+	}
+}
+
+// WithLogs calls fn with the server logs of the query, which are sent at the level of the send_logs_level setting.
+// The HTTP interface doesn't send server logs, so fn is only called over the native protocol.
 func WithLogs(fn func(*Log)) QueryOption {
 	return func(o *QueryOptions) error {
 		o.events.logs = fn
@@ -114,6 +131,8 @@ func WithLogs(fn func(*Log)) QueryOption {
 	}
 }
 
+// WithProgress calls fn with the progress the query made since the last call. Over HTTP the progress is read from
+// the X-ClickHouse-Progress headers, which are only available once the response starts.
 func WithProgress(fn func(*Progress)) QueryOption {
 	return func(o *QueryOptions) error {
 		o.events.progress = fn
@@ -128,6 +147,7 @@ func WithProfileInfo(fn func(*ProfileInfo)) QueryOption {
 	}
 }
 
+// WithProfileEvents calls fn with the profile events of the query, which are only sent over the native protocol
 func WithProfileEvents(fn func([]ProfileEvent)) QueryOption {
 	return func(o *QueryOptions) error {
 		o.events.profileEvents = fn
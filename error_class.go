@@ -0,0 +1,165 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+)
+
+// ErrorCode is a ClickHouse server error code. The codes are defined in lib/proto, e.g. proto.ErrTooManyParts,
+// and can be matched against returned errors with errors.Is.
+type ErrorCode = proto.ErrorCode
+
+// ErrorClass groups errors by how a caller should react to them.
+type ErrorClass uint8
+
+const (
+	// ErrorClassNone is the class of a nil error.
+	ErrorClassNone ErrorClass = iota
+	// ErrorClassPermanent errors fail again when retried, e.g. syntax errors or unknown tables.
+	ErrorClassPermanent
+	// ErrorClassTransient errors are caused by the state of the server or the network and may succeed when retried.
+	ErrorClassTransient
+	// ErrorClassQuota errors exceed a quota or a query complexity limit.
+	ErrorClassQuota
+	// ErrorClassAuth errors are failed authentications or missing privileges.
+	ErrorClassAuth
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorClassNone:
+		return "none"
+	case ErrorClassPermanent:
+		return "permanent"
+	case ErrorClassTransient:
+		return "transient"
+	case ErrorClassQuota:
+		return "quota"
+	case ErrorClassAuth:
+		return "auth"
+	}
+	return ""
+}
+
+// errorCodeClasses are the server error codes that are not permanent
+var errorCodeClasses = map[ErrorCode]ErrorClass{
+	proto.ErrTimeoutExceeded:                   ErrorClassTransient,
+	proto.ErrSocketTimeout:                     ErrorClassTransient,
+	proto.ErrNetworkError:                      ErrorClassTransient,
+	proto.ErrCannotReadFromSocket:              ErrorClassTransient,
+	proto.ErrCannotWriteToSocket:               ErrorClassTransient,
+	proto.ErrAllConnectionTriesFailed:          ErrorClassTransient,
+	proto.ErrNoFreeConnection:                  ErrorClassTransient,
+	proto.ErrTooManySimultaneousQueries:        ErrorClassTransient,
+	proto.ErrTooManyParts:                      ErrorClassTransient,
+	proto.ErrMemoryLimitExceeded:               ErrorClassTransient,
+	proto.ErrCannotAllocateMemory:              ErrorClassTransient,
+	proto.ErrTableIsReadOnly:                   ErrorClassTransient,
+	proto.ErrNoZookeeper:                       ErrorClassTransient,
+	proto.ErrKeeperException:                   ErrorClassTransient,
+	proto.ErrTooFewLiveReplicas:                ErrorClassTransient,
+	proto.ErrUnsatisfiedQuorumForPreviousWrite: ErrorClassTransient,
+	proto.ErrReplicaIsNotInQuorum:              ErrorClassTransient,
+	proto.ErrNoActiveReplicas:                  ErrorClassTransient,
+	proto.ErrNoAvailableReplica:                ErrorClassTransient,
+	proto.ErrAllReplicasAreStale:               ErrorClassTransient,
+	proto.ErrUnknownStatusOfInsert:             ErrorClassTransient,
+	proto.ErrAborted:                           ErrorClassTransient,
+	proto.ErrDeadlockAvoided:                   ErrorClassTransient,
+	proto.ErrCannotScheduleTask:                ErrorClassTransient,
+	proto.ErrSessionIsLocked:                   ErrorClassTransient,
+	proto.ErrQueryWithSameIdIsAlreadyRunning:   ErrorClassTransient,
+	proto.ErrReceivedErrorTooManyRequests:      ErrorClassTransient,
+	proto.ErrPartIsTemporarilyLocked:           ErrorClassTransient,
+	proto.ErrTooManyFetches:                    ErrorClassTransient,
+
+	proto.ErrQuotaExceeded:        ErrorClassQuota,
+	proto.ErrQuotaDoesntAllowKeys: ErrorClassQuota,
+	proto.ErrTooManyRows:          ErrorClassQuota,
+	proto.ErrTooManyBytes:         ErrorClassQuota,
+	proto.ErrTooManyRowsOrBytes:   ErrorClassQuota,
+	proto.ErrTooSlow:              ErrorClassQuota,
+	proto.ErrLimitExceeded:        ErrorClassQuota,
+	proto.ErrSetSizeLimitExceeded: ErrorClassQuota,
+
+	proto.ErrUnknownUser:          ErrorClassAuth,
+	proto.ErrWrongPassword:        ErrorClassAuth,
+	proto.ErrRequiredPassword:     ErrorClassAuth,
+	proto.ErrIpAddressNotAllowed:  ErrorClassAuth,
+	proto.ErrDatabaseAccessDenied: ErrorClassAuth,
+	proto.ErrAccessDenied:         ErrorClassAuth,
+	proto.ErrAuthenticationFailed: ErrorClassAuth,
+	proto.ErrReadonly:             ErrorClassAuth,
+}
+
+// ClassifyError returns the class of an error returned by the native or the HTTP interface. Server exceptions
+// are classified by their code, and network errors, dropped connections and acquire timeouts are transient.
+// Errors the driver raises by itself, such as conversion errors, and cancelled contexts are permanent.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+	var exception *Exception
+	if errors.As(err, &exception) {
+		if class, ok := errorCodeClasses[exception.ErrorCode()]; ok {
+			return class
+		}
+		return ErrorClassPermanent
+	}
+	var httpErr *httpError
+	if errors.As(err, &httpErr) {
+		switch httpErr.statusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return ErrorClassAuth
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return ErrorClassTransient
+		}
+		return ErrorClassPermanent
+	}
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassPermanent
+	case errors.Is(err, ErrAcquireConnTimeout),
+		errors.Is(err, driver.ErrBadConn),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.EPIPE):
+		return ErrorClassTransient
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ErrorClassTransient
+	}
+	return ErrorClassPermanent
+}
+
+// IsTransientError reports whether err may succeed when retried, see ClassifyError.
+func IsTransientError(err error) bool {
+	return ClassifyError(err) == ErrorClassTransient
+}
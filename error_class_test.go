@@ -0,0 +1,122 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCode(t *testing.T) {
+	assert.Equal(t, "TOO_MANY_PARTS", proto.ErrTooManyParts.String())
+	assert.Equal(t, "UNKNOWN_ERROR_CODE_123456", ErrorCode(123456).String())
+	assert.Equal(t, "code: 252, TOO_MANY_PARTS", proto.ErrTooManyParts.Error())
+
+	err := &OpError{
+		Op:  "Send",
+		Err: &Exception{Code: 252, Message: "Too many parts"},
+	}
+	assert.True(t, errors.Is(err, proto.ErrTooManyParts))
+	assert.False(t, errors.Is(err, proto.ErrTimeoutExceeded))
+	assert.True(t, errors.Is(err, &Exception{Code: 252}))
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err   error
+		class ErrorClass
+	}{
+		{nil, ErrorClassNone},
+		{&Exception{Code: int32(proto.ErrTimeoutExceeded)}, ErrorClassTransient},
+		{&Exception{Code: int32(proto.ErrMemoryLimitExceeded)}, ErrorClassTransient},
+		{&OpError{Op: "Send", Err: &Exception{Code: int32(proto.ErrTooManyParts)}}, ErrorClassTransient},
+		{&Exception{Code: int32(proto.ErrQuotaExceeded)}, ErrorClassQuota},
+		{&Exception{Code: int32(proto.ErrTooManyRows)}, ErrorClassQuota},
+		{&Exception{Code: int32(proto.ErrAuthenticationFailed)}, ErrorClassAuth},
+		{&Exception{Code: int32(proto.ErrReadonly)}, ErrorClassAuth},
+		{&Exception{Code: int32(proto.ErrSyntaxError)}, ErrorClassPermanent},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, ErrorClassTransient},
+		{fmt.Errorf("read: %w", io.EOF), ErrorClassTransient},
+		{fmt.Errorf("write: %w", syscall.EPIPE), ErrorClassTransient},
+		{driver.ErrBadConn, ErrorClassTransient},
+		{ErrAcquireConnTimeout, ErrorClassTransient},
+		{context.Canceled, ErrorClassPermanent},
+		{context.DeadlineExceeded, ErrorClassPermanent},
+		{&OpError{Op: "Append", Err: &column.ColumnConverterError{Op: "Append", To: "UInt8", From: "string"}}, ErrorClassPermanent},
+		{&httpError{statusCode: http.StatusServiceUnavailable}, ErrorClassTransient},
+		{&httpError{statusCode: http.StatusForbidden}, ErrorClassAuth},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.class, ClassifyError(test.err), "%v", test.err)
+	}
+}
+
+func TestClassifyHttpError(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		message string
+		code    ErrorCode
+		class   ErrorClass
+	}{
+		{
+			name:    "body",
+			message: "Code: 241. DB::Exception: Memory limit (total) exceeded. (MEMORY_LIMIT_EXCEEDED) (version 24.8.1.1)",
+			code:    proto.ErrMemoryLimitExceeded,
+			class:   ErrorClassTransient,
+		},
+		{
+			name:    "header",
+			header:  "516",
+			message: "default: Authentication failed",
+			code:    proto.ErrAuthenticationFailed,
+			class:   ErrorClassAuth,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conn := newTestHttpConnect(t, func(w http.ResponseWriter, r *http.Request) {
+				if test.header != "" {
+					w.Header().Set("X-ClickHouse-Exception-Code", test.header)
+				}
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(test.message))
+			})
+			err := conn.exec(context.Background(), "SELECT 1")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.message)
+			assert.True(t, errors.Is(err, test.code))
+			var exception *Exception
+			require.ErrorAs(t, err, &exception)
+			assert.Equal(t, int32(test.code), exception.Code)
+			assert.Equal(t, test.class, ClassifyError(err))
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package chcol
+
+// Dynamic represents a value of a ClickHouse Dynamic column. It is stored the same way as a Variant,
+// with Type reporting the ClickHouse type the value was read as.
+type Dynamic = Variant
+
+// NewDynamic creates a Dynamic holding v. The ClickHouse type is inferred from the Go type of v on insert.
+func NewDynamic(v any) Dynamic {
+	return NewVariant(v)
+}
+
+// NewDynamicWithType creates a Dynamic holding v that will be inserted as the ClickHouse type chType.
+func NewDynamicWithType(v any, chType string) Dynamic {
+	return NewVariantWithType(v, chType)
+}
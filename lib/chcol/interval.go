@@ -0,0 +1,125 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package chcol
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IntervalUnit is the unit of a ClickHouse Interval type, e.g. Second for IntervalSecond
+type IntervalUnit string
+
+const (
+	IntervalNanosecond  IntervalUnit = "Nanosecond"
+	IntervalMicrosecond IntervalUnit = "Microsecond"
+	IntervalMillisecond IntervalUnit = "Millisecond"
+	IntervalSecond      IntervalUnit = "Second"
+	IntervalMinute      IntervalUnit = "Minute"
+	IntervalHour        IntervalUnit = "Hour"
+	IntervalDay         IntervalUnit = "Day"
+	IntervalWeek        IntervalUnit = "Week"
+	IntervalMonth       IntervalUnit = "Month"
+	IntervalQuarter     IntervalUnit = "Quarter"
+	IntervalYear        IntervalUnit = "Year"
+)
+
+// fixedIntervalUnits are the units with a fixed length, from the largest to the smallest
+var fixedIntervalUnits = []IntervalUnit{
+	IntervalWeek,
+	IntervalDay,
+	IntervalHour,
+	IntervalMinute,
+	IntervalSecond,
+	IntervalMillisecond,
+	IntervalMicrosecond,
+	IntervalNanosecond,
+}
+
+// Duration returns the length of the unit. It is 0 for Month, Quarter and Year, whose length depends on the date.
+func (u IntervalUnit) Duration() time.Duration {
+	switch u {
+	case IntervalNanosecond:
+		return time.Nanosecond
+	case IntervalMicrosecond:
+		return time.Microsecond
+	case IntervalMillisecond:
+		return time.Millisecond
+	case IntervalSecond:
+		return time.Second
+	case IntervalMinute:
+		return time.Minute
+	case IntervalHour:
+		return time.Hour
+	case IntervalDay:
+		return 24 * time.Hour
+	case IntervalWeek:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// Valid reports whether u is one of the units supported by ClickHouse
+func (u IntervalUnit) Valid() bool {
+	switch u {
+	case IntervalMonth, IntervalQuarter, IntervalYear:
+		return true
+	}
+	return u.Duration() != 0
+}
+
+// Interval is a number of units of a ClickHouse Interval type. Unlike time.Duration it can hold months, quarters
+// and years.
+type Interval struct {
+	Value int64
+	Unit  IntervalUnit
+}
+
+// NewInterval creates an Interval of value units
+func NewInterval(value int64, unit IntervalUnit) Interval {
+	return Interval{Value: value, Unit: unit}
+}
+
+// DurationInterval converts a duration to an Interval of the largest unit that divides it exactly. A zero duration
+// is zero seconds.
+func DurationInterval(d time.Duration) Interval {
+	if d == 0 {
+		return Interval{Unit: IntervalSecond}
+	}
+	for _, unit := range fixedIntervalUnits {
+		if d%unit.Duration() == 0 {
+			return Interval{Value: int64(d / unit.Duration()), Unit: unit}
+		}
+	}
+	return Interval{Value: int64(d), Unit: IntervalNanosecond}
+}
+
+// Duration returns the interval as a duration, and false when its unit has no fixed length
+func (i Interval) Duration() (time.Duration, bool) {
+	unit := i.Unit.Duration()
+	if unit == 0 {
+		return 0, false
+	}
+	return time.Duration(i.Value) * unit, true
+}
+
+// String returns the interval as a ClickHouse literal, e.g. INTERVAL 3 MONTH
+func (i Interval) String() string {
+	return fmt.Sprintf("INTERVAL %d %s", i.Value, strings.ToUpper(string(i.Unit)))
+}
@@ -0,0 +1,131 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package chcol
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// JSON represents a row of a ClickHouse JSON column as a flat set of dot separated paths and their values.
+// Paths that are absent from a row are not present in the set.
+type JSON struct {
+	valuesByPath map[string]any
+}
+
+// NewJSON creates an empty JSON value
+func NewJSON() *JSON {
+	return &JSON{
+		valuesByPath: make(map[string]any),
+	}
+}
+
+// ValuesByPath returns the flat path to value map. Values of Dynamic paths are unwrapped.
+func (o *JSON) ValuesByPath() map[string]any {
+	return o.valuesByPath
+}
+
+// Paths returns the paths present in the row in sorted order
+func (o *JSON) Paths() []string {
+	paths := make([]string, 0, len(o.valuesByPath))
+	for path := range o.valuesByPath {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// ValueAtPath returns the value at a dot separated path
+func (o *JSON) ValueAtPath(path string) (any, bool) {
+	value, ok := o.valuesByPath[path]
+	return value, ok
+}
+
+// SetValueAtPath sets the value at a dot separated path
+func (o *JSON) SetValueAtPath(path string, value any) {
+	if o.valuesByPath == nil {
+		o.valuesByPath = make(map[string]any)
+	}
+	o.valuesByPath[path] = value
+}
+
+// NestedMap converts the flat paths into nested maps, splitting each path on dots
+func (o *JSON) NestedMap() map[string]any {
+	result := make(map[string]any)
+	for path, value := range o.valuesByPath {
+		current := result
+		parts := strings.Split(path, ".")
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := current[part].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				current[part] = next
+			}
+			current = next
+		}
+		current[parts[len(parts)-1]] = value
+	}
+	return result
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (o *JSON) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.NestedMap())
+}
+
+// Scan implements the sql.Scanner interface
+func (o *JSON) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		o.valuesByPath = make(map[string]any)
+	case JSON:
+		*o = v
+	case *JSON:
+		*o = *v
+	case string:
+		return o.UnmarshalJSON([]byte(v))
+	case []byte:
+		return o.UnmarshalJSON(v)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON", value)
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Nested objects are flattened into dot separated paths.
+func (o *JSON) UnmarshalJSON(data []byte) error {
+	var nested map[string]any
+	if err := json.Unmarshal(data, &nested); err != nil {
+		return err
+	}
+	o.valuesByPath = make(map[string]any)
+	o.flatten("", nested)
+	return nil
+}
+
+func (o *JSON) flatten(prefix string, nested map[string]any) {
+	for key, value := range nested {
+		if child, ok := value.(map[string]any); ok && len(child) != 0 {
+			o.flatten(prefix+key+".", child)
+			continue
+		}
+		o.valuesByPath[prefix+key] = value
+	}
+}
@@ -0,0 +1,143 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package chcol
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Time is a civil time of day, or a duration of up to 999 hours, as stored by the ClickHouse Time and Time64 types.
+// It carries no date or time zone.
+type Time struct {
+	Negative   bool
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// NewTime creates a Time from a duration
+func NewTime(d time.Duration) Time {
+	var t Time
+	if d < 0 {
+		t.Negative, d = true, -d
+	}
+	t.Hour = int(d / time.Hour)
+	t.Minute = int(d % time.Hour / time.Minute)
+	t.Second = int(d % time.Minute / time.Second)
+	t.Nanosecond = int(d % time.Second)
+	return t
+}
+
+// ParseTime parses the ClickHouse text representation [-]HHH:MM:SS[.fffffffff]
+func ParseTime(s string) (Time, error) {
+	var t Time
+	value := s
+	if strings.HasPrefix(value, "-") {
+		t.Negative, value = true, value[1:]
+	}
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return Time{}, fmt.Errorf("invalid time %q: expected [-]HHH:MM:SS[.fffffffff]", s)
+	}
+	seconds, fraction, _ := strings.Cut(parts[2], ".")
+	var err error
+	if t.Hour, err = strconv.Atoi(parts[0]); err != nil {
+		return Time{}, fmt.Errorf("invalid time %q: %w", s, err)
+	}
+	if t.Minute, err = strconv.Atoi(parts[1]); err != nil || t.Minute > 59 {
+		return Time{}, fmt.Errorf("invalid time %q: minutes out of range", s)
+	}
+	if t.Second, err = strconv.Atoi(seconds); err != nil || t.Second > 59 {
+		return Time{}, fmt.Errorf("invalid time %q: seconds out of range", s)
+	}
+	if fraction != "" {
+		if len(fraction) > 9 {
+			return Time{}, fmt.Errorf("invalid time %q: more than 9 fractional digits", s)
+		}
+		if t.Nanosecond, err = strconv.Atoi(fraction + strings.Repeat("0", 9-len(fraction))); err != nil {
+			return Time{}, fmt.Errorf("invalid time %q: %w", s, err)
+		}
+	}
+	return t, nil
+}
+
+// Duration returns the time as a duration since midnight
+func (t Time) Duration() time.Duration {
+	d := time.Duration(t.Hour)*time.Hour +
+		time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second +
+		time.Duration(t.Nanosecond)
+	if t.Negative {
+		return -d
+	}
+	return d
+}
+
+// String returns the time as [-]HH:MM:SS followed by the fractional seconds, if any
+func (t Time) String() string {
+	return FormatDuration(t.Duration(), -1)
+}
+
+// Scan implements the sql.Scanner interface
+func (t *Time) Scan(value any) (err error) {
+	switch v := value.(type) {
+	case nil:
+		*t = Time{}
+	case Time:
+		*t = v
+	case time.Duration:
+		*t = NewTime(v)
+	case string:
+		*t, err = ParseTime(v)
+	case []byte:
+		*t, err = ParseTime(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into Time", value)
+	}
+	return err
+}
+
+// Value implements the driver.Valuer interface
+func (t Time) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+// FormatDuration formats a duration as the ClickHouse Time text representation [-]HH:MM:SS[.fffffffff].
+// A negative precision prints only the significant fractional digits.
+func FormatDuration(d time.Duration, precision int) string {
+	var sign string
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	text := fmt.Sprintf("%s%02d:%02d:%02d", sign, d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second)
+	fraction := fmt.Sprintf("%09d", d%time.Second)
+	switch {
+	case precision < 0:
+		if fraction = strings.TrimRight(fraction, "0"); fraction != "" {
+			text += "." + fraction
+		}
+	case precision > 0:
+		text += "." + fraction[:min(precision, 9)]
+	}
+	return text
+}
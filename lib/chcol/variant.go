@@ -0,0 +1,84 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package chcol
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// Variant represents a value of a ClickHouse Variant(T1, T2, ...) column.
+// When scanned, Type reports the member type the value was stored as.
+// When appended, the type may be set with NewVariantWithType to resolve values that fit more than one member type.
+type Variant struct {
+	value  any
+	chType string
+}
+
+// NewVariant creates a Variant holding v. The member type is resolved from the Go type of v on insert.
+func NewVariant(v any) Variant {
+	return Variant{value: v}
+}
+
+// NewVariantWithType creates a Variant holding v that will be inserted as the member type chType.
+func NewVariantWithType(v any, chType string) Variant {
+	return Variant{value: v, chType: chType}
+}
+
+// Nil returns true if the Variant holds no value (NULL)
+func (v Variant) Nil() bool {
+	return v.value == nil
+}
+
+// Any returns the underlying value as any
+func (v Variant) Any() any {
+	return v.value
+}
+
+// Type returns the ClickHouse member type of the value, or an empty string if it is unknown or NULL
+func (v Variant) Type() string {
+	return v.chType
+}
+
+// HasType returns true if the Variant carries an explicit ClickHouse member type
+func (v Variant) HasType() bool {
+	return v.chType != ""
+}
+
+// Scan implements the sql.Scanner interface
+func (v *Variant) Scan(value any) error {
+	switch vv := value.(type) {
+	case Variant:
+		*v = vv
+	case *Variant:
+		*v = *vv
+	default:
+		v.value, v.chType = value, ""
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (v Variant) Value() (driver.Value, error) {
+	return v.value, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (v Variant) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
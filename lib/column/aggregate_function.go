@@ -0,0 +1,674 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/shopspring/decimal"
+)
+
+// AggregateFunction holds the intermediate states of an aggregate function, e.g. AggregateFunction(uniq, String).
+// States are appended and scanned as opaque bytes, which lets them be copied between tables and clusters.
+// The native format does not frame states, so decoding needs to know the state layout of the function: count, sum,
+// min, max, any, anyLast, avg, groupBitmap and uniq (and their -If variants) are supported. States of count, sum,
+// min, max, any, anyLast, avg and groupBitmap can also be scanned into Go values.
+type AggregateFunction struct {
+	chType   Type
+	name     string
+	tz       *time.Location
+	function string
+	args     []Type
+	state    *aggregateState
+	col      proto.ColBytes
+}
+
+// aggregateState describes how the state of a function is serialized
+type aggregateState struct {
+	// read copies exactly one state from the reader
+	read func(reader *proto.Reader, state []byte) ([]byte, error)
+	// empty is the state of an aggregation over no rows
+	empty []byte
+	// scan converts a state into a Go value, nil if the state is opaque
+	scan func(state []byte, dest any) error
+}
+
+func (col *AggregateFunction) Reset() {
+	col.col.Reset()
+}
+
+func (col *AggregateFunction) Name() string {
+	return col.name
+}
+
+func (col *AggregateFunction) parse(t Type, tz *time.Location) (_ *AggregateFunction, err error) {
+	col.chType, col.tz = t, tz
+	params := splitTypeParams(t.params())
+	// the function may be preceded by the version of its state
+	if len(params) > 0 {
+		if _, err := strconv.Atoi(params[0]); err == nil {
+			params = params[1:]
+		}
+	}
+	if len(params) == 0 {
+		return nil, &UnsupportedColumnTypeError{
+			t: t,
+		}
+	}
+	col.function = params[0]
+	for _, arg := range params[1:] {
+		col.args = append(col.args, Type(arg))
+	}
+	// a nil state is reported when the column is decoded, so opaque states of any function can still be inserted
+	col.state = newAggregateState(col.function, col.args, tz)
+	return col, nil
+}
+
+func (col *AggregateFunction) Type() Type {
+	return col.chType
+}
+
+func (col *AggregateFunction) ScanType() reflect.Type {
+	return scanTypeByte
+}
+
+func (col *AggregateFunction) Rows() int {
+	return col.col.Rows()
+}
+
+func (col *AggregateFunction) Row(i int, ptr bool) any {
+	value := col.col.Row(i)
+	if ptr {
+		return &value
+	}
+	return value
+}
+
+// ScanRow copies the raw state into a *[]byte, or decodes it into a Go value for functions with typed decoders:
+// count into an unsigned or signed integer, sum, min, max, any and anyLast into the destinations accepted by
+// their result type, avg into a float64, and groupBitmap into a slice of integers or its cardinality.
+func (col *AggregateFunction) ScanRow(dest any, row int) error {
+	state := col.col.Row(row)
+	switch d := dest.(type) {
+	case *[]byte:
+		*d = bytes.Clone(state)
+		return nil
+	case **[]byte:
+		*d = new([]byte)
+		**d = bytes.Clone(state)
+		return nil
+	}
+	if col.state == nil || col.state.scan == nil {
+		return &ColumnConverterError{
+			Op:   "ScanRow",
+			To:   fmt.Sprintf("%T", dest),
+			From: string(col.chType),
+			Hint: fmt.Sprintf("states of %s can only be scanned as []byte", col.function),
+		}
+	}
+	if err := col.state.scan(state, dest); err != nil {
+		return &ColumnConverterError{
+			Op:   "ScanRow",
+			To:   fmt.Sprintf("%T", dest),
+			From: string(col.chType),
+			Hint: err.Error(),
+		}
+	}
+	return nil
+}
+
+func (col *AggregateFunction) Append(v any) (nulls []uint8, err error) {
+	switch v := v.(type) {
+	case [][]byte:
+		nulls = make([]uint8, len(v))
+		for i := range v {
+			if err := col.AppendRow(v[i]); err != nil {
+				return nil, err
+			}
+		}
+	case []string:
+		nulls = make([]uint8, len(v))
+		for i := range v {
+			col.col.AppendBytes([]byte(v[i]))
+		}
+	default:
+		return nil, &ColumnConverterError{
+			Op:   "Append",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+		}
+	}
+	return
+}
+
+// AppendRow appends a serialized state as []byte or string. A nil value appends the state of an empty aggregation.
+func (col *AggregateFunction) AppendRow(v any) error {
+	switch v := v.(type) {
+	case []byte:
+		if v == nil {
+			return col.AppendRow(nil)
+		}
+		col.col.AppendBytes(v)
+	case *[]byte:
+		if v == nil {
+			return col.AppendRow(nil)
+		}
+		return col.AppendRow(*v)
+	case string:
+		col.col.AppendBytes([]byte(v))
+	case sql.RawBytes:
+		col.col.AppendBytes(v)
+	case nil:
+		if col.state == nil {
+			return &ColumnConverterError{
+				Op:   "AppendRow",
+				To:   string(col.chType),
+				From: "nil",
+				Hint: fmt.Sprintf("the empty state of %s is unknown", col.function),
+			}
+		}
+		col.col.AppendBytes(col.state.empty)
+	default:
+		return &ColumnConverterError{
+			Op:   "AppendRow",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+			Hint: "aggregate function states must be appended as []byte",
+		}
+	}
+	return nil
+}
+
+func (col *AggregateFunction) Decode(reader *proto.Reader, rows int) error {
+	if col.state == nil {
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        fmt.Errorf("decoding states of %s is not supported", col.function),
+		}
+	}
+	var state []byte
+	for i := 0; i < rows; i++ {
+		var err error
+		if state, err = col.state.read(reader, state[:0]); err != nil {
+			return fmt.Errorf("%s state: %w", col.function, err)
+		}
+		col.col.AppendBytes(state)
+	}
+	return nil
+}
+
+func (col *AggregateFunction) Encode(buffer *proto.Buffer) {
+	// states are written back to back without a length prefix
+	for i := 0; i < col.col.Rows(); i++ {
+		buffer.PutRaw(col.col.Row(i))
+	}
+}
+
+// newAggregateState returns the state layout of a function, or nil if it is unknown
+func newAggregateState(function string, args []Type, tz *time.Location) *aggregateState {
+	base := function
+	// the -If combinator takes an extra UInt8 condition argument but keeps the state of the base function
+	if strings.HasSuffix(function, "If") && len(args) > 0 {
+		base, args = strings.TrimSuffix(function, "If"), args[:len(args)-1]
+	}
+	if base == "count" {
+		return &aggregateState{
+			read:  readAggregateVarUInt,
+			empty: []byte{0},
+			scan:  scanAggregateCount,
+		}
+	}
+	if len(args) != 1 {
+		return nil
+	}
+	arg := args[0]
+	if strings.HasPrefix(string(arg), "Nullable(") {
+		switch base {
+		case "sum", "min", "max", "any", "anyLast", "avg":
+			nested := newAggregateState(base, []Type{Type(arg.params())}, tz)
+			if nested == nil {
+				return nil
+			}
+			return nullableAggregateState(nested)
+		}
+		return nil
+	}
+	switch base {
+	case "sum":
+		result, ok := aggregateSumType(arg)
+		if !ok {
+			return nil
+		}
+		size, _ := aggregateValueSize(result)
+		return &aggregateState{
+			read:  readAggregateFixed(size),
+			empty: make([]byte, size),
+			scan:  scanAggregateValue(result, tz),
+		}
+	case "min", "max", "any", "anyLast":
+		size, ok := aggregateValueSize(arg)
+		if !ok {
+			return nil
+		}
+		return &aggregateState{
+			read: func(reader *proto.Reader, state []byte) ([]byte, error) {
+				has, err := reader.UInt8()
+				if err != nil {
+					return nil, err
+				}
+				state = append(state, has)
+				if has == 0 {
+					return state, nil
+				}
+				return readAggregateFixed(size)(reader, state)
+			},
+			empty: []byte{0},
+			scan: func(state []byte, dest any) error {
+				if len(state) == 0 || state[0] == 0 {
+					return setAggregateZero(dest)
+				}
+				return scanAggregateValue(arg, tz)(state[1:], dest)
+			},
+		}
+	case "avg":
+		numerator, ok := aggregateAvgType(arg)
+		if !ok {
+			return nil
+		}
+		size, _ := aggregateValueSize(numerator)
+		return &aggregateState{
+			read: func(reader *proto.Reader, state []byte) ([]byte, error) {
+				state, err := readAggregateFixed(size)(reader, state)
+				if err != nil {
+					return nil, err
+				}
+				return readAggregateVarUInt(reader, state)
+			},
+			empty: make([]byte, size+1),
+			scan:  scanAggregateAvg(numerator, size, tz),
+		}
+	case "groupBitmap":
+		size, ok := aggregateValueSize(arg)
+		if !ok || !isAggregateInteger(arg) {
+			return nil
+		}
+		return &aggregateState{
+			read:  readAggregateBitmap(size),
+			empty: []byte{0, 0},
+			scan:  scanAggregateBitmap(size),
+		}
+	case "uniq":
+		return &aggregateState{
+			read:  readAggregateUniq,
+			empty: []byte{0, 0},
+		}
+	}
+	return nil
+}
+
+// nullableAggregateState wraps the state of a function over a Nullable argument, which is prefixed with
+// a flag telling whether any non-NULL value was aggregated.
+func nullableAggregateState(nested *aggregateState) *aggregateState {
+	return &aggregateState{
+		read: func(reader *proto.Reader, state []byte) ([]byte, error) {
+			flag, err := reader.UInt8()
+			if err != nil {
+				return nil, err
+			}
+			state = append(state, flag)
+			if flag == 0 {
+				return state, nil
+			}
+			return nested.read(reader, state)
+		},
+		empty: []byte{0},
+		scan: func(state []byte, dest any) error {
+			if len(state) == 0 || state[0] == 0 {
+				return setAggregateZero(dest)
+			}
+			return nested.scan(state[1:], dest)
+		},
+	}
+}
+
+func readAggregateFixed(size int) func(reader *proto.Reader, state []byte) ([]byte, error) {
+	return func(reader *proto.Reader, state []byte) ([]byte, error) {
+		start := len(state)
+		state = append(state, make([]byte, size)...)
+		if err := reader.ReadFull(state[start:]); err != nil {
+			return nil, err
+		}
+		return state, nil
+	}
+}
+
+func readAggregateVarUInt(reader *proto.Reader, state []byte) ([]byte, error) {
+	v, err := reader.UVarInt()
+	if err != nil {
+		return nil, err
+	}
+	return binary.AppendUvarint(state, v), nil
+}
+
+// readAggregateUniq reads a UniquesHashSet: UInt8 skip degree, VarUInt size and UInt32 hashes
+func readAggregateUniq(reader *proto.Reader, state []byte) ([]byte, error) {
+	state, err := readAggregateFixed(1)(reader, state)
+	if err != nil {
+		return nil, err
+	}
+	size, err := reader.UVarInt()
+	if err != nil {
+		return nil, err
+	}
+	state = binary.AppendUvarint(state, size)
+	return readAggregateFixed(int(size)*4)(reader, state)
+}
+
+// readAggregateBitmap reads a RoaringBitmapWithSmallSet: a UInt8 flag, then either a VarUInt count of values
+// of the argument size, or a roaring bitmap serialized as a string.
+func readAggregateBitmap(size int) func(reader *proto.Reader, state []byte) ([]byte, error) {
+	return func(reader *proto.Reader, state []byte) ([]byte, error) {
+		large, err := reader.UInt8()
+		if err != nil {
+			return nil, err
+		}
+		state = append(state, large)
+		length, err := reader.UVarInt()
+		if err != nil {
+			return nil, err
+		}
+		state = binary.AppendUvarint(state, length)
+		if large == 0 {
+			return readAggregateFixed(int(length)*size)(reader, state)
+		}
+		return readAggregateFixed(int(length))(reader, state)
+	}
+}
+
+func scanAggregateCount(state []byte, dest any) error {
+	count, n := binary.Uvarint(state)
+	if n <= 0 {
+		return fmt.Errorf("invalid count state")
+	}
+	return setAggregateNumber(dest, count)
+}
+
+// scanAggregateValue decodes a state holding a single value of the given type and scans it with its column
+func scanAggregateValue(t Type, tz *time.Location) func(state []byte, dest any) error {
+	return func(state []byte, dest any) error {
+		col, err := decodeAggregateValue(t, state, tz)
+		if err != nil {
+			return err
+		}
+		return col.ScanRow(dest, 0)
+	}
+}
+
+func decodeAggregateValue(t Type, state []byte, tz *time.Location) (Interface, error) {
+	col, err := t.Column("", tz)
+	if err != nil {
+		return nil, err
+	}
+	if err := col.Decode(proto.NewReader(bytes.NewReader(state)), 1); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+func scanAggregateAvg(numerator Type, size int, tz *time.Location) func(state []byte, dest any) error {
+	return func(state []byte, dest any) error {
+		if len(state) < size {
+			return fmt.Errorf("invalid avg state")
+		}
+		col, err := decodeAggregateValue(numerator, state[:size], tz)
+		if err != nil {
+			return err
+		}
+		denominator, n := binary.Uvarint(state[size:])
+		if n <= 0 {
+			return fmt.Errorf("invalid avg state")
+		}
+		var sum float64
+		switch v := col.Row(0, false).(type) {
+		case int64:
+			sum = float64(v)
+		case uint64:
+			sum = float64(v)
+		case float64:
+			sum = v
+		case decimal.Decimal:
+			sum = v.InexactFloat64()
+		}
+		avg := math.NaN()
+		if denominator != 0 {
+			avg = sum / float64(denominator)
+		}
+		switch d := dest.(type) {
+		case *float64:
+			*d = avg
+		case *sql.NullFloat64:
+			return d.Scan(avg)
+		default:
+			return fmt.Errorf("avg states can only be scanned into float64")
+		}
+		return nil
+	}
+}
+
+// scanAggregateBitmap scans a groupBitmap state into a slice of integers, or its cardinality into an integer
+func scanAggregateBitmap(size int) func(state []byte, dest any) error {
+	return func(state []byte, dest any) error {
+		values, err := decodeAggregateBitmap(state, size)
+		if err != nil {
+			return err
+		}
+		value := reflect.ValueOf(dest)
+		if value.Kind() != reflect.Pointer || value.IsNil() {
+			return fmt.Errorf("destination must be a pointer")
+		}
+		if elem := value.Elem(); elem.Kind() == reflect.Slice {
+			slice := reflect.MakeSlice(elem.Type(), len(values), len(values))
+			for i, v := range values {
+				if !reflect.ValueOf(v).CanConvert(elem.Type().Elem()) {
+					return fmt.Errorf("cannot convert bitmap values to %s", elem.Type().Elem())
+				}
+				slice.Index(i).Set(reflect.ValueOf(v).Convert(elem.Type().Elem()))
+			}
+			elem.Set(slice)
+			return nil
+		}
+		return setAggregateNumber(dest, uint64(len(values)))
+	}
+}
+
+func decodeAggregateBitmap(state []byte, size int) ([]uint64, error) {
+	if len(state) < 2 {
+		return nil, fmt.Errorf("invalid groupBitmap state")
+	}
+	length, n := binary.Uvarint(state[1:])
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid groupBitmap state")
+	}
+	data := state[1+n:]
+	if state[0] != 0 {
+		if size == 8 {
+			return decodeRoaring64(data)
+		}
+		values, _, err := decodeRoaring(data)
+		return values, err
+	}
+	if len(data) < int(length)*size {
+		return nil, fmt.Errorf("invalid groupBitmap state")
+	}
+	values := make([]uint64, length)
+	for i := range values {
+		switch v := data[i*size:]; size {
+		case 1:
+			values[i] = uint64(v[0])
+		case 2:
+			values[i] = uint64(binary.LittleEndian.Uint16(v))
+		case 4:
+			values[i] = uint64(binary.LittleEndian.Uint32(v))
+		default:
+			values[i] = binary.LittleEndian.Uint64(v)
+		}
+	}
+	return values, nil
+}
+
+func setAggregateNumber(dest any, v uint64) error {
+	switch d := dest.(type) {
+	case *sql.NullInt64:
+		return d.Scan(int64(v))
+	case sql.Scanner:
+		return d.Scan(v)
+	}
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("destination must be a pointer")
+	}
+	switch elem := value.Elem(); elem.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if elem.OverflowUint(v) {
+			return fmt.Errorf("%d overflows %s", v, elem.Type())
+		}
+		elem.SetUint(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v > math.MaxInt64 || elem.OverflowInt(int64(v)) {
+			return fmt.Errorf("%d overflows %s", v, elem.Type())
+		}
+		elem.SetInt(int64(v))
+	default:
+		return fmt.Errorf("cannot scan a count into %s", elem.Type())
+	}
+	return nil
+}
+
+// setAggregateZero sets the destination for a state that has no value: pointers become nil and values zero
+func setAggregateZero(dest any) error {
+	if scan, ok := dest.(sql.Scanner); ok {
+		return scan.Scan(nil)
+	}
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("destination must be a pointer")
+	}
+	value.Elem().Set(reflect.Zero(value.Elem().Type()))
+	return nil
+}
+
+// aggregateValueSize returns the serialized size of a fixed size value
+func aggregateValueSize(t Type) (int, bool) {
+	switch {
+	case strings.HasPrefix(string(t), "Decimal("):
+		col, err := (&Decimal{}).parse(t)
+		if err != nil {
+			return 0, false
+		}
+		switch {
+		case col.precision <= 9:
+			return 4, true
+		case col.precision <= 18:
+			return 8, true
+		case col.precision <= 38:
+			return 16, true
+		}
+		return 32, true
+	case strings.HasPrefix(string(t), "DateTime64("):
+		return 8, true
+	case strings.HasPrefix(string(t), "DateTime"):
+		return 4, true
+	case strings.HasPrefix(string(t), "Enum8("):
+		return 1, true
+	case strings.HasPrefix(string(t), "Enum16("):
+		return 2, true
+	}
+	switch t {
+	case "Int8", "UInt8", "Bool":
+		return 1, true
+	case "Int16", "UInt16", "Date":
+		return 2, true
+	case "Int32", "UInt32", "Float32", "Date32", "IPv4":
+		return 4, true
+	case "Int64", "UInt64", "Float64":
+		return 8, true
+	case "Int128", "UInt128", "UUID", "IPv6":
+		return 16, true
+	case "Int256", "UInt256":
+		return 32, true
+	}
+	return 0, false
+}
+
+func isAggregateInteger(t Type) bool {
+	switch t {
+	case "Int8", "Int16", "Int32", "Int64", "UInt8", "UInt16", "UInt32", "UInt64":
+		return true
+	}
+	return false
+}
+
+// aggregateSumType returns the type sum accumulates values of the given type in
+func aggregateSumType(t Type) (Type, bool) {
+	switch t {
+	case "Int8", "Int16", "Int32", "Int64":
+		return "Int64", true
+	case "UInt8", "UInt16", "UInt32", "UInt64":
+		return "UInt64", true
+	case "Float32", "Float64":
+		return "Float64", true
+	case "Int128", "Int256", "UInt128", "UInt256":
+		return t, true
+	}
+	return aggregateDecimalType(t)
+}
+
+// aggregateAvgType returns the type of the numerator of an avg state
+func aggregateAvgType(t Type) (Type, bool) {
+	switch t {
+	case "Int128", "Int256", "UInt128", "UInt256":
+		return "Float64", true
+	}
+	return aggregateSumType(t)
+}
+
+// aggregateDecimalType widens decimals to Decimal128, or Decimal256 for the largest precisions
+func aggregateDecimalType(t Type) (Type, bool) {
+	if !strings.HasPrefix(string(t), "Decimal(") {
+		return "", false
+	}
+	col, err := (&Decimal{}).parse(t)
+	if err != nil {
+		return "", false
+	}
+	if col.precision <= 38 {
+		return Type(fmt.Sprintf("Decimal(38, %d)", col.scale)), true
+	}
+	return Type(fmt.Sprintf("Decimal(76, %d)", col.scale)), true
+}
+
+var _ Interface = (*AggregateFunction)(nil)
@@ -0,0 +1,129 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func roundTripAggregateStates(t *testing.T, chType Type, states ...[]byte) Interface {
+	col, err := chType.Column("a", nil)
+	require.NoError(t, err)
+	for _, state := range states {
+		require.NoError(t, col.AppendRow(state))
+	}
+	var buffer proto.Buffer
+	col.Encode(&buffer)
+	decoded, err := chType.Column("a", nil)
+	require.NoError(t, err)
+	reader := proto.NewReader(buffer.Reader())
+	require.NoError(t, decoded.Decode(reader, len(states)))
+	_, err = reader.ReadByte()
+	require.Error(t, err, "all state bytes must be consumed")
+	for i, state := range states {
+		assert.Equal(t, state, decoded.Row(i, false))
+	}
+	return decoded
+}
+
+func TestAggregateFunctionCountSum(t *testing.T) {
+	col := roundTripAggregateStates(t, "AggregateFunction(count)", []byte{0}, binary.AppendUvarint(nil, 300))
+	var count uint64
+	require.NoError(t, col.ScanRow(&count, 1))
+	assert.Equal(t, uint64(300), count)
+
+	sum := binary.LittleEndian.AppendUint64(nil, 42)
+	col = roundTripAggregateStates(t, "AggregateFunction(sumIf, UInt32, UInt8)", sum, make([]byte, 8))
+	var total uint64
+	require.NoError(t, col.ScanRow(&total, 0))
+	assert.Equal(t, uint64(42), total)
+}
+
+func TestAggregateFunctionMinMax(t *testing.T) {
+	value := binary.LittleEndian.AppendUint32([]byte{1}, math.MaxUint32) // -1 as Int32
+	col := roundTripAggregateStates(t, "AggregateFunction(1, min, Int32)", value, []byte{0})
+	var (
+		minimum int32
+		missing *int32
+	)
+	require.NoError(t, col.ScanRow(&minimum, 0))
+	assert.Equal(t, int32(-1), minimum)
+	require.NoError(t, col.ScanRow(&missing, 1))
+	assert.Nil(t, missing)
+
+	// functions over Nullable arguments prefix the state with a flag
+	col = roundTripAggregateStates(t, "AggregateFunction(max, Nullable(UInt8))", []byte{1, 1, 7}, []byte{0})
+	var maximum *uint8
+	require.NoError(t, col.ScanRow(&maximum, 0))
+	require.NotNil(t, maximum)
+	assert.Equal(t, uint8(7), *maximum)
+}
+
+func TestAggregateFunctionAvg(t *testing.T) {
+	state := binary.AppendUvarint(binary.LittleEndian.AppendUint64(nil, 10), 4)
+	col := roundTripAggregateStates(t, "AggregateFunction(avg, Int32)", state)
+	var avg float64
+	require.NoError(t, col.ScanRow(&avg, 0))
+	assert.Equal(t, 2.5, avg)
+}
+
+func TestAggregateFunctionGroupBitmap(t *testing.T) {
+	small := []byte{0, 3}
+	for _, v := range []uint32{1, 5, 9} {
+		small = binary.LittleEndian.AppendUint32(small, v)
+	}
+	// a roaring bitmap without run containers holding an array container with the values 65536 and 65538
+	roaring := binary.LittleEndian.AppendUint32(nil, roaringSerialCookieNoRunContainer)
+	roaring = binary.LittleEndian.AppendUint32(roaring, 1)
+	roaring = binary.LittleEndian.AppendUint16(roaring, 1)
+	roaring = binary.LittleEndian.AppendUint16(roaring, 1)
+	roaring = binary.LittleEndian.AppendUint32(roaring, 16)
+	roaring = binary.LittleEndian.AppendUint16(roaring, 0)
+	roaring = binary.LittleEndian.AppendUint16(roaring, 2)
+	large := append(binary.AppendUvarint([]byte{1}, uint64(len(roaring))), roaring...)
+
+	col := roundTripAggregateStates(t, "AggregateFunction(groupBitmap, UInt32)", small, large)
+	var values []uint32
+	require.NoError(t, col.ScanRow(&values, 0))
+	assert.Equal(t, []uint32{1, 5, 9}, values)
+	require.NoError(t, col.ScanRow(&values, 1))
+	assert.Equal(t, []uint32{65536, 65538}, values)
+	var cardinality uint64
+	require.NoError(t, col.ScanRow(&cardinality, 1))
+	assert.Equal(t, uint64(2), cardinality)
+}
+
+func TestAggregateFunctionOpaque(t *testing.T) {
+	state := binary.LittleEndian.AppendUint32([]byte{0, 1}, 123456)
+	col := roundTripAggregateStates(t, "AggregateFunction(uniq, String)", state)
+	var count uint64
+	require.Error(t, col.ScanRow(&count, 0))
+
+	// states of unknown functions can be inserted but not decoded
+	col, err := Type("AggregateFunction(quantiles(0.5, 0.9), Float64)").Column("a", nil)
+	require.NoError(t, err)
+	require.NoError(t, col.AppendRow([]byte{1, 2, 3}))
+	require.Error(t, col.AppendRow(nil))
+	require.Error(t, col.Decode(proto.NewReader(nil), 1))
+}
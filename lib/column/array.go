@@ -91,6 +91,18 @@ func (col *Array) Base() Interface {
 	return col.values
 }
 
+// Depth returns the number of nested array levels of the column
+func (col *Array) Depth() int {
+	return col.depth
+}
+
+// Offsets returns the end offsets of the arrays at the given nesting level, starting at 0 for the outermost arrays.
+// They index the arrays of the next level, or the values of Base for the innermost level. The slice shares memory
+// with the column.
+func (col *Array) Offsets(level int) []uint64 {
+	return col.offsets[level].values.Data()
+}
+
 func (col *Array) Type() Type {
 	return col.chType
 }
@@ -0,0 +1,338 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// binaryType is a data type read from its binary encoding.
+// https://clickhouse.com/docs/en/sql-reference/data-types/data-types-binary-encoding
+type binaryType struct {
+	code  byte
+	name  string
+	elems []binaryType
+	// element names of a named tuple
+	names []string
+}
+
+const (
+	binaryTypeNothing        byte = 0x00
+	binaryTypeFixedString    byte = 0x16
+	binaryTypeArray          byte = 0x1E
+	binaryTypeTuple          byte = 0x1F
+	binaryTypeNamedTuple     byte = 0x20
+	binaryTypeNullable       byte = 0x23
+	binaryTypeLowCardinality byte = 0x26
+	binaryTypeMap            byte = 0x27
+	binaryTypeVariant        byte = 0x2A
+	binaryTypeDynamic        byte = 0x2B
+)
+
+// binarySimpleTypes are the types without parameters, by binary code
+var binarySimpleTypes = map[byte]string{
+	0x01: "UInt8",
+	0x02: "UInt16",
+	0x03: "UInt32",
+	0x04: "UInt64",
+	0x05: "UInt128",
+	0x06: "UInt256",
+	0x07: "Int8",
+	0x08: "Int16",
+	0x09: "Int32",
+	0x0A: "Int64",
+	0x0B: "Int128",
+	0x0C: "Int256",
+	0x0D: "Float32",
+	0x0E: "Float64",
+	0x0F: "Date",
+	0x10: "Date32",
+	0x11: "DateTime",
+	0x15: "String",
+	0x1D: "UUID",
+	0x28: "IPv4",
+	0x29: "IPv6",
+	0x2D: "Bool",
+	0x32: "Time",
+}
+
+// decodeBinaryValue decodes a value prefixed with its binary encoded type, as stored in the shared data
+// of JSON columns and the shared variant of Dynamic columns.
+func decodeBinaryValue(data []byte, tz *time.Location) (string, any, error) {
+	reader := proto.NewReader(bytes.NewReader(data))
+	t, err := readBinaryType(reader)
+	if err != nil {
+		return "", nil, err
+	}
+	value, err := readBinaryValue(reader, t, tz)
+	if err != nil {
+		return "", nil, err
+	}
+	return t.name, value, nil
+}
+
+func readBinaryType(reader *proto.Reader) (t binaryType, err error) {
+	if t.code, err = reader.ReadByte(); err != nil {
+		return t, err
+	}
+	if name, ok := binarySimpleTypes[t.code]; ok {
+		t.name = name
+		return t, nil
+	}
+	switch t.code {
+	case binaryTypeNothing:
+		t.name = "Nothing"
+	case 0x12: // DateTime(time_zone)
+		tz, err := reader.Str()
+		if err != nil {
+			return t, err
+		}
+		t.name = fmt.Sprintf("DateTime('%s')", tz)
+	case 0x13, 0x14: // DateTime64(P) and DateTime64(P, time_zone)
+		precision, err := reader.UInt8()
+		if err != nil {
+			return t, err
+		}
+		t.name = fmt.Sprintf("DateTime64(%d)", precision)
+		if t.code == 0x14 {
+			tz, err := reader.Str()
+			if err != nil {
+				return t, err
+			}
+			t.name = fmt.Sprintf("DateTime64(%d, '%s')", precision, tz)
+		}
+	case 0x34: // Time64(P)
+		precision, err := reader.UInt8()
+		if err != nil {
+			return t, err
+		}
+		t.name = fmt.Sprintf("Time64(%d)", precision)
+	case binaryTypeFixedString:
+		size, err := reader.UVarInt()
+		if err != nil {
+			return t, err
+		}
+		t.name = fmt.Sprintf("FixedString(%d)", size)
+	case 0x17, 0x18: // Enum8 and Enum16
+		count, err := reader.UVarInt()
+		if err != nil {
+			return t, err
+		}
+		elements := make([]string, 0, count)
+		for i := uint64(0); i < count; i++ {
+			name, err := reader.Str()
+			if err != nil {
+				return t, err
+			}
+			var value int
+			if t.code == 0x17 {
+				v, err := reader.Int8()
+				if err != nil {
+					return t, err
+				}
+				value = int(v)
+			} else {
+				v, err := reader.Int16()
+				if err != nil {
+					return t, err
+				}
+				value = int(v)
+			}
+			elements = append(elements, fmt.Sprintf("'%s' = %d", strings.ReplaceAll(name, "'", "\\'"), value))
+		}
+		t.name = fmt.Sprintf("Enum8(%s)", strings.Join(elements, ", "))
+		if t.code == 0x18 {
+			t.name = fmt.Sprintf("Enum16(%s)", strings.Join(elements, ", "))
+		}
+	case 0x19, 0x1A, 0x1B, 0x1C: // Decimal32, Decimal64, Decimal128 and Decimal256
+		precision, err := reader.UInt8()
+		if err != nil {
+			return t, err
+		}
+		scale, err := reader.UInt8()
+		if err != nil {
+			return t, err
+		}
+		t.name = fmt.Sprintf("Decimal(%d, %d)", precision, scale)
+	case binaryTypeArray, binaryTypeNullable, binaryTypeLowCardinality:
+		elem, err := readBinaryType(reader)
+		if err != nil {
+			return t, err
+		}
+		t.elems = []binaryType{elem}
+		switch t.code {
+		case binaryTypeArray:
+			t.name = "Array(" + elem.name + ")"
+		case binaryTypeNullable:
+			t.name = "Nullable(" + elem.name + ")"
+		default:
+			t.name = "LowCardinality(" + elem.name + ")"
+		}
+	case binaryTypeMap:
+		key, err := readBinaryType(reader)
+		if err != nil {
+			return t, err
+		}
+		value, err := readBinaryType(reader)
+		if err != nil {
+			return t, err
+		}
+		t.elems = []binaryType{key, value}
+		t.name = "Map(" + key.name + ", " + value.name + ")"
+	case binaryTypeTuple, binaryTypeNamedTuple, binaryTypeVariant:
+		count, err := reader.UVarInt()
+		if err != nil {
+			return t, err
+		}
+		names := make([]string, 0, count)
+		for i := uint64(0); i < count; i++ {
+			var name string
+			if t.code == binaryTypeNamedTuple {
+				if name, err = reader.Str(); err != nil {
+					return t, err
+				}
+				t.names = append(t.names, name)
+			}
+			elem, err := readBinaryType(reader)
+			if err != nil {
+				return t, err
+			}
+			t.elems = append(t.elems, elem)
+			if name != "" {
+				names = append(names, name+" "+elem.name)
+			} else {
+				names = append(names, elem.name)
+			}
+		}
+		t.name = "Tuple(" + strings.Join(names, ", ") + ")"
+		if t.code == binaryTypeVariant {
+			t.name = "Variant(" + strings.Join(names, ", ") + ")"
+		}
+	case binaryTypeDynamic:
+		maxTypes, err := reader.UInt8()
+		if err != nil {
+			return t, err
+		}
+		t.name = fmt.Sprintf("Dynamic(max_types=%d)", maxTypes)
+	default:
+		return t, fmt.Errorf("unsupported binary type encoding 0x%02X", t.code)
+	}
+	return t, nil
+}
+
+func readBinaryValue(reader *proto.Reader, t binaryType, tz *time.Location) (any, error) {
+	switch t.code {
+	case binaryTypeNothing:
+		return nil, nil
+	case binaryTypeArray:
+		size, err := reader.UVarInt()
+		if err != nil {
+			return nil, err
+		}
+		values := make([]any, 0, size)
+		for i := uint64(0); i < size; i++ {
+			value, err := readBinaryValue(reader, t.elems[0], tz)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case binaryTypeMap:
+		size, err := reader.UVarInt()
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[any]any, size)
+		for i := uint64(0); i < size; i++ {
+			key, err := readBinaryValue(reader, t.elems[0], tz)
+			if err != nil {
+				return nil, err
+			}
+			value, err := readBinaryValue(reader, t.elems[1], tz)
+			if err != nil {
+				return nil, err
+			}
+			values[key] = value
+		}
+		return values, nil
+	case binaryTypeNullable:
+		isNull, err := reader.UInt8()
+		if err != nil {
+			return nil, err
+		}
+		if isNull == 1 {
+			return nil, nil
+		}
+		return readBinaryValue(reader, t.elems[0], tz)
+	case binaryTypeLowCardinality:
+		return readBinaryValue(reader, t.elems[0], tz)
+	case binaryTypeTuple:
+		values := make([]any, 0, len(t.elems))
+		for _, elem := range t.elems {
+			value, err := readBinaryValue(reader, elem, tz)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case binaryTypeNamedTuple:
+		values := make(map[string]any, len(t.elems))
+		for i, elem := range t.elems {
+			value, err := readBinaryValue(reader, elem, tz)
+			if err != nil {
+				return nil, err
+			}
+			values[t.names[i]] = value
+		}
+		return values, nil
+	case binaryTypeVariant:
+		discriminator, err := reader.UInt8()
+		if err != nil {
+			return nil, err
+		}
+		if discriminator == NullVariantDiscriminator {
+			return nil, nil
+		}
+		if int(discriminator) >= len(t.elems) {
+			return nil, fmt.Errorf("invalid variant discriminator %d", discriminator)
+		}
+		return readBinaryValue(reader, t.elems[discriminator], tz)
+	case binaryTypeDynamic:
+		valueType, err := readBinaryType(reader)
+		if err != nil {
+			return nil, err
+		}
+		return readBinaryValue(reader, valueType, tz)
+	}
+	// the binary encoding of a single value of the remaining types matches their native encoding
+	column, err := Type(t.name).Column("", tz)
+	if err != nil {
+		return nil, err
+	}
+	if err := column.Decode(reader, 1); err != nil {
+		return nil, err
+	}
+	return column.Row(0, false), nil
+}
@@ -83,6 +83,8 @@ func (t Type) Column(name string, tz *time.Location) (Interface, error) {
 		return &Date{name: name, location: tz}, nil
 	case "Date32":
 		return &Date32{name: name, location: tz}, nil
+	case "Time":
+		return &Time{name: name, chType: t}, nil
 	case "UUID":
 		return &UUID{name: name}, nil
 	case "Nothing":
@@ -117,10 +119,34 @@ func (t Type) Column(name string, tz *time.Location) (Interface, error) {
             set:  set,
             name: name,
         }, nil
+	case "LineString":
+		set, err := (&Array{name: name}).parse("Array(Point)", tz)
+        if err != nil {
+            return nil, err
+        }
+        set.chType = "LineString"
+        return &LineString{
+            set:  set,
+            name: name,
+        }, nil
+	case "MultiLineString":
+		set, err := (&Array{name: name}).parse("Array(LineString)", tz)
+        if err != nil {
+            return nil, err
+        }
+        set.chType = "MultiLineString"
+        return &MultiLineString{
+            set:  set,
+            name: name,
+        }, nil
+	case "Geometry":
+		return (&Geometry{name: name}).parse(tz)
 	case "Point":
 		return &Point{name: name}, nil
+	case "SharedVariant":
+		return &SharedVariant{name: name}, nil
 	case "String":
-		return &String{name: name}, nil
+		return &String{name: name, col: colStrProvider()}, nil
 	case "Object('json')":
 	    return &JSONObject{name: name, root: true, tz: tz}, nil
 	}
@@ -130,6 +156,12 @@ func (t Type) Column(name string, tz *time.Location) (Interface, error) {
 		return (&Map{name: name}).parse(t, tz)
 	case strings.HasPrefix(string(t), "Tuple("):
 		return (&Tuple{name: name}).parse(t, tz)
+	case strings.HasPrefix(strType, "Variant("):
+		return (&Variant{name: name}).parse(t, tz)
+	case strings.HasPrefix(strType, "Dynamic"):
+		return (&Dynamic{name: name}).parse(t, tz)
+	case strings.HasPrefix(strType, "JSON"):
+		return (&JSONColumn{name: name}).parse(t, tz)
 	case strings.HasPrefix(string(t), "Decimal("):
 		return (&Decimal{name: name}).parse(t)
 	case strings.HasPrefix(strType, "Nested("):
@@ -146,12 +178,16 @@ func (t Type) Column(name string, tz *time.Location) (Interface, error) {
 		return (&LowCardinality{name: name}).parse(t, tz)
 	case strings.HasPrefix(string(t), "SimpleAggregateFunction"):
 		return (&SimpleAggregateFunction{name: name}).parse(t, tz)
+	case strings.HasPrefix(string(t), "AggregateFunction("):
+		return (&AggregateFunction{name: name}).parse(t, tz)
 	case strings.HasPrefix(string(t), "Enum8") || strings.HasPrefix(string(t), "Enum16"):
 		return Enum(t, name)
 	case strings.HasPrefix(string(t), "DateTime64"):
 		return (&DateTime64{name: name}).parse(t, tz)
 	case strings.HasPrefix(strType, "DateTime") && !strings.HasPrefix(strType, "DateTime64"):
 		return (&DateTime{name: name}).parse(t, tz)
+	case strings.HasPrefix(strType, "Time64("):
+		return (&Time64{name: name}).parse(t)
 	}
 	return nil, &UnsupportedColumnTypeError{
 		t: t,
@@ -191,6 +227,9 @@ var (
 		scanTypePolygon = reflect.TypeOf(orb.Polygon{})
 		scanTypeDecimal = reflect.TypeOf(decimal.Decimal{})
 		scanTypeMultiPolygon = reflect.TypeOf(orb.MultiPolygon{})
+		scanTypeLineString = reflect.TypeOf(orb.LineString{})
+		scanTypeMultiLineString = reflect.TypeOf(orb.MultiLineString{})
+		scanTypeGeometry = reflect.TypeOf((*orb.Geometry)(nil)).Elem()
 	)
 
 {{- range . }}
@@ -215,6 +254,11 @@ func (col *{{ .ChType }}) Reset() {
     col.col.Reset()
 }
 
+// Data returns the values of the column without converting them. The slice shares memory with the column.
+func (col *{{ .ChType }}) Data() []{{ .GoType }} {
+	return col.col
+}
+
 func (col *{{ .ChType }}) ScanRow(dest any, row int) error {
 	value := col.col.Row(row)
 	switch d := dest.(type) {
@@ -99,6 +99,8 @@ func (t Type) Column(name string, tz *time.Location) (Interface, error) {
 		return &Date{name: name, location: tz}, nil
 	case "Date32":
 		return &Date32{name: name, location: tz}, nil
+	case "Time":
+		return &Time{name: name, chType: t}, nil
 	case "UUID":
 		return &UUID{name: name}, nil
 	case "Nothing":
@@ -133,8 +135,32 @@ func (t Type) Column(name string, tz *time.Location) (Interface, error) {
 			set:  set,
 			name: name,
 		}, nil
+	case "LineString":
+		set, err := (&Array{name: name}).parse("Array(Point)", tz)
+		if err != nil {
+			return nil, err
+		}
+		set.chType = "LineString"
+		return &LineString{
+			set:  set,
+			name: name,
+		}, nil
+	case "MultiLineString":
+		set, err := (&Array{name: name}).parse("Array(LineString)", tz)
+		if err != nil {
+			return nil, err
+		}
+		set.chType = "MultiLineString"
+		return &MultiLineString{
+			set:  set,
+			name: name,
+		}, nil
+	case "Geometry":
+		return (&Geometry{name: name}).parse(tz)
 	case "Point":
 		return &Point{name: name}, nil
+	case "SharedVariant":
+		return &SharedVariant{name: name}, nil
 	case "String":
 		return &String{name: name, col: colStrProvider()}, nil
 	case "Object('json')":
@@ -146,6 +172,12 @@ func (t Type) Column(name string, tz *time.Location) (Interface, error) {
 		return (&Map{name: name}).parse(t, tz)
 	case strings.HasPrefix(string(t), "Tuple("):
 		return (&Tuple{name: name}).parse(t, tz)
+	case strings.HasPrefix(strType, "Variant("):
+		return (&Variant{name: name}).parse(t, tz)
+	case strings.HasPrefix(strType, "Dynamic"):
+		return (&Dynamic{name: name}).parse(t, tz)
+	case strings.HasPrefix(strType, "JSON"):
+		return (&JSONColumn{name: name}).parse(t, tz)
 	case strings.HasPrefix(string(t), "Decimal("):
 		return (&Decimal{name: name}).parse(t)
 	case strings.HasPrefix(strType, "Nested("):
@@ -162,12 +194,16 @@ func (t Type) Column(name string, tz *time.Location) (Interface, error) {
 		return (&LowCardinality{name: name}).parse(t, tz)
 	case strings.HasPrefix(string(t), "SimpleAggregateFunction"):
 		return (&SimpleAggregateFunction{name: name}).parse(t, tz)
+	case strings.HasPrefix(string(t), "AggregateFunction("):
+		return (&AggregateFunction{name: name}).parse(t, tz)
 	case strings.HasPrefix(string(t), "Enum8") || strings.HasPrefix(string(t), "Enum16"):
 		return Enum(t, name)
 	case strings.HasPrefix(string(t), "DateTime64"):
 		return (&DateTime64{name: name}).parse(t, tz)
 	case strings.HasPrefix(strType, "DateTime") && !strings.HasPrefix(strType, "DateTime64"):
 		return (&DateTime{name: name}).parse(t, tz)
+	case strings.HasPrefix(strType, "Time64("):
+		return (&Time64{name: name}).parse(t)
 	}
 	return nil, &UnsupportedColumnTypeError{
 		t: t,
@@ -231,30 +267,33 @@ var (
 )
 
 var (
-	scanTypeFloat32      = reflect.TypeOf(float32(0))
-	scanTypeFloat64      = reflect.TypeOf(float64(0))
-	scanTypeInt8         = reflect.TypeOf(int8(0))
-	scanTypeInt16        = reflect.TypeOf(int16(0))
-	scanTypeInt32        = reflect.TypeOf(int32(0))
-	scanTypeInt64        = reflect.TypeOf(int64(0))
-	scanTypeUInt8        = reflect.TypeOf(uint8(0))
-	scanTypeUInt16       = reflect.TypeOf(uint16(0))
-	scanTypeUInt32       = reflect.TypeOf(uint32(0))
-	scanTypeUInt64       = reflect.TypeOf(uint64(0))
-	scanTypeIP           = reflect.TypeOf(net.IP{})
-	scanTypeBool         = reflect.TypeOf(true)
-	scanTypeByte         = reflect.TypeOf([]byte{})
-	scanTypeUUID         = reflect.TypeOf(uuid.UUID{})
-	scanTypeTime         = reflect.TypeOf(time.Time{})
-	scanTypeRing         = reflect.TypeOf(orb.Ring{})
-	scanTypePoint        = reflect.TypeOf(orb.Point{})
-	scanTypeSlice        = reflect.TypeOf([]any{})
-	scanTypeMap          = reflect.TypeOf(map[string]any{})
-	scanTypeBigInt       = reflect.TypeOf(&big.Int{})
-	scanTypeString       = reflect.TypeOf("")
-	scanTypePolygon      = reflect.TypeOf(orb.Polygon{})
-	scanTypeDecimal      = reflect.TypeOf(decimal.Decimal{})
-	scanTypeMultiPolygon = reflect.TypeOf(orb.MultiPolygon{})
+	scanTypeFloat32         = reflect.TypeOf(float32(0))
+	scanTypeFloat64         = reflect.TypeOf(float64(0))
+	scanTypeInt8            = reflect.TypeOf(int8(0))
+	scanTypeInt16           = reflect.TypeOf(int16(0))
+	scanTypeInt32           = reflect.TypeOf(int32(0))
+	scanTypeInt64           = reflect.TypeOf(int64(0))
+	scanTypeUInt8           = reflect.TypeOf(uint8(0))
+	scanTypeUInt16          = reflect.TypeOf(uint16(0))
+	scanTypeUInt32          = reflect.TypeOf(uint32(0))
+	scanTypeUInt64          = reflect.TypeOf(uint64(0))
+	scanTypeIP              = reflect.TypeOf(net.IP{})
+	scanTypeBool            = reflect.TypeOf(true)
+	scanTypeByte            = reflect.TypeOf([]byte{})
+	scanTypeUUID            = reflect.TypeOf(uuid.UUID{})
+	scanTypeTime            = reflect.TypeOf(time.Time{})
+	scanTypeRing            = reflect.TypeOf(orb.Ring{})
+	scanTypePoint           = reflect.TypeOf(orb.Point{})
+	scanTypeSlice           = reflect.TypeOf([]any{})
+	scanTypeMap             = reflect.TypeOf(map[string]any{})
+	scanTypeBigInt          = reflect.TypeOf(&big.Int{})
+	scanTypeString          = reflect.TypeOf("")
+	scanTypePolygon         = reflect.TypeOf(orb.Polygon{})
+	scanTypeDecimal         = reflect.TypeOf(decimal.Decimal{})
+	scanTypeMultiPolygon    = reflect.TypeOf(orb.MultiPolygon{})
+	scanTypeLineString      = reflect.TypeOf(orb.LineString{})
+	scanTypeMultiLineString = reflect.TypeOf(orb.MultiLineString{})
+	scanTypeGeometry        = reflect.TypeOf((*orb.Geometry)(nil)).Elem()
 )
 
 func (col *Float32) Name() string {
@@ -277,6 +316,11 @@ func (col *Float32) Reset() {
 	col.col.Reset()
 }
 
+// Data returns the values of the column without converting them. The slice shares memory with the column.
+func (col *Float32) Data() []float32 {
+	return col.col
+}
+
 func (col *Float32) ScanRow(dest any, row int) error {
 	value := col.col.Row(row)
 	switch d := dest.(type) {
@@ -418,6 +462,11 @@ func (col *Float64) Reset() {
 	col.col.Reset()
 }
 
+// Data returns the values of the column without converting them. The slice shares memory with the column.
+func (col *Float64) Data() []float64 {
+	return col.col
+}
+
 func (col *Float64) ScanRow(dest any, row int) error {
 	value := col.col.Row(row)
 	switch d := dest.(type) {
@@ -588,6 +637,11 @@ func (col *Int8) Reset() {
 	col.col.Reset()
 }
 
+// Data returns the values of the column without converting them. The slice shares memory with the column.
+func (col *Int8) Data() []int8 {
+	return col.col
+}
+
 func (col *Int8) ScanRow(dest any, row int) error {
 	value := col.col.Row(row)
 	switch d := dest.(type) {
@@ -768,6 +822,11 @@ func (col *Int16) Reset() {
 	col.col.Reset()
 }
 
+// Data returns the values of the column without converting them. The slice shares memory with the column.
+func (col *Int16) Data() []int16 {
+	return col.col
+}
+
 func (col *Int16) ScanRow(dest any, row int) error {
 	value := col.col.Row(row)
 	switch d := dest.(type) {
@@ -938,6 +997,11 @@ func (col *Int32) Reset() {
 	col.col.Reset()
 }
 
+// Data returns the values of the column without converting them. The slice shares memory with the column.
+func (col *Int32) Data() []int32 {
+	return col.col
+}
+
 func (col *Int32) ScanRow(dest any, row int) error {
 	value := col.col.Row(row)
 	switch d := dest.(type) {
@@ -1108,6 +1172,11 @@ func (col *Int64) Reset() {
 	col.col.Reset()
 }
 
+// Data returns the values of the column without converting them. The slice shares memory with the column.
+func (col *Int64) Data() []int64 {
+	return col.col
+}
+
 func (col *Int64) ScanRow(dest any, row int) error {
 	value := col.col.Row(row)
 	switch d := dest.(type) {
@@ -1284,6 +1353,11 @@ func (col *UInt8) Reset() {
 	col.col.Reset()
 }
 
+// Data returns the values of the column without converting them. The slice shares memory with the column.
+func (col *UInt8) Data() []uint8 {
+	return col.col
+}
+
 func (col *UInt8) ScanRow(dest any, row int) error {
 	value := col.col.Row(row)
 	switch d := dest.(type) {
@@ -1438,6 +1512,11 @@ func (col *UInt16) Reset() {
 	col.col.Reset()
 }
 
+// Data returns the values of the column without converting them. The slice shares memory with the column.
+func (col *UInt16) Data() []uint16 {
+	return col.col
+}
+
 func (col *UInt16) ScanRow(dest any, row int) error {
 	value := col.col.Row(row)
 	switch d := dest.(type) {
@@ -1579,6 +1658,11 @@ func (col *UInt32) Reset() {
 	col.col.Reset()
 }
 
+// Data returns the values of the column without converting them. The slice shares memory with the column.
+func (col *UInt32) Data() []uint32 {
+	return col.col
+}
+
 func (col *UInt32) ScanRow(dest any, row int) error {
 	value := col.col.Row(row)
 	switch d := dest.(type) {
@@ -1720,6 +1804,11 @@ func (col *UInt64) Reset() {
 	col.col.Reset()
 }
 
+// Data returns the values of the column without converting them. The slice shares memory with the column.
+func (col *UInt64) Data() []uint64 {
+	return col.col
+}
+
 func (col *UInt64) ScanRow(dest any, row int) error {
 	value := col.col.Row(row)
 	switch d := dest.(type) {
@@ -80,6 +80,11 @@ func (col *DateTime) Rows() int {
 	return col.col.Rows()
 }
 
+// Location returns the time zone the values of the column are returned in
+func (col *DateTime) Location() *time.Location {
+	return col.col.Location
+}
+
 func (col *DateTime) Row(i int, ptr bool) any {
 	value := col.row(i)
 	if ptr {
@@ -286,6 +286,19 @@ func (col *DateTime64) Encode(buffer *proto.Buffer) {
 	col.col.EncodeColumn(buffer)
 }
 
+// Precision returns the number of decimal digits of the fractional seconds of the column
+func (col *DateTime64) Precision() int {
+	return int(col.col.Precision)
+}
+
+// Location returns the time zone the values of the column are returned in
+func (col *DateTime64) Location() *time.Location {
+	if col.timezone != nil {
+		return col.timezone
+	}
+	return col.col.Location
+}
+
 func (col *DateTime64) row(i int) time.Time {
 	time := col.col.Row(i)
 	if col.timezone != nil {
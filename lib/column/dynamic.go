@@ -0,0 +1,309 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// DynamicSerializationVersionV1 writes max_dynamic_types in the state prefix
+	DynamicSerializationVersionV1 = 1
+	// DynamicSerializationVersionV2 omits max_dynamic_types from the state prefix
+	DynamicSerializationVersionV2 = 2
+	// DefaultMaxDynamicTypes is the server default for Dynamic when max_types is not set
+	DefaultMaxDynamicTypes = 32
+)
+
+// https://github.com/ClickHouse/ClickHouse/blob/master/src/Columns/ColumnDynamic.h
+// A Dynamic column is a Variant whose member types are not part of the column type. They are read from
+// the state prefix of every block, and built from the appended values when inserting.
+type Dynamic struct {
+	chType   Type
+	name     string
+	tz       *time.Location
+	maxTypes int
+	variant  Variant
+}
+
+func (col *Dynamic) Reset() {
+	col.variant.Reset()
+}
+
+func (col *Dynamic) Name() string {
+	return col.name
+}
+
+func (col *Dynamic) parse(t Type, tz *time.Location) (_ *Dynamic, err error) {
+	col.chType, col.tz, col.maxTypes = t, tz, DefaultMaxDynamicTypes
+	if params := strings.TrimSpace(t.params()); params != "" {
+		key, value, _ := strings.Cut(params, "=")
+		if strings.TrimSpace(key) != "max_types" {
+			return nil, &UnsupportedColumnTypeError{
+				t: t,
+			}
+		}
+		if col.maxTypes, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+			return nil, fmt.Errorf("invalid max_types for %s: %w", t, err)
+		}
+	}
+	if err := col.initVariant([]string{SharedVariantTypeName}); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+func (col *Dynamic) initVariant(types []string) error {
+	col.variant = Variant{
+		chType: col.chType,
+		name:   col.name,
+	}
+	return col.variant.init(types, col.tz)
+}
+
+func (col *Dynamic) Type() Type {
+	return col.chType
+}
+
+func (col *Dynamic) ScanType() reflect.Type {
+	return scanTypeVariant
+}
+
+func (col *Dynamic) Rows() int {
+	return col.variant.Rows()
+}
+
+func (col *Dynamic) Row(i int, ptr bool) any {
+	return col.variant.Row(i, ptr)
+}
+
+func (col *Dynamic) ScanRow(dest any, row int) error {
+	return col.variant.ScanRow(dest, row)
+}
+
+func (col *Dynamic) Append(v any) (nulls []uint8, err error) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Slice {
+		return nil, &ColumnConverterError{
+			Op:   "Append",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+			Hint: "value must be a slice",
+		}
+	}
+	for i := 0; i < value.Len(); i++ {
+		if err := col.AppendRow(value.Index(i).Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// AppendRow stores v as the ClickHouse type matching its Go type. Use chcol.NewDynamicWithType to choose
+// the type explicitly, in which case it must be given in its canonical form, e.g. Map(String, Int64).
+func (col *Dynamic) AppendRow(v any) (err error) {
+	var typeName string
+	switch value := v.(type) {
+	case chcol.Variant:
+		v, typeName = value.Any(), value.Type()
+	case *chcol.Variant:
+		if value != nil {
+			v, typeName = value.Any(), value.Type()
+		} else {
+			v = nil
+		}
+	}
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		col.variant.appendNull()
+		return nil
+	}
+	if typeName == "" {
+		if typeName, err = dynamicTypeOf(v); err != nil {
+			return &ColumnConverterError{
+				Op:   "AppendRow",
+				To:   string(col.chType),
+				From: fmt.Sprintf("%T", v),
+				Hint: "use chcol.NewDynamicWithType to set the ClickHouse type",
+			}
+		}
+	}
+	discriminator, ok := col.variant.typeIndex[typeName]
+	if !ok {
+		if discriminator, err = col.variant.addType(typeName); err != nil {
+			return err
+		}
+	}
+	return col.variant.appendTo(discriminator, v)
+}
+
+func (col *Dynamic) Decode(reader *proto.Reader, rows int) error {
+	return col.variant.Decode(reader, rows)
+}
+
+func (col *Dynamic) Encode(buffer *proto.Buffer) {
+	col.variant.sortTypes()
+	col.variant.Encode(buffer)
+}
+
+func (col *Dynamic) ReadStatePrefix(reader *proto.Reader) error {
+	version, err := reader.UInt64()
+	if err != nil {
+		return err
+	}
+	switch version {
+	case DynamicSerializationVersionV1:
+		if _, err := reader.UVarInt(); err != nil {
+			return err
+		}
+	case DynamicSerializationVersionV2:
+	default:
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        errors.New(fmt.Sprintf("unsupported dynamic serialization version %d", version)),
+		}
+	}
+	count, err := reader.UVarInt()
+	if err != nil {
+		return err
+	}
+	types := make([]string, 0, count+1)
+	for i := uint64(0); i < count; i++ {
+		typeName, err := reader.Str()
+		if err != nil {
+			return err
+		}
+		types = append(types, typeName)
+	}
+	// the shared variant is not listed but always takes part in the discriminator order
+	types = append(types, SharedVariantTypeName)
+	sort.Strings(types)
+	if err := col.initVariant(types); err != nil {
+		return err
+	}
+	return col.variant.ReadStatePrefix(reader)
+}
+
+func (col *Dynamic) WriteStatePrefix(buffer *proto.Buffer) error {
+	col.variant.sortTypes()
+	maxTypes := col.maxTypes
+	if count := len(col.variant.types) - 1; count > maxTypes {
+		maxTypes = count
+	}
+	buffer.PutUInt64(DynamicSerializationVersionV1)
+	buffer.PutUVarInt(uint64(maxTypes))
+	buffer.PutUVarInt(uint64(len(col.variant.types) - 1))
+	for _, typeName := range col.variant.types {
+		if typeName != SharedVariantTypeName {
+			buffer.PutString(typeName)
+		}
+	}
+	return col.variant.WriteStatePrefix(buffer)
+}
+
+// dynamicTypeOf returns the ClickHouse type a Go value is stored as in a Dynamic column
+func dynamicTypeOf(v any) (string, error) {
+	switch v := v.(type) {
+	case []byte:
+		return "String", nil
+	case net.IP:
+		if v.To4() != nil {
+			return "IPv4", nil
+		}
+		return "IPv6", nil
+	case decimal.Decimal:
+		scale := -v.Exponent()
+		if scale < 0 {
+			scale = 0
+		}
+		if scale > 38 {
+			return "", fmt.Errorf("decimal scale %d exceeds 38", scale)
+		}
+		return fmt.Sprintf("Decimal(38, %d)", scale), nil
+	}
+	return dynamicTypeOfType(reflect.TypeOf(v))
+}
+
+var scanTypeBigIntValue = reflect.TypeOf(big.Int{})
+
+func dynamicTypeOfType(t reflect.Type) (string, error) {
+	switch t {
+	case scanTypeTime:
+		return "DateTime64(9)", nil
+	case scanTypeUUID:
+		return "UUID", nil
+	case scanTypeBigInt, scanTypeBigIntValue:
+		return "Int256", nil
+	case scanTypeIP:
+		return "IPv6", nil
+	case scanTypePoint:
+		return "Point", nil
+	case scanTypeRing:
+		return "Ring", nil
+	case scanTypePolygon:
+		return "Polygon", nil
+	case scanTypeMultiPolygon:
+		return "MultiPolygon", nil
+	case scanTypeLineString:
+		return "LineString", nil
+	case scanTypeMultiLineString:
+		return "MultiLineString", nil
+	case scanTypeByte:
+		return "String", nil
+	}
+	if chType, ok := kindMappings[t.Kind()]; ok {
+		return chType, nil
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return dynamicTypeOfType(t.Elem())
+	case reflect.Slice, reflect.Array:
+		elem, err := dynamicTypeOfType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "Array(" + elem + ")", nil
+	case reflect.Map:
+		key, err := dynamicTypeOfType(t.Key())
+		if err != nil {
+			return "", err
+		}
+		value, err := dynamicTypeOfType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "Map(" + key + ", " + value + ")", nil
+	}
+	return "", fmt.Errorf("no ClickHouse type for %s", t)
+}
+
+var (
+	_ Interface           = (*Dynamic)(nil)
+	_ CustomSerialization = (*Dynamic)(nil)
+)
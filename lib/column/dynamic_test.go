@@ -0,0 +1,96 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamicTypeOf(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{value: int64(1), expected: "Int64"},
+		{value: 1, expected: "Int64"},
+		{value: uint8(1), expected: "UInt8"},
+		{value: "a", expected: "String"},
+		{value: []byte("a"), expected: "String"},
+		{value: true, expected: "Bool"},
+		{value: time.Now(), expected: "DateTime64(9)"},
+		{value: []string{"a"}, expected: "Array(String)"},
+		{value: map[string][]int32{}, expected: "Map(String, Array(Int32))"},
+	}
+	for _, test := range tests {
+		chType, err := dynamicTypeOf(test.value)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, chType)
+	}
+	_, err := dynamicTypeOf([]any{1})
+	require.Error(t, err)
+}
+
+func TestDynamicEncodeDecode(t *testing.T) {
+	col, err := Type("Dynamic").Column("d", nil)
+	require.NoError(t, err)
+	dynamic := col.(*Dynamic)
+
+	require.NoError(t, dynamic.AppendRow("hello"))
+	require.NoError(t, dynamic.AppendRow(int64(42)))
+	require.NoError(t, dynamic.AppendRow(nil))
+	require.NoError(t, dynamic.AppendRow([]int64{1, 2}))
+	require.NoError(t, dynamic.AppendRow(chcol.NewDynamicWithType(int64(7), "Int32")))
+	require.Equal(t, 5, dynamic.Rows())
+
+	var buffer proto.Buffer
+	require.NoError(t, dynamic.WriteStatePrefix(&buffer))
+	dynamic.Encode(&buffer)
+
+	decoded, err := Type("Dynamic").Column("d", nil)
+	require.NoError(t, err)
+	reader := proto.NewReader(buffer.Reader())
+	require.NoError(t, decoded.(CustomSerialization).ReadStatePrefix(reader))
+	require.NoError(t, decoded.Decode(reader, 5))
+
+	expected := []chcol.Dynamic{
+		chcol.NewDynamicWithType("hello", "String"),
+		chcol.NewDynamicWithType(int64(42), "Int64"),
+		{},
+		chcol.NewDynamicWithType([]int64{1, 2}, "Array(Int64)"),
+		chcol.NewDynamicWithType(int32(7), "Int32"),
+	}
+	for i := range expected {
+		var v chcol.Dynamic
+		require.NoError(t, decoded.ScanRow(&v, i))
+		assert.Equal(t, expected[i], v)
+	}
+}
+
+func TestDynamicMaxTypes(t *testing.T) {
+	col, err := Type("Dynamic(max_types=4)").Column("d", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 4, col.(*Dynamic).maxTypes)
+	_, err = Type("Dynamic(foo=4)").Column("d", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,201 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/paulmach/orb"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
+)
+
+// geometryVariant is the variant the server stores Geometry values in
+const geometryVariant = "Variant(LineString, MultiLineString, MultiPolygon, Point, Polygon, Ring)"
+
+// Geometry holds any of the geo types. Its values are orb.Point, orb.Ring, orb.LineString, orb.MultiLineString,
+// orb.Polygon or orb.MultiPolygon, and nil for NULL.
+type Geometry struct {
+	variant *Variant
+	name    string
+}
+
+func (col *Geometry) parse(tz *time.Location) (_ *Geometry, err error) {
+	if col.variant, err = (&Variant{name: col.name}).parse(geometryVariant, tz); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+func (col *Geometry) Reset() {
+	col.variant.Reset()
+}
+
+func (col *Geometry) Name() string {
+	return col.name
+}
+
+func (col *Geometry) Type() Type {
+	return "Geometry"
+}
+
+func (col *Geometry) ScanType() reflect.Type {
+	return scanTypeGeometry
+}
+
+func (col *Geometry) Rows() int {
+	return col.variant.Rows()
+}
+
+func (col *Geometry) Row(i int, ptr bool) any {
+	value := col.row(i)
+	if ptr {
+		return &value
+	}
+	return value
+}
+
+func (col *Geometry) ScanRow(dest any, row int) error {
+	switch d := dest.(type) {
+	case *orb.Geometry:
+		*d = col.row(row)
+	case **orb.Geometry:
+		*d = new(orb.Geometry)
+		**d = col.row(row)
+	default:
+		// the member column reports a mismatch between dest and the geo type of the row
+		return col.variant.ScanRow(dest, row)
+	}
+	return nil
+}
+
+func (col *Geometry) Append(v any) (nulls []uint8, err error) {
+	switch v := v.(type) {
+	case []orb.Geometry:
+		for _, v := range v {
+			if err := col.AppendRow(v); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case []orb.Point, []*orb.Point, []orb.Ring, []*orb.Ring, []orb.LineString, []*orb.LineString,
+		[]orb.MultiLineString, []*orb.MultiLineString, []orb.Polygon, []*orb.Polygon, []orb.MultiPolygon, []*orb.MultiPolygon:
+		value := reflect.ValueOf(v)
+		for i := 0; i < value.Len(); i++ {
+			if err := col.AppendRow(value.Index(i).Interface()); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	default:
+		if valuer, ok := v.(driver.Valuer); ok {
+			val, err := valuer.Value()
+			if err != nil {
+				return nil, &ColumnConverterError{
+					Op:   "Append",
+					To:   "Geometry",
+					From: fmt.Sprintf("%T", v),
+					Hint: fmt.Sprintf("could not get driver.Valuer value, try using %s", col.Type()),
+				}
+			}
+			return col.Append(val)
+		}
+		return nil, &ColumnConverterError{
+			Op:   "Append",
+			To:   "Geometry",
+			From: fmt.Sprintf("%T", v),
+		}
+	}
+}
+
+func (col *Geometry) AppendRow(v any) error {
+	switch v := v.(type) {
+	case nil:
+		return col.variant.AppendRow(nil)
+	case orb.Point:
+		return col.variant.AppendRow(chcol.NewVariantWithType(v, "Point"))
+	case orb.Ring:
+		return col.variant.AppendRow(chcol.NewVariantWithType(v, "Ring"))
+	case orb.LineString:
+		return col.variant.AppendRow(chcol.NewVariantWithType(v, "LineString"))
+	case orb.MultiLineString:
+		return col.variant.AppendRow(chcol.NewVariantWithType(v, "MultiLineString"))
+	case orb.Polygon:
+		return col.variant.AppendRow(chcol.NewVariantWithType(v, "Polygon"))
+	case orb.MultiPolygon:
+		return col.variant.AppendRow(chcol.NewVariantWithType(v, "MultiPolygon"))
+	case *orb.Geometry:
+		if v == nil {
+			return col.variant.AppendRow(nil)
+		}
+		return col.AppendRow(*v)
+	case *orb.Point, *orb.Ring, *orb.LineString, *orb.MultiLineString, *orb.Polygon, *orb.MultiPolygon:
+		if value := reflect.ValueOf(v); !value.IsNil() {
+			return col.AppendRow(value.Elem().Interface())
+		}
+		return col.variant.AppendRow(nil)
+	default:
+		if valuer, ok := v.(driver.Valuer); ok {
+			val, err := valuer.Value()
+			if err != nil {
+				return &ColumnConverterError{
+					Op:   "AppendRow",
+					To:   "Geometry",
+					From: fmt.Sprintf("%T", v),
+					Hint: fmt.Sprintf("could not get driver.Valuer value, try using %s", col.Type()),
+				}
+			}
+			return col.AppendRow(val)
+		}
+		return &ColumnConverterError{
+			Op:   "AppendRow",
+			To:   "Geometry",
+			From: fmt.Sprintf("%T", v),
+		}
+	}
+}
+
+func (col *Geometry) Decode(reader *proto.Reader, rows int) error {
+	return col.variant.Decode(reader, rows)
+}
+
+func (col *Geometry) Encode(buffer *proto.Buffer) {
+	col.variant.Encode(buffer)
+}
+
+func (col *Geometry) ReadStatePrefix(reader *proto.Reader) error {
+	return col.variant.ReadStatePrefix(reader)
+}
+
+func (col *Geometry) WriteStatePrefix(buffer *proto.Buffer) error {
+	return col.variant.WriteStatePrefix(buffer)
+}
+
+func (col *Geometry) row(i int) orb.Geometry {
+	value, _ := col.variant.row(i).Any().(orb.Geometry)
+	return value
+}
+
+var (
+	_ Interface           = (*Geometry)(nil)
+	_ CustomSerialization = (*Geometry)(nil)
+)
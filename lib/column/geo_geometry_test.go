@@ -0,0 +1,72 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"testing"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeometryEncodeDecode(t *testing.T) {
+	ring := orb.Ring{orb.Point{0, 0}, orb.Point{1, 0}, orb.Point{0, 0}}
+	line := orb.LineString{orb.Point{1, 2}, orb.Point{3, 4}}
+	values := []orb.Geometry{
+		orb.Point{1, 2},
+		ring,
+		line,
+		orb.MultiLineString{line, line},
+		orb.Polygon{ring},
+		orb.MultiPolygon{orb.Polygon{ring}},
+		nil,
+	}
+	col, err := Type("Geometry").Column("g", nil)
+	require.NoError(t, err)
+	_, err = col.Append(values)
+	require.NoError(t, err)
+	require.NoError(t, col.AppendRow(&line))
+	_, err = col.Append([]orb.Ring{ring})
+	require.NoError(t, err)
+	require.Error(t, col.AppendRow(orb.MultiPoint{}))
+
+	var buffer proto.Buffer
+	require.NoError(t, col.(CustomSerialization).WriteStatePrefix(&buffer))
+	col.Encode(&buffer)
+	decoded, err := Type("Geometry").Column("g", nil)
+	require.NoError(t, err)
+	reader := proto.NewReader(buffer.Reader())
+	require.NoError(t, decoded.(CustomSerialization).ReadStatePrefix(reader))
+	require.NoError(t, decoded.Decode(reader, len(values)+2))
+
+	assert.Equal(t, Type("Geometry"), decoded.Type())
+	assert.Equal(t, scanTypeGeometry, decoded.ScanType())
+	for i, v := range append(values, line, ring) {
+		var value orb.Geometry
+		require.NoError(t, decoded.ScanRow(&value, i))
+		assert.Equal(t, v, value)
+		assert.Equal(t, v, decoded.Row(i, false))
+	}
+
+	var polygon orb.Polygon
+	require.NoError(t, decoded.ScanRow(&polygon, 4))
+	assert.Equal(t, orb.Polygon{ring}, polygon)
+	require.Error(t, decoded.ScanRow(&polygon, 0))
+}
@@ -18,15 +18,23 @@
 package column
 
 import (
-	"errors"
+	"database/sql"
+	"database/sql/driver"
 	"fmt"
-	"github.com/ClickHouse/ch-go/proto"
 	"reflect"
 	"strings"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
 )
 
+var scanTypeInterval = reflect.TypeOf(chcol.Interval{})
+
 type Interval struct {
 	chType Type
+	unit   chcol.IntervalUnit
 	name   string
 	col    proto.ColInt64
 }
@@ -40,8 +48,8 @@ func (col *Interval) Name() string {
 }
 
 func (col *Interval) parse(t Type) (Interface, error) {
-	switch col.chType = t; col.chType {
-	case "IntervalSecond", "IntervalMinute", "IntervalHour", "IntervalDay", "IntervalWeek", "IntervalMonth", "IntervalYear":
+	col.chType = t
+	if col.unit = chcol.IntervalUnit(strings.TrimPrefix(string(t), "Interval")); col.unit.Valid() {
 		return col, nil
 	}
 	return nil, &UnsupportedColumnTypeError{
@@ -49,44 +57,164 @@ func (col *Interval) parse(t Type) (Interface, error) {
 	}
 }
 
-func (col *Interval) Type() Type             { return col.chType }
-func (col *Interval) ScanType() reflect.Type { return scanTypeString }
-func (col *Interval) Rows() int              { return col.col.Rows() }
+func (col *Interval) Type() Type { return col.chType }
+
+// ScanType is time.Duration for the units with a fixed length, and chcol.Interval for Month, Quarter and Year
+func (col *Interval) ScanType() reflect.Type {
+	if col.unit.Duration() == 0 {
+		return scanTypeInterval
+	}
+	return scanTypeDuration
+}
+
+func (col *Interval) Rows() int { return col.col.Rows() }
+
 func (col *Interval) Row(i int, ptr bool) any {
-	val := col.row(i)
+	interval := col.row(i)
+	if d, ok := interval.Duration(); ok {
+		if ptr {
+			return &d
+		}
+		return d
+	}
 	if ptr {
-		return &val
+		return &interval
 	}
-	return val
+	return interval
 }
+
 func (col *Interval) ScanRow(dest any, row int) error {
+	interval := col.row(row)
 	switch d := dest.(type) {
 	case *string:
-		*d = col.row(row)
+		*d = col.text(interval)
 	case **string:
 		*d = new(string)
-		**d = col.row(row)
+		**d = col.text(interval)
+	case *chcol.Interval:
+		*d = interval
+	case **chcol.Interval:
+		*d = new(chcol.Interval)
+		**d = interval
+	case *time.Duration, **time.Duration:
+		value, ok := interval.Duration()
+		if !ok {
+			return &ColumnConverterError{
+				Op:   "ScanRow",
+				To:   fmt.Sprintf("%T", dest),
+				From: string(col.chType),
+				Hint: "a month, quarter or year has no fixed duration, scan into chcol.Interval",
+			}
+		}
+		switch d := d.(type) {
+		case *time.Duration:
+			*d = value
+		case **time.Duration:
+			*d = new(time.Duration)
+			**d = value
+		}
+	case *int64:
+		*d = interval.Value
+	case **int64:
+		*d = new(int64)
+		**d = interval.Value
 	default:
+		if scan, ok := dest.(sql.Scanner); ok {
+			return scan.Scan(col.Row(row, false))
+		}
 		return &ColumnConverterError{
 			Op:   "ScanRow",
 			To:   fmt.Sprintf("%T", dest),
-			From: "Interval",
+			From: string(col.chType),
 		}
 	}
 	return nil
 }
 
-func (Interval) Append(any) ([]uint8, error) {
-	return nil, &Error{
-		ColumnType: "Interval",
-		Err:        errors.New("data type values can't be stored in tables"),
+func (col *Interval) Append(v any) (nulls []uint8, err error) {
+	if v, ok := v.([]int64); ok {
+		col.col = append(col.col, v...)
+		return make([]uint8, len(v)), nil
 	}
+	return appendTimes(col, v, string(col.chType))
 }
 
-func (Interval) AppendRow(any) error {
-	return &Error{
-		ColumnType: "Interval",
-		Err:        errors.New("data type values can't be stored in tables"),
+func (col *Interval) AppendRow(v any) error {
+	value, err := col.value(v)
+	if err != nil {
+		return err
+	}
+	col.col.Append(value)
+	return nil
+}
+
+// value converts a value appended to the column into a number of units. Integers are numbers of units and
+// durations must be a whole number of units, they're never truncated. Nil values are appended as zero.
+func (col *Interval) value(v any) (int64, error) {
+	switch v := v.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return v, nil
+	case *int64:
+		if v != nil {
+			return *v, nil
+		}
+		return 0, nil
+	case int:
+		return int64(v), nil
+	case *int:
+		if v != nil {
+			return int64(*v), nil
+		}
+		return 0, nil
+	case time.Duration:
+		if unit := col.unit.Duration(); unit != 0 && v%unit == 0 {
+			return int64(v / unit), nil
+		}
+	case *time.Duration:
+		if v != nil {
+			return col.value(*v)
+		}
+		return 0, nil
+	case chcol.Interval:
+		if v.Unit == col.unit {
+			return v.Value, nil
+		}
+		if d, ok := v.Duration(); ok {
+			return col.value(d)
+		}
+	case *chcol.Interval:
+		if v != nil {
+			return col.value(*v)
+		}
+		return 0, nil
+	case sql.NullInt64:
+		return v.Int64, nil
+	default:
+		if valuer, ok := v.(driver.Valuer); ok {
+			val, err := valuer.Value()
+			if err != nil {
+				return 0, &ColumnConverterError{
+					Op:   "AppendRow",
+					To:   string(col.chType),
+					From: fmt.Sprintf("%T", v),
+					Hint: "could not get driver.Valuer value",
+				}
+			}
+			return col.value(val)
+		}
+		return 0, &ColumnConverterError{
+			Op:   "AppendRow",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+		}
+	}
+	return 0, &ColumnConverterError{
+		Op:   "AppendRow",
+		To:   string(col.chType),
+		From: fmt.Sprintf("%T", v),
+		Hint: fmt.Sprintf("%v cannot be converted to a number of %s units", v, strings.ToLower(string(col.unit))),
 	}
 }
 
@@ -94,13 +222,18 @@ func (col *Interval) Decode(reader *proto.Reader, rows int) error {
 	return col.col.DecodeColumn(reader, rows)
 }
 
-func (Interval) Encode(buffer *proto.Buffer) {
+func (col *Interval) Encode(buffer *proto.Buffer) {
+	col.col.EncodeColumn(buffer)
+}
+
+func (col *Interval) row(i int) chcol.Interval {
+	return chcol.NewInterval(col.col.Row(i), col.unit)
 }
 
-func (col *Interval) row(i int) string {
-	val := col.col.Row(i)
-	v := fmt.Sprintf("%d %s", val, strings.TrimPrefix(string(col.chType), "Interval"))
-	if val > 1 {
+// text formats an interval as a number of units, e.g. 4 Seconds
+func (col *Interval) text(interval chcol.Interval) string {
+	v := fmt.Sprintf("%d %s", interval.Value, interval.Unit)
+	if interval.Value > 1 {
 		v += "s"
 	}
 	return v
@@ -0,0 +1,95 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervalEncodeDecode(t *testing.T) {
+	encodeDecode := func(t *testing.T, chType Type, values ...any) Interface {
+		col, err := chType.Column("i", nil)
+		require.NoError(t, err)
+		for _, v := range values {
+			require.NoError(t, col.AppendRow(v))
+		}
+		var buffer proto.Buffer
+		col.Encode(&buffer)
+		decoded, err := chType.Column("i", nil)
+		require.NoError(t, err)
+		require.NoError(t, decoded.Decode(proto.NewReader(buffer.Reader()), len(values)))
+		return decoded
+	}
+
+	t.Run("fixed", func(t *testing.T) {
+		d := 180 * time.Second
+		col := encodeDecode(t, "IntervalMinute", 2*time.Minute, &d, chcol.NewInterval(2, chcol.IntervalHour), int64(5), nil)
+		assert.Equal(t, scanTypeDuration, col.ScanType())
+		assert.Equal(t, 2*time.Minute, col.Row(0, false))
+		assert.Equal(t, 3*time.Minute, col.Row(1, false))
+		assert.Equal(t, 2*time.Hour, col.Row(2, false))
+		assert.Equal(t, 5*time.Minute, col.Row(3, false))
+		assert.Equal(t, time.Duration(0), col.Row(4, false))
+
+		var (
+			duration time.Duration
+			interval chcol.Interval
+			text     string
+		)
+		require.NoError(t, col.ScanRow(&duration, 3))
+		assert.Equal(t, 5*time.Minute, duration)
+		require.NoError(t, col.ScanRow(&interval, 3))
+		assert.Equal(t, chcol.NewInterval(5, chcol.IntervalMinute), interval)
+		require.NoError(t, col.ScanRow(&text, 3))
+		assert.Equal(t, "5 Minutes", text)
+
+		// a duration that isn't a whole number of units is rejected rather than truncated
+		assert.Error(t, col.AppendRow(90*time.Second))
+		assert.Error(t, col.AppendRow(chcol.NewInterval(1500, chcol.IntervalMillisecond)))
+		assert.Equal(t, 5, col.Rows())
+	})
+
+	t.Run("calendar", func(t *testing.T) {
+		col := encodeDecode(t, "IntervalMonth", chcol.NewInterval(3, chcol.IntervalMonth), int64(1))
+		assert.Equal(t, scanTypeInterval, col.ScanType())
+		assert.Equal(t, chcol.NewInterval(3, chcol.IntervalMonth), col.Row(0, false))
+
+		var duration time.Duration
+		require.Error(t, col.ScanRow(&duration, 0))
+		require.Error(t, col.AppendRow(time.Hour))
+		require.Error(t, col.AppendRow(chcol.NewInterval(1, chcol.IntervalYear)))
+	})
+
+	_, err := Type("IntervalFortnight").Column("i", nil)
+	require.Error(t, err)
+}
+
+func TestDurationInterval(t *testing.T) {
+	assert.Equal(t, chcol.NewInterval(2, chcol.IntervalWeek), chcol.DurationInterval(14*24*time.Hour))
+	assert.Equal(t, chcol.NewInterval(-90, chcol.IntervalMinute), chcol.DurationInterval(-90*time.Minute))
+	assert.Equal(t, chcol.NewInterval(1500, chcol.IntervalMillisecond), chcol.DurationInterval(1500*time.Millisecond))
+	assert.Equal(t, chcol.NewInterval(1, chcol.IntervalNanosecond), chcol.DurationInterval(time.Nanosecond))
+	assert.Equal(t, chcol.NewInterval(0, chcol.IntervalSecond), chcol.DurationInterval(0))
+	assert.Equal(t, "INTERVAL 3 QUARTER", chcol.NewInterval(3, chcol.IntervalQuarter).String())
+}
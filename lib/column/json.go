@@ -18,936 +18,806 @@
 package column
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
 )
 
-// This JSON type implementation was done for an experimental Object('JSON') type:
-// https://clickhouse.com/docs/en/sql-reference/data-types/object-data-type
-// It's already deprecated in ClickHouse and will be removed in the future.
-// Since ClickHouse 24.8, the Object('JSON') type is no longer alias for JSON type.
-// The new JSON type has been introduced: https://clickhouse.com/docs/en/sql-reference/data-types/newjson
-// However, the new JSON type is not supported by the driver yet.
-//
-// This implementation is kept for backward compatibility and will be removed in the future. TODO: remove this
-
-// inverse mapping - go types to clickhouse types
-var kindMappings = map[reflect.Kind]string{
-	reflect.String:  "String",
-	reflect.Int:     "Int64",
-	reflect.Int8:    "Int8",
-	reflect.Int16:   "Int16",
-	reflect.Int32:   "Int32",
-	reflect.Int64:   "Int64",
-	reflect.Uint:    "UInt64",
-	reflect.Uint8:   "UInt8",
-	reflect.Uint16:  "UInt16",
-	reflect.Uint32:  "UInt32",
-	reflect.Uint64:  "UInt64",
-	reflect.Float32: "Float32",
-	reflect.Float64: "Float64",
-	reflect.Bool:    "Bool",
+const (
+	// JSONObjectSerializationVersionV1 writes max_dynamic_paths in the state prefix
+	JSONObjectSerializationVersionV1 = 0
+	// JSONStringSerializationVersion sends each row as JSON text
+	JSONStringSerializationVersion = 1
+	// JSONObjectSerializationVersionV2 omits max_dynamic_paths from the state prefix
+	JSONObjectSerializationVersionV2 = 2
+	// DefaultMaxDynamicPaths is the server default for JSON when max_dynamic_paths is not set
+	DefaultMaxDynamicPaths = 1024
+)
+
+var scanTypeJSON = reflect.TypeOf(chcol.JSON{})
+
+// JSONColumn is a column of the JSON type, the JSON interface is implemented by the columns of Object('json').
+// https://github.com/ClickHouse/ClickHouse/blob/master/src/Columns/ColumnObject.h
+// A JSON column is made of typed paths declared in the column type, dynamic paths discovered per block and
+// stored as Dynamic columns, and shared data holding the paths beyond max_dynamic_paths.
+type JSONColumn struct {
+	chType Type
+	name   string
+	tz     *time.Location
+	rows   int
+
+	maxDynamicPaths int
+	maxDynamicTypes int
+
+	typedPaths     []string
+	typedColumns   []Interface
+	typedPathIndex map[string]int
+	skipPaths      []string
+	skipRegexps    []*regexp.Regexp
+
+	dynamicPaths     []string
+	dynamicColumns   []*Dynamic
+	dynamicPathIndex map[string]int
+
+	// sharedData is Array(Tuple(paths String, values String)), with values prefixed by their binary encoded type
+	sharedData *Array
+
+	serializationVersion uint64
+	// jsonStrings holds the rows when the server sends JSON as text
+	jsonStrings proto.ColStr
+}
+
+func (col *JSONColumn) Reset() {
+	col.rows = 0
+	for _, column := range col.typedColumns {
+		column.Reset()
+	}
+	col.dynamicPaths, col.dynamicColumns = nil, nil
+	col.dynamicPathIndex = make(map[string]int)
+	col.sharedData.Reset()
+	col.jsonStrings.Reset()
+}
+
+func (col *JSONColumn) Name() string {
+	return col.name
+}
+
+// parse reads the column parameters, e.g. JSON(max_dynamic_paths=10, a.b UInt32, SKIP c, SKIP REGEXP '^d')
+func (col *JSONColumn) parse(t Type, tz *time.Location) (_ *JSONColumn, err error) {
+	col.chType, col.tz = t, tz
+	col.maxDynamicPaths, col.maxDynamicTypes = DefaultMaxDynamicPaths, DefaultMaxDynamicTypes
+	col.typedPathIndex = make(map[string]int)
+	col.dynamicPathIndex = make(map[string]int)
+	typedTypes := make(map[string]string)
+	for _, param := range splitTypeParams(t.params()) {
+		switch {
+		case strings.HasPrefix(param, "max_dynamic_paths="):
+			if col.maxDynamicPaths, err = strconv.Atoi(strings.TrimPrefix(param, "max_dynamic_paths=")); err != nil {
+				return nil, fmt.Errorf("invalid max_dynamic_paths for %s: %w", t, err)
+			}
+		case strings.HasPrefix(param, "max_dynamic_types="):
+			if col.maxDynamicTypes, err = strconv.Atoi(strings.TrimPrefix(param, "max_dynamic_types=")); err != nil {
+				return nil, fmt.Errorf("invalid max_dynamic_types for %s: %w", t, err)
+			}
+		case strings.HasPrefix(param, "SKIP REGEXP "):
+			pattern, err := strconv.Unquote(`"` + strings.Trim(strings.TrimSpace(strings.TrimPrefix(param, "SKIP REGEXP ")), "'") + `"`)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SKIP REGEXP for %s: %w", t, err)
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SKIP REGEXP for %s: %w", t, err)
+			}
+			col.skipRegexps = append(col.skipRegexps, re)
+		case strings.HasPrefix(param, "SKIP "):
+			path, _ := parseJSONPath(strings.TrimPrefix(param, "SKIP "))
+			col.skipPaths = append(col.skipPaths, path)
+		default:
+			path, typeName := parseJSONPath(param)
+			if path == "" || typeName == "" {
+				return nil, &UnsupportedColumnTypeError{
+					t: t,
+				}
+			}
+			col.typedPaths = append(col.typedPaths, path)
+			typedTypes[path] = typeName
+		}
+	}
+	// typed paths are serialized in sorted order
+	sort.Strings(col.typedPaths)
+	for i, path := range col.typedPaths {
+		column, err := Type(typedTypes[path]).Column(path, tz)
+		if err != nil {
+			return nil, err
+		}
+		col.typedColumns = append(col.typedColumns, column)
+		col.typedPathIndex[path] = i
+	}
+	if col.sharedData, err = (&Array{name: col.name}).parse("Array(Tuple(paths String, values String))", tz); err != nil {
+		return nil, err
+	}
+	return col, nil
 }
 
-// complex types for which a mapping exists - currently we map to String but could enhance in the future for other types
-var typeMappings = map[string]struct{}{
-	// currently JSON doesn't support DateTime, Decimal or IP so mapped to String
-	"time.Time":       {},
-	"decimal.Decimal": {},
-	"net.IP":          {},
-	"uuid.UUID":       {},
+// parseJSONPath splits "path Type" where the path may be quoted with backticks
+func parseJSONPath(param string) (path string, rest string) {
+	param = strings.TrimSpace(param)
+	if strings.HasPrefix(param, "`") {
+		for i := 1; i < len(param); i++ {
+			switch param[i] {
+			case '\\':
+				i++
+			case '`':
+				return colUnEscape.Replace(param[1:i]), strings.TrimSpace(param[i+1:])
+			}
+		}
+		return "", ""
+	}
+	path, rest, _ = strings.Cut(param, " ")
+	return path, strings.TrimSpace(rest)
 }
 
-type JSON interface {
-	Interface
-	appendEmptyValue() error
+func (col *JSONColumn) Type() Type {
+	return col.chType
 }
 
-type JSONParent interface {
-	upsertValue(name string, ct string) (*JSONValue, error)
-	upsertList(name string) (*JSONList, error)
-	upsertObject(name string) (*JSONObject, error)
-	insertEmptyColumn(name string) error
-	columnNames() []string
-	rows() int
+func (col *JSONColumn) ScanType() reflect.Type {
+	return scanTypeJSON
 }
 
-func parseType(name string, vType reflect.Type, values any, isArray bool, jCol JSONParent, numEmpty int) error {
-	_, ok := typeMappings[vType.String()]
-	if !ok {
-		return &UnsupportedColumnTypeError{
-			t: Type(vType.String()),
-		}
-	}
-	ct := "String"
-	if isArray {
-		ct = fmt.Sprintf("Array(%s)", ct)
-	}
-	col, err := jCol.upsertValue(name, ct)
+func (col *JSONColumn) Rows() int {
+	return col.rows
+}
+
+// Row returns the row as a chcol.JSON, or nil when it can't be decoded. ScanRow returns the decoding error.
+func (col *JSONColumn) Row(i int, ptr bool) any {
+	value, err := col.row(i)
 	if err != nil {
-		return err
+		return nil
+	}
+	if ptr {
+		return value
 	}
-	col.origType = vType
+	return *value
+}
 
-	//pre pad with empty - e.g. for new values in maps
-	for i := 0; i < numEmpty; i++ {
-		if isArray {
-			// empty array for nil of the right type
-			err = col.AppendRow([]string{})
-		} else {
-			// empty value of the type
-			err = col.AppendRow(fmt.Sprint(reflect.New(vType).Elem().Interface()))
-		}
-		if err != nil {
-			return err
+func (col *JSONColumn) row(i int) (*chcol.JSON, error) {
+	value := chcol.NewJSON()
+	if col.serializationVersion == JSONStringSerializationVersion {
+		if err := value.UnmarshalJSON(col.jsonStrings.RowBytes(i)); err != nil {
+			return nil, err
 		}
+		return value, nil
 	}
-	if isArray {
-		iValues := reflect.ValueOf(values)
-		sValues := make([]string, iValues.Len(), iValues.Len())
-		for i := 0; i < iValues.Len(); i++ {
-			sValues[i] = fmt.Sprint(iValues.Index(i).Interface())
+	for j, path := range col.typedPaths {
+		if v := col.typedColumns[j].Row(i, false); v != nil {
+			value.SetValueAtPath(path, v)
 		}
-		return col.AppendRow(sValues)
 	}
-	return col.AppendRow(fmt.Sprint(values))
-}
-
-func parsePrimitive(name string, kind reflect.Kind, values any, isArray bool, jCol JSONParent, numEmpty int) error {
-	ct, ok := kindMappings[kind]
-	if !ok {
-		return &UnsupportedColumnTypeError{
-			t: Type(fmt.Sprintf("%s - %s", kind, reflect.TypeOf(values).String())),
+	for j, path := range col.dynamicPaths {
+		if v := col.dynamicColumns[j].variant.row(i); !v.Nil() {
+			value.SetValueAtPath(path, v.Any())
 		}
 	}
-	var err error
-	if isArray {
-		ct = fmt.Sprintf("Array(%s)", ct)
-		// if we have a []any we will need to cast to the target column type - this will be based on the first
-		// values types. Inconsistent slices will fail.
-		values, err = convertSlice(values)
+	if col.sharedData.Rows() == 0 {
+		return value, nil
+	}
+	var (
+		offsets = col.sharedData.offsets[0].values.col
+		tuple   = col.sharedData.values.(*Tuple)
+		paths   = tuple.columns[0].(*String)
+		values  = tuple.columns[1].(*String)
+		start   = uint64(0)
+		end     = offsets.Row(i)
+	)
+	if i > 0 {
+		start = offsets.Row(i - 1)
+	}
+	for j := int(start); j < int(end); j++ {
+		_, v, err := decodeBinaryValue(values.col.RowBytes(j), col.tz)
 		if err != nil {
-			return err
+			return nil, &Error{
+				ColumnType: string(col.chType),
+				Err:        fmt.Errorf("shared data path %s: %w", paths.col.Row(j), err),
+			}
 		}
+		value.SetValueAtPath(paths.col.Row(j), v)
 	}
-	col, err := jCol.upsertValue(name, ct)
+	return value, nil
+}
+
+// ScanRow reads a row into a *chcol.JSON, a map, a struct, or JSON text as a string or []byte
+func (col *JSONColumn) ScanRow(dest any, row int) error {
+	value, err := col.row(row)
 	if err != nil {
 		return err
 	}
-
-	//pre pad with empty - e.g. for new values in maps
-	for i := 0; i < numEmpty; i++ {
-		if isArray {
-			// empty array for nil of the right type
-			err = col.AppendRow(reflect.MakeSlice(reflect.TypeOf(values), 0, 0).Interface())
-		} else {
-			err = col.AppendRow(nil)
+	switch d := dest.(type) {
+	case *chcol.JSON:
+		*d = *value
+		return nil
+	case **chcol.JSON:
+		*d = value
+		return nil
+	case *string:
+		b, err := value.MarshalJSON()
+		if err != nil {
+			return err
 		}
+		*d = string(b)
+		return nil
+	case *[]byte:
+		b, err := value.MarshalJSON()
 		if err != nil {
 			return err
 		}
-	}
-
-	return col.AppendRow(values)
-}
-
-// converts a []any of primitives to a typed slice
-// maybe this can be done with reflection but likely slower. investigate.
-// this uses the first value to determine the type - subsequent values must currently be of the same type - we might cast later
-// but wider driver doesn't support e.g. int to int64
-func convertSlice(values any) (any, error) {
-	rValues := reflect.ValueOf(values)
-	if rValues.Len() == 0 || rValues.Index(0).Kind() != reflect.Interface {
-		return values, nil
-	}
-	var fType reflect.Type
-	for i := 0; i < rValues.Len(); i++ {
-		elem := rValues.Index(i).Elem()
-		if elem.IsValid() {
-			fType = elem.Type()
-			break
+		*d = b
+		return nil
+	case *json.RawMessage:
+		b, err := value.MarshalJSON()
+		if err != nil {
+			return err
 		}
+		*d = b
+		return nil
+	case sql.Scanner:
+		return d.Scan(value)
 	}
-	if fType == nil {
-		return []any{}, nil
-	}
-	typedSlice := reflect.MakeSlice(reflect.SliceOf(fType), 0, rValues.Len())
-	for i := 0; i < rValues.Len(); i++ {
-		value := rValues.Index(i)
-		if value.IsNil() {
-			typedSlice = reflect.Append(typedSlice, reflect.Zero(fType))
-			continue
-		}
-		if rValues.Index(i).Elem().Type() != fType {
-			return nil, &Error{
-				ColumnType: fmt.Sprint(fType),
-				Err:        fmt.Errorf("inconsistent slices are not supported - expected %s got %s", fType, rValues.Index(i).Elem().Type()),
-			}
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &ColumnConverterError{
+			Op:   "ScanRow",
+			To:   fmt.Sprintf("%T", dest),
+			From: string(col.chType),
+			Hint: "destination must be a pointer",
 		}
-		typedSlice = reflect.Append(typedSlice, rValues.Index(i).Elem())
 	}
-	return typedSlice.Interface(), nil
-}
-
-func (jCol *JSONList) createNewOffsets(num int) {
-	for i := 0; i < num; i++ {
-		//single depth so can take 1st
-		if jCol.offsets[0].values.col.Rows() == 0 {
-			// first entry in the column
-			jCol.offsets[0].values.col.Append(0)
-		} else {
-			// entry for this object to see offset from last - offsets are cumulative
-			jCol.offsets[0].values.col.Append(jCol.offsets[0].values.col.Row(jCol.offsets[0].values.col.Rows() - 1))
+	if err := setJSONValue(rv.Elem(), value.NestedMap()); err != nil {
+		return &ColumnConverterError{
+			Op:   "ScanRow",
+			To:   fmt.Sprintf("%T", dest),
+			From: string(col.chType),
+			Hint: err.Error(),
 		}
 	}
+	return nil
 }
 
-func getStructFieldName(field reflect.StructField) (string, bool) {
-	name := field.Name
-	tag := field.Tag.Get("json")
-	// not a standard but we allow - to omit fields
-	if tag == "-" {
-		return name, true
-	}
-	if tag != "" {
-		return tag, false
-	}
-	// support ch tag as well as this is used elsewhere
-	tag = field.Tag.Get("ch")
-	if tag == "-" {
-		return name, true
-	}
-	if tag != "" {
-		return tag, false
+// setJSONValue assigns a value decoded from a JSON column to a Go value, mapping nested maps onto structs
+// using the same json and ch field tags as inserts.
+func setJSONValue(dest reflect.Value, value any) error {
+	if value == nil {
+		dest.Set(reflect.Zero(dest.Type()))
+		return nil
 	}
-	return name, false
-}
-
-// ensures numeric keys and ` are escaped properly
-func getMapFieldName(name string) string {
-	if !escapeColRegex.MatchString(name) {
-		return fmt.Sprintf("`%s`", colEscape.Replace(name))
+	source := reflect.ValueOf(value)
+	switch {
+	case source.Type().AssignableTo(dest.Type()):
+		dest.Set(source)
+		return nil
+	case dest.Kind() == reflect.Pointer:
+		elem := reflect.New(dest.Type().Elem())
+		if err := setJSONValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		dest.Set(elem)
+		return nil
 	}
-	return colEscape.Replace(name)
-}
-
-func parseSlice(name string, values any, jCol JSONParent, preFill int) error {
-	fType := reflect.TypeOf(values).Elem()
-	sKind := fType.Kind()
-	rValues := reflect.ValueOf(values)
-
-	if sKind == reflect.Interface {
-		//use the first element to determine if it is a complex or primitive map - after this we need consistent dimensions
-		if rValues.Len() == 0 {
+	switch nested := value.(type) {
+	case map[string]any:
+		switch dest.Kind() {
+		case reflect.Struct:
+			for i := 0; i < dest.NumField(); i++ {
+				field := dest.Type().Field(i)
+				if !field.IsExported() {
+					continue
+				}
+				name, omit := getStructFieldName(field)
+				if omit {
+					continue
+				}
+				if v, ok := nested[name]; ok {
+					if err := setJSONValue(dest.Field(i), v); err != nil {
+						return fmt.Errorf("field %s: %w", field.Name, err)
+					}
+				}
+			}
 			return nil
-		}
-		var value reflect.Value
-		for i := 0; i < rValues.Len(); i++ {
-			value = rValues.Index(i).Elem()
-			if value.IsValid() {
+		case reflect.Map:
+			if dest.Type().Key().Kind() != reflect.String {
 				break
 			}
-		}
-		if !value.IsValid() {
+			if dest.IsNil() {
+				dest.Set(reflect.MakeMapWithSize(dest.Type(), len(nested)))
+			}
+			for k, v := range nested {
+				elem := reflect.New(dest.Type().Elem()).Elem()
+				if err := setJSONValue(elem, v); err != nil {
+					return fmt.Errorf("key %s: %w", k, err)
+				}
+				dest.SetMapIndex(reflect.ValueOf(k).Convert(dest.Type().Key()), elem)
+			}
 			return nil
 		}
-		fType = value.Type()
-		sKind = value.Kind()
 	}
-
-	if _, ok := typeMappings[fType.String()]; ok {
-		return parseType(name, fType, values, true, jCol, preFill)
-	} else if sKind == reflect.Struct || sKind == reflect.Map || sKind == reflect.Slice {
-		if rValues.Len() == 0 {
-			return nil
-		}
-		col, err := jCol.upsertList(name)
-		if err != nil {
-			return err
-		}
-		col.createNewOffsets(preFill + 1)
-		for i := 0; i < rValues.Len(); i++ {
-			// increment offset
-			col.offsets[0].values.col[col.offsets[0].values.col.Rows()-1] += 1
-			value := rValues.Index(i)
-			sKind = value.Kind()
-			if sKind == reflect.Interface {
-				sKind = value.Elem().Kind()
-			}
-			switch sKind {
-			case reflect.Struct:
-				col.isNested = true
-				if err = iterateStruct(value, col, 0); err != nil {
-					return err
-				}
-			case reflect.Map:
-				col.isNested = true
-				if err = iterateMap(value, col, 0); err != nil {
-					return err
-				}
-			case reflect.Slice:
-				if err = parseSlice("", value.Interface(), col, 0); err != nil {
-					return err
-				}
-			default:
-				// only happens if slice has a primitive mixed with complex types in a []any
-				return &Error{
-					ColumnType: fmt.Sprint(sKind),
-					Err:        fmt.Errorf("slices must be same dimension in column %s", col.Name()),
-				}
+	if dest.Kind() == reflect.Slice && (source.Kind() == reflect.Slice || source.Kind() == reflect.Array) {
+		slice := reflect.MakeSlice(dest.Type(), source.Len(), source.Len())
+		for i := 0; i < source.Len(); i++ {
+			if err := setJSONValue(slice.Index(i), source.Index(i).Interface()); err != nil {
+				return err
 			}
 		}
+		dest.Set(slice)
 		return nil
 	}
-	return parsePrimitive(name, sKind, values, true, jCol, preFill)
-}
-
-func parseStruct(name string, structVal reflect.Value, jCol JSONParent, preFill int) error {
-	col, err := jCol.upsertObject(name)
-	if err != nil {
-		return err
+	if source.CanConvert(dest.Type()) && source.Kind() != reflect.String && dest.Kind() != reflect.String {
+		dest.Set(source.Convert(dest.Type()))
+		return nil
 	}
-	return iterateStruct(structVal, col, preFill)
+	return fmt.Errorf("cannot assign %T to %s", value, dest.Type())
 }
 
-func iterateStruct(structVal reflect.Value, col JSONParent, preFill int) error {
-	// structs generally have consistent field counts but we ignore nil values that are any as we can't infer from
-	// these until they occur - so we might need to either backfill when to do occur or insert empty based on previous
-	if structVal.Kind() == reflect.Interface {
-		// can happen if passed from []any
-		structVal = structVal.Elem()
+func (col *JSONColumn) Append(v any) (nulls []uint8, err error) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Slice {
+		return nil, &ColumnConverterError{
+			Op:   "Append",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+			Hint: "value must be a slice",
+		}
 	}
-
-	currentColumns := col.columnNames()
-	columnLookup := make(map[string]struct{})
-	numRows := col.rows()
-	for _, name := range currentColumns {
-		columnLookup[name] = struct{}{}
+	for i := 0; i < value.Len(); i++ {
+		if err := col.AppendRow(value.Index(i).Interface()); err != nil {
+			return nil, err
+		}
 	}
-	addedColumns := make([]string, structVal.NumField(), structVal.NumField())
-	newColumn := false
+	return
+}
 
-	for i := 0; i < structVal.NumField(); i++ {
-		fName, omit := getStructFieldName(structVal.Type().Field(i))
-		if omit {
-			continue
-		}
-		field := structVal.Field(i)
-		if !field.CanInterface() {
-			// can't interface - likely not exported so ignore the field
-			continue
+// AppendRow inserts a struct, a map with string keys, a *chcol.JSON, or JSON text as a string or []byte
+func (col *JSONColumn) AppendRow(v any) error {
+	paths := make(map[string]any)
+	switch value := v.(type) {
+	case nil:
+	case chcol.JSON:
+		for path, v := range value.ValuesByPath() {
+			paths[path] = v
 		}
-		kind := field.Kind()
-		value := field.Interface()
-		fType := field.Type()
-		//resolve underlying kind
-		if kind == reflect.Interface {
-			if value == nil {
-				// ignore nil fields
-				continue
+	case *chcol.JSON:
+		if value != nil {
+			for path, v := range value.ValuesByPath() {
+				paths[path] = v
 			}
-			kind = reflect.TypeOf(value).Kind()
-			field = reflect.ValueOf(value)
-			fType = field.Type()
 		}
-		if _, ok := columnLookup[fName]; !ok && len(currentColumns) > 0 {
-			// new column - need to handle missing
-			preFill = numRows
-			newColumn = true
-		}
-		if _, ok := typeMappings[fType.String()]; ok {
-			if err := parseType(fName, fType, value, false, col, preFill); err != nil {
-				return err
-			}
-		} else {
-			switch kind {
-			case reflect.Slice:
-				if reflect.ValueOf(value).Len() == 0 {
-					continue
-				}
-				if err := parseSlice(fName, value, col, preFill); err != nil {
-					return err
-				}
-			case reflect.Struct:
-				if err := parseStruct(fName, field, col, preFill); err != nil {
-					return err
-				}
-			case reflect.Map:
-				if err := parseMap(fName, field, col, preFill); err != nil {
-					return err
-				}
-			default:
-				if err := parsePrimitive(fName, kind, value, false, col, preFill); err != nil {
-					return err
-				}
-			}
+	case string:
+		if err := flattenJSONText([]byte(value), paths); err != nil {
+			return err
 		}
-		addedColumns[i] = fName
-		if newColumn {
-			// reset as otherwise prefill overflow to other fields. But don't reset if this prefill has come from
-			// a higher level
-			preFill = 0
+	case []byte:
+		if err := flattenJSONText(value, paths); err != nil {
+			return err
 		}
-	}
-	// handle missing
-	missingColumns := difference(currentColumns, addedColumns)
-	for _, name := range missingColumns {
-		if err := col.insertEmptyColumn(name); err != nil {
+	case json.RawMessage:
+		if err := flattenJSONText(value, paths); err != nil {
 			return err
 		}
+	default:
+		rv := reflect.Indirect(reflect.ValueOf(v))
+		if !rv.IsValid() {
+			break
+		}
+		if rv.Kind() != reflect.Struct && !(rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String) {
+			return &ColumnConverterError{
+				Op:   "AppendRow",
+				To:   string(col.chType),
+				From: fmt.Sprintf("%T", v),
+				Hint: "JSON rows must be a struct, a map with string keys or a JSON string",
+			}
+		}
+		flattenJSONValue("", rv, paths)
 	}
-	return nil
+	return col.appendPaths(paths)
 }
 
-func parseMap(name string, mapVal reflect.Value, jCol JSONParent, preFill int) error {
-	if mapVal.Type().Key().Kind() != reflect.String {
+func (col *JSONColumn) appendPaths(paths map[string]any) error {
+	if col.serializationVersion == JSONStringSerializationVersion {
 		return &Error{
-			ColumnType: fmt.Sprint(mapVal.Type().Key().Kind()),
-			Err:        fmt.Errorf("map keys must be string for column %s", name),
+			ColumnType: string(col.chType),
+			Err:        errors.New("cannot append to a column read with string serialization"),
 		}
 	}
-	col, err := jCol.upsertObject(name)
-	if err != nil {
-		return err
+	// typed paths that are not in the row are appended with their default value
+	for i, path := range col.typedPaths {
+		value, ok := paths[path]
+		if ok {
+			delete(paths, path)
+		}
+		if number, ok := value.(json.Number); ok {
+			value = jsonNumberAs(number, col.typedColumns[i].ScanType())
+		}
+		if err := col.typedColumns[i].AppendRow(value); err != nil {
+			return fmt.Errorf("typed path %s: %w", path, err)
+		}
 	}
-	return iterateMap(mapVal, col, preFill)
-}
-
-func iterateMap(mapVal reflect.Value, col JSONParent, preFill int) error {
-	// maps can have inconsistent numbers of elements - we must ensure they are consistent in the encoding
-	// two inconsistent options - 1. new - map has new columns 2. massing - map has missing columns
-	// for (1) we need to update previous, for (2) we need to ensure we add a null entry
-	if mapVal.Kind() == reflect.Interface {
-		// can happen if passed from []any
-		mapVal = mapVal.Elem()
-	}
-
-	currentColumns := col.columnNames()
-	//gives us a fast lookup for large maps
-	columnLookup := make(map[string]struct{})
-	numRows := col.rows()
-	// true if we need nil values
-	for _, name := range currentColumns {
-		columnLookup[name] = struct{}{}
-	}
-	addedColumns := make([]string, len(mapVal.MapKeys()), len(mapVal.MapKeys()))
-	newColumn := false
-	for i, key := range mapVal.MapKeys() {
-		if newColumn {
-			// reset as otherwise prefill overflow to other fields. But don't reset if this prefill has come from
-			// a higher level
-			preFill = 0
-		}
-
-		name := getMapFieldName(key.Interface().(string))
-		if _, ok := columnLookup[name]; !ok && len(currentColumns) > 0 {
-			// new column - need to handle
-			preFill = numRows
-			newColumn = true
-		}
-		field := mapVal.MapIndex(key)
-		kind := field.Kind()
-		fType := field.Type()
-
-		if kind == reflect.Interface {
-			if field.Interface() == nil {
-				// ignore nil fields
-				continue
-			}
-			kind = reflect.TypeOf(field.Interface()).Kind()
-			field = reflect.ValueOf(field.Interface())
-			fType = field.Type()
+	for path, value := range paths {
+		// missing and null paths are both read back as NULL, so there is no need to store them
+		if value == nil || col.skipPath(path) {
+			continue
 		}
-		if _, ok := typeMappings[fType.String()]; ok {
-			if err := parseType(name, fType, field.Interface(), false, col, preFill); err != nil {
+		i, ok := col.dynamicPathIndex[path]
+		if !ok {
+			var err error
+			if i, err = col.addDynamicPath(path); err != nil {
 				return err
 			}
-		} else {
-			switch kind {
-			case reflect.Struct:
-				if err := parseStruct(name, field, col, preFill); err != nil {
-					return err
-				}
-			case reflect.Slice:
-				if err := parseSlice(name, field.Interface(), col, preFill); err != nil {
-					return err
-				}
-			case reflect.Map:
-				if err := parseMap(name, field, col, preFill); err != nil {
-					return err
-				}
-			default:
-				if err := parsePrimitive(name, kind, field.Interface(), false, col, preFill); err != nil {
-					return err
-				}
+		}
+		typeName, value, err := jsonValueType(value)
+		if err != nil {
+			return &ColumnConverterError{
+				Op:   "AppendRow",
+				To:   string(col.chType),
+				From: fmt.Sprintf("%T", value),
+				Hint: fmt.Sprintf("path %s: %s", path, err),
 			}
 		}
-		addedColumns[i] = name
-	}
-	// handle missing
-	missingColumns := difference(currentColumns, addedColumns)
-	for _, name := range missingColumns {
-		if err := col.insertEmptyColumn(name); err != nil {
-			return err
+		if err := col.dynamicColumns[i].AppendRow(chcol.NewDynamicWithType(value, typeName)); err != nil {
+			return fmt.Errorf("dynamic path %s: %w", path, err)
 		}
 	}
-	return nil
-}
-
-func appendStructOrMap(jCol *JSONObject, data any) error {
-	vData := reflect.ValueOf(data)
-	kind := vData.Kind()
-	if kind == reflect.Struct {
-		return iterateStruct(vData, jCol, 0)
-	}
-	if kind == reflect.Map {
-		if reflect.TypeOf(data).Key().Kind() != reflect.String {
-			return &Error{
-				ColumnType: fmt.Sprint(reflect.TypeOf(data).Key().Kind()),
-				Err:        fmt.Errorf("map keys must be string for column %s", jCol.Name()),
-			}
-		}
-		if jCol.columns == nil && vData.Len() == 0 {
-			// if map is empty, we need to create an empty Tuple to make sure subcolumns protocol is happy
-			// _dummy is a ClickHouse internal name for empty Tuple subcolumn
-			// it has the same effect as `INSERT INTO single_json_type_table VALUES ('{}');`
-			jCol.upsertValue("_dummy", "Int8")
-			return jCol.insertEmptyColumn("_dummy")
+	col.rows++
+	// paths that are not in the row are NULL
+	for _, column := range col.dynamicColumns {
+		if column.Rows() < col.rows {
+			column.variant.appendNull()
 		}
-		return iterateMap(vData, jCol, 0)
 	}
-	return &UnsupportedColumnTypeError{
-		t: Type(fmt.Sprint(kind)),
+	if err := col.sharedData.AppendRow([]map[string]any{}); err != nil {
+		return err
 	}
+	return nil
 }
 
-type JSONValue struct {
-	Interface
-	// represents the type e.g. uuid - these may have been mapped to a Column type support by JSON e.g. String
-	origType reflect.Type
-}
-
-func (jCol *JSONValue) Reset() {
-	jCol.Interface.Reset()
-}
-
-func (jCol *JSONValue) appendEmptyValue() error {
-	switch jCol.Interface.(type) {
-	case *Array:
-		if jCol.Rows() > 0 {
-			return jCol.AppendRow(reflect.MakeSlice(reflect.TypeOf(jCol.Row(0, false)), 0, 0).Interface())
+func (col *JSONColumn) skipPath(path string) bool {
+	for _, skip := range col.skipPaths {
+		if path == skip || strings.HasPrefix(path, skip+".") {
+			return true
 		}
-		return &Error{
-			ColumnType: "unknown",
-			Err:        fmt.Errorf("can't add empty value to column %s - no entries to infer type", jCol.Name()),
-		}
-	default:
-		// can't just append nil here as we need a custom nil value for the type
-		if jCol.origType != nil {
-			return jCol.AppendRow(fmt.Sprint(reflect.New(jCol.origType).Elem().Interface()))
+	}
+	for _, re := range col.skipRegexps {
+		if re.MatchString(path) {
+			return true
 		}
-		return jCol.AppendRow(nil)
 	}
+	return false
 }
 
-func (jCol *JSONValue) Type() Type {
-	return Type(fmt.Sprintf("%s %s", jCol.Name(), jCol.Interface.Type()))
-}
-
-type JSONList struct {
-	Array
-	name     string
-	isNested bool // indicates if this a list of objects i.e. a Nested
+func (col *JSONColumn) addDynamicPath(path string) (int, error) {
+	column, err := Type(fmt.Sprintf("Dynamic(max_types=%d)", col.maxDynamicTypes)).Column(path, col.tz)
+	if err != nil {
+		return 0, err
+	}
+	dynamic := column.(*Dynamic)
+	for i := 0; i < col.rows; i++ {
+		dynamic.variant.appendNull()
+	}
+	col.dynamicPaths = append(col.dynamicPaths, path)
+	col.dynamicColumns = append(col.dynamicColumns, dynamic)
+	col.dynamicPathIndex[path] = len(col.dynamicPaths) - 1
+	return len(col.dynamicPaths) - 1, nil
 }
 
-func (jCol *JSONList) Name() string {
-	return jCol.name
+// sortDynamicPaths orders the dynamic paths by name, as ClickHouse serializes them
+func (col *JSONColumn) sortDynamicPaths() {
+	if sort.StringsAreSorted(col.dynamicPaths) {
+		return
+	}
+	sort.Sort(jsonDynamicPaths{col})
+	for i, path := range col.dynamicPaths {
+		col.dynamicPathIndex[path] = i
+	}
 }
 
-func (jCol *JSONList) columnNames() []string {
-	return jCol.Array.values.(*JSONObject).columnNames()
-}
+type jsonDynamicPaths struct{ col *JSONColumn }
 
-func (jCol *JSONList) rows() int {
-	return jCol.values.(*JSONObject).Rows()
+func (p jsonDynamicPaths) Len() int { return len(p.col.dynamicPaths) }
+func (p jsonDynamicPaths) Less(i, j int) bool {
+	return p.col.dynamicPaths[i] < p.col.dynamicPaths[j]
 }
-
-func createJSONList(name string, tz *time.Location) (jCol *JSONList) {
-	// lists are represented as Nested which are in turn encoded as Array(Tuple()). We thus pass a Array(JSONObject())
-	// as this encodes like a tuple
-	lCol := &JSONList{
-		name: name,
-	}
-	lCol.values = &JSONObject{tz: tz}
-	// depth should always be one as nested arrays aren't possible
-	lCol.depth = 1
-	lCol.scanType = scanTypeSlice
-	offsetScanTypes := []reflect.Type{lCol.scanType}
-	lCol.offsets = []*offset{{
-		scanType: offsetScanTypes[0],
-	}}
-	return lCol
+func (p jsonDynamicPaths) Swap(i, j int) {
+	p.col.dynamicPaths[i], p.col.dynamicPaths[j] = p.col.dynamicPaths[j], p.col.dynamicPaths[i]
+	p.col.dynamicColumns[i], p.col.dynamicColumns[j] = p.col.dynamicColumns[j], p.col.dynamicColumns[i]
 }
 
-func (jCol *JSONList) appendEmptyValue() error {
-	// only need to bump the offsets
-	jCol.createNewOffsets(1)
+func flattenJSONText(data []byte, paths map[string]any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value map[string]any
+	if err := decoder.Decode(&value); err != nil {
+		return &Error{
+			ColumnType: "JSON",
+			Err:        fmt.Errorf("invalid JSON text: %w", err),
+		}
+	}
+	flattenJSONValue("", reflect.ValueOf(value), paths)
 	return nil
 }
 
-func (jCol *JSONList) insertEmptyColumn(name string) error {
-	return jCol.values.(*JSONObject).insertEmptyColumn(name)
+// jsonLeafTypes are stored as a single value rather than being flattened into nested paths
+var jsonLeafTypes = map[reflect.Type]struct{}{
+	scanTypeTime:                    {},
+	scanTypeUUID:                    {},
+	scanTypeDecimal:                 {},
+	scanTypeBigIntValue:             {},
+	scanTypeVariant:                 {},
+	reflect.TypeOf(json.Number("")): {},
 }
 
-func (jCol *JSONList) upsertValue(name string, ct string) (*JSONValue, error) {
-	// check if column exists and reuse if same type, error if same name and different type
-	jObj := jCol.values.(*JSONObject)
-	cols := jObj.columns
-	for i := range cols {
-		sCol := cols[i]
-		if sCol.Name() == name {
-			vCol, ok := cols[i].(*JSONValue)
-			if !ok {
-				sType := cols[i].Type()
-				return nil, &Error{
-					ColumnType: fmt.Sprint(sType),
-					Err:        fmt.Errorf("type mismatch in column %s - expected value, got %s", name, sType),
-				}
-			}
-			tType := vCol.Interface.Type()
-			if tType != Type(ct) {
-				return nil, &Error{
-					ColumnType: ct,
-					Err:        fmt.Errorf("type mismatch in column %s - expected %s, got %s", name, tType, ct),
-				}
+// flattenJSONValue collects the leaf values of nested structs and maps into dot separated paths
+func flattenJSONValue(path string, value reflect.Value, paths map[string]any) {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			if path != "" {
+				paths[path] = nil
 			}
-			return vCol, nil
+			return
 		}
-	}
-	col, err := Type(ct).Column(name, jObj.tz)
-	if err != nil {
-		return nil, err
-	}
-	vCol := &JSONValue{
-		Interface: col,
-	}
-	jCol.values.(*JSONObject).columns = append(cols, vCol) // nolint:gocritic
-	return vCol, nil
-}
-
-func (jCol *JSONList) upsertList(name string) (*JSONList, error) {
-	// check if column exists and reuse if same type, error if same name and different type
-	jObj := jCol.values.(*JSONObject)
-	cols := jCol.values.(*JSONObject).columns
-	for i := range cols {
-		sCol := cols[i]
-		if sCol.Name() == name {
-			sCol, ok := cols[i].(*JSONList)
-			if !ok {
-				return nil, &Error{
-					ColumnType: fmt.Sprint(cols[i].Type()),
-					Err:        fmt.Errorf("type mismatch in column %s - expected list, got %s", name, cols[i].Type()),
-				}
-			}
-			return sCol, nil
+		if _, ok := jsonLeafTypes[value.Type()]; ok {
+			break
 		}
+		value = value.Elem()
 	}
-	lCol := createJSONList(name, jObj.tz)
-	jCol.values.(*JSONObject).columns = append(cols, lCol) // nolint:gocritic
-	return lCol, nil
-
-}
-
-func (jCol *JSONList) upsertObject(name string) (*JSONObject, error) {
-	// check if column exists and reuse if same type, error if same name and different type
-	jObj := jCol.values.(*JSONObject)
-	cols := jObj.columns
-	for i := range cols {
-		sCol := cols[i]
-		if sCol.Name() == name {
-			sCol, ok := cols[i].(*JSONObject)
-			if !ok {
-				sType := cols[i].Type()
-				return nil, &Error{
-					ColumnType: fmt.Sprint(sType),
-					Err:        fmt.Errorf("type mismatch in column %s, expected object got %s", name, sType),
+	if _, ok := jsonLeafTypes[value.Type()]; !ok {
+		switch {
+		case value.Kind() == reflect.Struct:
+			for i := 0; i < value.NumField(); i++ {
+				field := value.Type().Field(i)
+				if !field.IsExported() {
+					continue
 				}
+				name, omit := getStructFieldName(field)
+				if omit {
+					continue
+				}
+				flattenJSONValue(joinJSONPath(path, name), value.Field(i), paths)
+			}
+			return
+		case value.Kind() == reflect.Map && value.Type().Key().Kind() == reflect.String:
+			iter := value.MapRange()
+			for iter.Next() {
+				flattenJSONValue(joinJSONPath(path, iter.Key().String()), iter.Value(), paths)
 			}
-			return sCol, nil
+			return
 		}
 	}
-	// lists are represented as Nested which are in turn encoded as Array(Tuple()). We thus pass a Array(JSONObject())
-	// as this encodes like a tuple
-	oCol := &JSONObject{
-		name: name,
-		tz:   jObj.tz,
+	if path != "" {
+		paths[path] = value.Interface()
 	}
-	jCol.values.(*JSONObject).columns = append(cols, oCol) // nolint:gocritic
-	return oCol, nil
 }
 
-func (jCol *JSONList) Type() Type {
-	cols := jCol.values.(*JSONObject).columns
-	subTypes := make([]string, len(cols))
-	for i, v := range cols {
-		subTypes[i] = string(v.Type())
+func joinJSONPath(prefix, name string) string {
+	if prefix == "" {
+		return name
 	}
-	// can be a list of lists or a nested
-	if jCol.isNested {
-		return Type(fmt.Sprintf("%s Nested(%s)", jCol.name, strings.Join(subTypes, ", ")))
-	}
-	return Type(fmt.Sprintf("%s Array(%s)", jCol.name, strings.Join(subTypes, ", ")))
+	return prefix + "." + name
 }
 
-type JSONObject struct {
-	columns  []JSON
-	name     string
-	root     bool
-	encoding uint8
-	tz       *time.Location
-}
-
-func (jCol *JSONObject) Reset() {
-	for i := range jCol.columns {
-		jCol.columns[i].Reset()
+// jsonValueType returns the ClickHouse type a dynamic path value is stored as, normalizing
+// values decoded from JSON text: numbers become Int64 or Float64 and arrays take the type of their elements.
+func jsonValueType(v any) (string, any, error) {
+	switch value := v.(type) {
+	case nil:
+		return "", nil, nil
+	case chcol.Variant:
+		return value.Type(), value.Any(), nil
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return "Int64", i, nil
+		}
+		f, err := value.Float64()
+		if err != nil {
+			return "", nil, err
+		}
+		return "Float64", f, nil
+	case int:
+		return "Int64", int64(value), nil
+	case uint:
+		return "UInt64", uint64(value), nil
+	case []any:
+		return jsonArrayType(value)
+	case map[string]any:
+		return "", nil, errors.New("nested objects in arrays are not supported")
+	}
+	typeName, err := dynamicTypeOf(v)
+	if err != nil {
+		return "", nil, err
 	}
+	return typeName, v, nil
 }
 
-func (jCol *JSONObject) Name() string {
-	return jCol.name
-}
-
-func (jCol *JSONObject) columnNames() []string {
-	columns := make([]string, len(jCol.columns), len(jCol.columns))
-	for i := range jCol.columns {
-		columns[i] = jCol.columns[i].Name()
+// jsonArrayType converts a JSON array into a typed slice when all elements share a type
+func jsonArrayType(values []any) (string, any, error) {
+	if len(values) == 0 {
+		return "Array(Nullable(String))", []*string{}, nil
 	}
-	return columns
-}
-
-func (jCol *JSONObject) rows() int {
-	return jCol.Rows()
-}
-
-func (jCol *JSONObject) appendEmptyValue() error {
-	for i := range jCol.columns {
-		if err := jCol.columns[i].appendEmptyValue(); err != nil {
-			return err
+	var (
+		elemType  string
+		elements  = make([]any, 0, len(values))
+		nullable  bool
+		sliceType reflect.Type
+	)
+	for _, v := range values {
+		typeName, value, err := jsonValueType(v)
+		if err != nil {
+			return "", nil, err
 		}
-	}
-	return nil
-}
-
-func (jCol *JSONObject) insertEmptyColumn(name string) error {
-	for i := range jCol.columns {
-		if jCol.columns[i].Name() == name {
-			if err := jCol.columns[i].appendEmptyValue(); err != nil {
-				return err
-			}
-			return nil
+		if value == nil {
+			nullable = true
+			elements = append(elements, nil)
+			continue
 		}
-	}
-	return &Error{
-		ColumnType: "unknown",
-		Err:        fmt.Errorf("column %s is missing - empty value cannot be appended", name),
-	}
-}
-
-func (jCol *JSONObject) upsertValue(name string, ct string) (*JSONValue, error) {
-	for i := range jCol.columns {
-		sCol := jCol.columns[i]
-		if sCol.Name() == name {
-			vCol, ok := jCol.columns[i].(*JSONValue)
-			if !ok {
-				sType := jCol.columns[i].Type()
-				return nil, &Error{
-					ColumnType: fmt.Sprint(sType),
-					Err:        fmt.Errorf("type mismatch in column %s, expected value got %s", name, sType),
-				}
-			}
-			if vCol.Interface.Type() != Type(ct) {
-				return nil, &Error{
-					ColumnType: ct,
-					Err:        fmt.Errorf("type mismatch in column %s, expected %s got %s", name, vCol.Interface.Type(), ct),
-				}
-			}
-			return vCol, nil
+		if elemType != "" && elemType != typeName {
+			return "", nil, fmt.Errorf("array elements have different types %s and %s", elemType, typeName)
 		}
+		elemType, sliceType = typeName, reflect.TypeOf(value)
+		elements = append(elements, value)
 	}
-	col, err := Type(ct).Column(name, jCol.tz)
-	if err != nil {
-		return nil, err
+	if elemType == "" {
+		return "Array(Nullable(String))", make([]*string, len(values)), nil
 	}
-	vCol := &JSONValue{
-		Interface: col,
+	if nullable {
+		elemType, sliceType = "Nullable("+elemType+")", reflect.PointerTo(sliceType)
 	}
-	jCol.columns = append(jCol.columns, vCol)
-	return vCol, nil
-}
-
-func (jCol *JSONObject) upsertList(name string) (*JSONList, error) {
-	for i := range jCol.columns {
-		sCol := jCol.columns[i]
-		if sCol.Name() == name {
-			sCol, ok := jCol.columns[i].(*JSONList)
-			if !ok {
-				sType := jCol.columns[i].Type()
-				return nil, &Error{
-					ColumnType: fmt.Sprint(sType),
-					Err:        fmt.Errorf("type mismatch in column %s, expected list got %s", name, sType),
-				}
-			}
-			return sCol, nil
+	slice := reflect.MakeSlice(reflect.SliceOf(sliceType), len(elements), len(elements))
+	for i, v := range elements {
+		switch {
+		case v == nil:
+		case nullable:
+			ptr := reflect.New(sliceType.Elem())
+			ptr.Elem().Set(reflect.ValueOf(v))
+			slice.Index(i).Set(ptr)
+		default:
+			slice.Index(i).Set(reflect.ValueOf(v))
 		}
 	}
-	lCol := createJSONList(name, jCol.tz)
-	jCol.columns = append(jCol.columns, lCol)
-	return lCol, nil
+	return "Array(" + elemType + ")", slice.Interface(), nil
 }
 
-func (jCol *JSONObject) upsertObject(name string) (*JSONObject, error) {
-	// check if it exists
-	for i := range jCol.columns {
-		sCol := jCol.columns[i]
-		if sCol.Name() == name {
-			sCol, ok := jCol.columns[i].(*JSONObject)
-			if !ok {
-				sType := jCol.columns[i].Type()
-				return nil, &Error{
-					ColumnType: fmt.Sprint(sType),
-					Err:        fmt.Errorf("type mismatch in column %s, expected object got %s", name, sType),
-				}
-			}
-			return sCol, nil
-		}
+// jsonNumberAs converts a number parsed from JSON text into the scan type of a typed path
+func jsonNumberAs(number json.Number, t reflect.Type) any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
 	}
-	// not present so create
-	oCol := &JSONObject{
-		name: name,
-		tz:   jCol.tz,
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v, err := strconv.ParseInt(string(number), 10, t.Bits()); err == nil {
+			return reflect.ValueOf(v).Convert(t).Interface()
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v, err := strconv.ParseUint(string(number), 10, t.Bits()); err == nil {
+			return reflect.ValueOf(v).Convert(t).Interface()
+		}
+	case reflect.Float32, reflect.Float64:
+		if v, err := strconv.ParseFloat(string(number), t.Bits()); err == nil {
+			return reflect.ValueOf(v).Convert(t).Interface()
+		}
 	}
-	jCol.columns = append(jCol.columns, oCol)
-	return oCol, nil
+	return string(number)
 }
 
-func (jCol *JSONObject) Type() Type {
-	if jCol.root {
-		return "Object('json')"
+func (col *JSONColumn) Decode(reader *proto.Reader, rows int) error {
+	col.rows = rows
+	if col.serializationVersion == JSONStringSerializationVersion {
+		return col.jsonStrings.DecodeColumn(reader, rows)
 	}
-	return jCol.FullType()
-}
-
-func (jCol *JSONObject) FullType() Type {
-	subTypes := make([]string, len(jCol.columns))
-	for i, v := range jCol.columns {
-		subTypes[i] = string(v.Type())
+	for i, column := range col.typedColumns {
+		if err := column.Decode(reader, rows); err != nil {
+			return fmt.Errorf("typed path %s: %w", col.typedPaths[i], err)
+		}
 	}
-	if jCol.root {
-		return Type(fmt.Sprintf("Tuple(%s)", strings.Join(subTypes, ", ")))
+	for i, column := range col.dynamicColumns {
+		if err := column.Decode(reader, rows); err != nil {
+			return fmt.Errorf("dynamic path %s: %w", col.dynamicPaths[i], err)
+		}
 	}
-	return Type(fmt.Sprintf("%s Tuple(%s)", jCol.name, strings.Join(subTypes, ", ")))
-}
-
-func (jCol *JSONObject) ScanType() reflect.Type {
-	return scanTypeMap
+	return col.sharedData.Decode(reader, rows)
 }
 
-func (jCol *JSONObject) Rows() int {
-	if len(jCol.columns) != 0 {
-		return jCol.columns[0].Rows()
+func (col *JSONColumn) Encode(buffer *proto.Buffer) {
+	col.sortDynamicPaths()
+	for _, column := range col.typedColumns {
+		column.Encode(buffer)
 	}
-	return 0
-}
-
-// ClickHouse returns JSON as a tuple i.e. these will never be invoked
-
-func (jCol *JSONObject) Row(i int, ptr bool) any {
-	panic("Not implemented")
-}
-
-func (jCol *JSONObject) ScanRow(dest any, row int) error {
-	panic("Not implemented")
+	for _, column := range col.dynamicColumns {
+		column.Encode(buffer)
+	}
+	col.sharedData.Encode(buffer)
 }
 
-func (jCol *JSONObject) Append(v any) (nulls []uint8, err error) {
-	jSlice := reflect.ValueOf(v)
-	if jSlice.Kind() != reflect.Slice {
-		return nil, &ColumnConverterError{
-			Op:   "Append",
-			To:   string(jCol.Type()),
-			From: fmt.Sprintf("slice of structs/map or strings required - received %T", v),
-		}
+func (col *JSONColumn) ReadStatePrefix(reader *proto.Reader) error {
+	version, err := reader.UInt64()
+	if err != nil {
+		return fmt.Errorf("failed to read JSON serialization version: %w", err)
 	}
-	for i := 0; i < jSlice.Len(); i++ {
-		if err := jCol.AppendRow(jSlice.Index(i).Interface()); err != nil {
-			return nil, err
+	col.serializationVersion = version
+	switch version {
+	case JSONStringSerializationVersion:
+		return nil
+	case JSONObjectSerializationVersionV1:
+		maxPaths, err := reader.UVarInt()
+		if err != nil {
+			return fmt.Errorf("failed to read JSON max dynamic paths: %w", err)
 		}
+		col.maxDynamicPaths = int(maxPaths)
+	case JSONObjectSerializationVersionV2:
+	default:
+		return fmt.Errorf("unsupported JSON serialization version: %d", version)
 	}
-	return nil, nil
-}
-
-func (jCol *JSONObject) AppendRow(v any) error {
-	if reflect.ValueOf(v).Kind() == reflect.Struct || reflect.ValueOf(v).Kind() == reflect.Map {
-		if jCol.columns != nil && jCol.encoding == 1 {
-			return &Error{
-				ColumnType: fmt.Sprint(jCol.Type()),
-				Err:        fmt.Errorf("encoding of JSON columns cannot be mixed in a batch - %s cannot be added as previously String", reflect.ValueOf(v).Kind()),
-			}
+	count, err := reader.UVarInt()
+	if err != nil {
+		return fmt.Errorf("failed to read JSON dynamic path count: %w", err)
+	}
+	col.dynamicPaths, col.dynamicColumns = nil, nil
+	col.dynamicPathIndex = make(map[string]int, count)
+	for i := 0; i < int(count); i++ {
+		path, err := reader.Str()
+		if err != nil {
+			return fmt.Errorf("failed to read JSON dynamic path %d: %w", i, err)
+		}
+		if _, err := col.addDynamicPath(path); err != nil {
+			return err
 		}
-		err := appendStructOrMap(jCol, v)
-		return err
 	}
-	switch v := v.(type) {
-	case string:
-		if jCol.columns != nil && jCol.encoding == 0 {
-			return &Error{
-				ColumnType: fmt.Sprint(jCol.Type()),
-				Err:        fmt.Errorf("encoding of JSON columns cannot be mixed in a batch - %s cannot be added as previously Struct/Map", reflect.ValueOf(v).Kind()),
+	for i, column := range col.typedColumns {
+		if serialize, ok := column.(CustomSerialization); ok {
+			if err := serialize.ReadStatePrefix(reader); err != nil {
+				return fmt.Errorf("typed path %s: %w", col.typedPaths[i], err)
 			}
 		}
-		jCol.encoding = 1
-		if jCol.columns == nil {
-			jCol.columns = append(jCol.columns, &JSONValue{Interface: &String{}})
-		}
-		jCol.columns[0].AppendRow(v)
-	default:
-		return &ColumnConverterError{
-			Op:   "AppendRow",
-			To:   "String",
-			From: fmt.Sprintf("json row must be struct, map or string - received %T", v),
+	}
+	for i, column := range col.dynamicColumns {
+		if err := column.ReadStatePrefix(reader); err != nil {
+			return fmt.Errorf("dynamic path %s: %w", col.dynamicPaths[i], err)
 		}
 	}
 	return nil
 }
 
-func (jCol *JSONObject) Decode(reader *proto.Reader, rows int) error {
-	panic("Not implemented")
-}
-
-func (jCol *JSONObject) Encode(buffer *proto.Buffer) {
-	if jCol.root && jCol.encoding == 0 {
-		buffer.PutString(string(jCol.FullType()))
+func (col *JSONColumn) WriteStatePrefix(buffer *proto.Buffer) error {
+	col.sortDynamicPaths()
+	buffer.PutUInt64(JSONObjectSerializationVersionV1)
+	buffer.PutUVarInt(uint64(max(col.maxDynamicPaths, len(col.dynamicPaths))))
+	buffer.PutUVarInt(uint64(len(col.dynamicPaths)))
+	for _, path := range col.dynamicPaths {
+		buffer.PutString(path)
+	}
+	for _, column := range col.typedColumns {
+		if serialize, ok := column.(CustomSerialization); ok {
+			if err := serialize.WriteStatePrefix(buffer); err != nil {
+				return err
+			}
+		}
 	}
-	for _, c := range jCol.columns {
-		c.Encode(buffer)
+	for _, column := range col.dynamicColumns {
+		if err := column.WriteStatePrefix(buffer); err != nil {
+			return err
+		}
 	}
-}
-
-func (jCol *JSONObject) ReadStatePrefix(reader *proto.Reader) error {
-	_, err := reader.UInt8()
-	return err
-}
-
-func (jCol *JSONObject) WriteStatePrefix(buffer *proto.Buffer) error {
-	buffer.PutUInt8(jCol.encoding)
 	return nil
 }
 
 var (
-	_ Interface           = (*JSONObject)(nil)
-	_ CustomSerialization = (*JSONObject)(nil)
+	_ Interface           = (*JSONColumn)(nil)
+	_ CustomSerialization = (*JSONColumn)(nil)
 )
@@ -0,0 +1,146 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"testing"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONParse(t *testing.T) {
+	col, err := Type("JSON(max_dynamic_paths=10, max_dynamic_types=3, `b.c` String, a UInt32, SKIP d.e, SKIP REGEXP '^f')").Column("j", nil)
+	require.NoError(t, err)
+	json := col.(*JSONColumn)
+	assert.Equal(t, 10, json.maxDynamicPaths)
+	assert.Equal(t, 3, json.maxDynamicTypes)
+	assert.Equal(t, []string{"a", "b.c"}, json.typedPaths)
+	assert.Equal(t, Type("UInt32"), json.typedColumns[0].Type())
+	assert.Equal(t, []string{"d.e"}, json.skipPaths)
+	assert.True(t, json.skipPath("d.e.f"))
+	assert.True(t, json.skipPath("foo"))
+	assert.False(t, json.skipPath("d.ef"))
+
+	col, err = Type("JSON").Column("j", nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMaxDynamicPaths, col.(*JSONColumn).maxDynamicPaths)
+}
+
+type jsonTestRow struct {
+	ID   uint32 `json:"id"`
+	Name string `json:"name"`
+	Tags []string
+	Meta struct {
+		Score float64 `json:"score"`
+	} `json:"meta"`
+	Ignored string `json:"-"`
+}
+
+func TestJSONEncodeDecode(t *testing.T) {
+	const chType = "JSON(id UInt32)"
+	col, err := Type(chType).Column("j", nil)
+	require.NoError(t, err)
+
+	row := jsonTestRow{ID: 1, Name: "a", Tags: []string{"x", "y"}, Ignored: "z"}
+	row.Meta.Score = 1.5
+	require.NoError(t, col.AppendRow(row))
+	require.NoError(t, col.AppendRow(`{"id": 2, "name": "b", "meta": {"score": 2}, "extra": [1, 2]}`))
+	require.NoError(t, col.AppendRow(map[string]any{"name": "c"}))
+	require.NoError(t, col.AppendRow(nil))
+	require.Equal(t, 4, col.Rows())
+
+	var buffer proto.Buffer
+	require.NoError(t, col.(CustomSerialization).WriteStatePrefix(&buffer))
+	col.Encode(&buffer)
+
+	decoded, err := Type(chType).Column("j", nil)
+	require.NoError(t, err)
+	reader := proto.NewReader(buffer.Reader())
+	require.NoError(t, decoded.(CustomSerialization).ReadStatePrefix(reader))
+	require.NoError(t, decoded.Decode(reader, 4))
+	assert.Equal(t, []string{"Tags", "extra", "meta.score", "name"}, decoded.(*JSONColumn).dynamicPaths)
+
+	var first jsonTestRow
+	require.NoError(t, decoded.ScanRow(&first, 0))
+	row.Ignored = ""
+	assert.Equal(t, row, first)
+
+	var second chcol.JSON
+	require.NoError(t, decoded.ScanRow(&second, 1))
+	assert.Equal(t, map[string]any{
+		"id":         uint32(2),
+		"name":       "b",
+		"meta.score": int64(2),
+		"extra":      []int64{1, 2},
+	}, second.ValuesByPath())
+
+	var third string
+	require.NoError(t, decoded.ScanRow(&third, 2))
+	assert.JSONEq(t, `{"id": 0, "name": "c"}`, third)
+
+	var fourth map[string]any
+	require.NoError(t, decoded.ScanRow(&fourth, 3))
+	assert.Equal(t, map[string]any{"id": uint32(0)}, fourth)
+}
+
+func TestJSONSharedData(t *testing.T) {
+	col, err := Type("JSON").Column("j", nil)
+	require.NoError(t, err)
+	json := col.(*JSONColumn)
+
+	// shared data values are the binary encoded type followed by the value
+	var value proto.Buffer
+	value.PutByte(0x0A) // Int64
+	value.PutInt64(-5)
+	require.NoError(t, json.sharedData.AppendRow([]map[string]any{{"paths": "a.b", "values": string(value.Buf)}}))
+	json.rows = 1
+
+	var row chcol.JSON
+	require.NoError(t, json.ScanRow(&row, 0))
+	assert.Equal(t, map[string]any{"a.b": int64(-5)}, row.ValuesByPath())
+}
+
+func TestDecodeBinaryValue(t *testing.T) {
+	var buffer proto.Buffer
+	buffer.PutByte(0x1E) // Array
+	buffer.PutByte(0x15) // String
+	buffer.PutUVarInt(2)
+	buffer.PutString("a")
+	buffer.PutString("b")
+	typeName, value, err := decodeBinaryValue(buffer.Buf, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Array(String)", typeName)
+	assert.Equal(t, []any{"a", "b"}, value)
+}
+
+func TestJSONRowDecodeError(t *testing.T) {
+	col, err := Type("JSON").Column("j", nil)
+	require.NoError(t, err)
+	json := col.(*JSONColumn)
+
+	// an unknown binary type in the shared data can't be decoded
+	require.NoError(t, json.sharedData.AppendRow([]map[string]any{{"paths": "a", "values": "\xff"}}))
+	json.rows = 1
+
+	var row chcol.JSON
+	assert.Error(t, json.ScanRow(&row, 0))
+	assert.Nil(t, json.Row(0, false))
+}
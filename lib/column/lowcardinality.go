@@ -23,6 +23,7 @@ import (
 	"github.com/ClickHouse/ch-go/proto"
 	"math"
 	"reflect"
+	"slices"
 	"time"
 )
 
@@ -283,6 +284,25 @@ func (col *LowCardinality) keys() Interface {
 	return &col.keys64
 }
 
+// Dictionary returns the column holding the distinct values of the column. When the column is nullable, its first
+// value stands for NULL.
+func (col *LowCardinality) Dictionary() Interface {
+	return col.index
+}
+
+// Keys returns the position in Dictionary of the value of each row
+func (col *LowCardinality) Keys() []int {
+	if col.keys().Rows() == 0 {
+		// appended rows only get their keys split by width when the column is encoded
+		return slices.Clone(col.append.keys)
+	}
+	keys := make([]int, col.rows)
+	for i := range keys {
+		keys[i] = col.indexRowNum(i)
+	}
+	return keys
+}
+
 func (col *LowCardinality) indexRowNum(row int) int {
 	switch v := col.keys().Row(row, false).(type) {
 	case uint8:
@@ -315,6 +315,21 @@ func (col *Map) WriteStatePrefix(encoder *proto.Buffer) error {
 	return nil
 }
 
+// Keys returns the column holding the keys of every row, Offsets splits them into rows
+func (col *Map) Keys() Interface {
+	return col.keys
+}
+
+// Values returns the column holding the values of every row, Offsets splits them into rows
+func (col *Map) Values() Interface {
+	return col.values
+}
+
+// Offsets returns the end offset in Keys and Values of each row. The slice shares memory with the column.
+func (col *Map) Offsets() []int64 {
+	return col.offsets.Data()
+}
+
 func (col *Map) row(n int) reflect.Value {
 	var (
 		prev  int64
@@ -62,6 +62,12 @@ func (col *Nullable) Base() Interface {
 	return col.base
 }
 
+// Nulls returns 1 for the rows of the column that are NULL and 0 for the others. The slice shares memory with the
+// column.
+func (col *Nullable) Nulls() []uint8 {
+	return col.nulls
+}
+
 func (col *Nullable) Type() Type {
 	return "Nullable(" + col.base.Type() + ")"
 }
@@ -0,0 +1,151 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// https://github.com/RoaringBitmap/RoaringFormatSpec
+const (
+	roaringSerialCookieNoRunContainer = 12346
+	roaringSerialCookie               = 12347
+	roaringNoOffsetThreshold          = 4
+	roaringMaxArrayCardinality        = 4096
+)
+
+var errInvalidRoaringBitmap = errors.New("invalid roaring bitmap")
+
+// decodeRoaring decodes a 32-bit roaring bitmap in the portable format, returning its values and the size read
+func decodeRoaring(data []byte) ([]uint64, int, error) {
+	if len(data) < 4 {
+		return nil, 0, errInvalidRoaringBitmap
+	}
+	var (
+		cookie    = binary.LittleEndian.Uint32(data)
+		pos       = 4
+		size      int
+		runBitmap []byte
+	)
+	switch {
+	case cookie&0xFFFF == roaringSerialCookie:
+		size = int(cookie>>16) + 1
+		if len(data) < pos+(size+7)/8 {
+			return nil, 0, errInvalidRoaringBitmap
+		}
+		runBitmap = data[pos : pos+(size+7)/8]
+		pos += (size + 7) / 8
+	case cookie == roaringSerialCookieNoRunContainer:
+		if len(data) < 8 {
+			return nil, 0, errInvalidRoaringBitmap
+		}
+		size = int(binary.LittleEndian.Uint32(data[4:]))
+		pos += 4
+	default:
+		return nil, 0, errInvalidRoaringBitmap
+	}
+	if len(data) < pos+4*size {
+		return nil, 0, errInvalidRoaringBitmap
+	}
+	var (
+		keys          = make([]uint64, size)
+		cardinalities = make([]int, size)
+	)
+	for i := 0; i < size; i++ {
+		keys[i] = uint64(binary.LittleEndian.Uint16(data[pos:])) << 16
+		cardinalities[i] = int(binary.LittleEndian.Uint16(data[pos+2:])) + 1
+		pos += 4
+	}
+	if runBitmap == nil || size >= roaringNoOffsetThreshold {
+		pos += 4 * size
+	}
+	var values []uint64
+	for i := 0; i < size; i++ {
+		switch {
+		case runBitmap != nil && runBitmap[i/8]&(1<<(i%8)) != 0:
+			if len(data) < pos+2 {
+				return nil, 0, errInvalidRoaringBitmap
+			}
+			runs := int(binary.LittleEndian.Uint16(data[pos:]))
+			pos += 2
+			if len(data) < pos+4*runs {
+				return nil, 0, errInvalidRoaringBitmap
+			}
+			for j := 0; j < runs; j++ {
+				start := uint64(binary.LittleEndian.Uint16(data[pos:]))
+				length := uint64(binary.LittleEndian.Uint16(data[pos+2:]))
+				for v := start; v <= start+length; v++ {
+					values = append(values, keys[i]|v)
+				}
+				pos += 4
+			}
+		case cardinalities[i] > roaringMaxArrayCardinality:
+			if len(data) < pos+8192 {
+				return nil, 0, errInvalidRoaringBitmap
+			}
+			for word := 0; word < 1024; word++ {
+				bits := binary.LittleEndian.Uint64(data[pos+word*8:])
+				for bit := 0; bits != 0; bit++ {
+					if bits&1 != 0 {
+						values = append(values, keys[i]|uint64(word*64+bit))
+					}
+					bits >>= 1
+				}
+			}
+			pos += 8192
+		default:
+			if len(data) < pos+2*cardinalities[i] {
+				return nil, 0, errInvalidRoaringBitmap
+			}
+			for j := 0; j < cardinalities[i]; j++ {
+				values = append(values, keys[i]|uint64(binary.LittleEndian.Uint16(data[pos:])))
+				pos += 2
+			}
+		}
+	}
+	return values, pos, nil
+}
+
+// decodeRoaring64 decodes a Roaring64Map in the portable format: a UInt64 count of 32-bit bitmaps,
+// each preceded by the UInt32 high bits of its values.
+func decodeRoaring64(data []byte) ([]uint64, error) {
+	if len(data) < 8 {
+		return nil, errInvalidRoaringBitmap
+	}
+	var (
+		count  = binary.LittleEndian.Uint64(data)
+		pos    = 8
+		values []uint64
+	)
+	for i := uint64(0); i < count; i++ {
+		if len(data) < pos+4 {
+			return nil, errInvalidRoaringBitmap
+		}
+		high := uint64(binary.LittleEndian.Uint32(data[pos:])) << 32
+		low, n, err := decodeRoaring(data[pos+4:])
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range low {
+			values = append(values, high|v)
+		}
+		pos += 4 + n
+	}
+	return values, nil
+}
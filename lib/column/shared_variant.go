@@ -0,0 +1,123 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// SharedVariantTypeName is the member type a Dynamic column stores values in once it exceeds its max_types
+const SharedVariantTypeName = "SharedVariant"
+
+// SharedVariant holds the values of a Dynamic column that do not have their own member type.
+// Each value is the binary encoded type followed by the binary encoded value, and is exposed as raw bytes.
+type SharedVariant struct {
+	name string
+	col  proto.ColBytes
+}
+
+func (col *SharedVariant) Reset() {
+	col.col.Reset()
+}
+
+func (col *SharedVariant) Name() string {
+	return col.name
+}
+
+func (col *SharedVariant) Type() Type {
+	return SharedVariantTypeName
+}
+
+func (col *SharedVariant) ScanType() reflect.Type {
+	return scanTypeByte
+}
+
+func (col *SharedVariant) Rows() int {
+	return col.col.Rows()
+}
+
+func (col *SharedVariant) Row(i int, ptr bool) any {
+	value := col.row(i)
+	if ptr {
+		return &value
+	}
+	return value
+}
+
+func (col *SharedVariant) row(i int) []byte {
+	return append([]byte(nil), col.col.Row(i)...)
+}
+
+func (col *SharedVariant) ScanRow(dest any, row int) error {
+	switch d := dest.(type) {
+	case *[]byte:
+		*d = col.row(row)
+	default:
+		return &ColumnConverterError{
+			Op:   "ScanRow",
+			To:   fmt.Sprintf("%T", dest),
+			From: SharedVariantTypeName,
+			Hint: fmt.Sprintf("try using *%s", scanTypeByte),
+		}
+	}
+	return nil
+}
+
+func (col *SharedVariant) Append(v any) (nulls []uint8, err error) {
+	switch v := v.(type) {
+	case [][]byte:
+		nulls = make([]uint8, len(v))
+		for i := range v {
+			col.col.Append(v[i])
+		}
+	default:
+		return nil, &ColumnConverterError{
+			Op:   "Append",
+			To:   SharedVariantTypeName,
+			From: fmt.Sprintf("%T", v),
+		}
+	}
+	return
+}
+
+func (col *SharedVariant) AppendRow(v any) error {
+	switch v := v.(type) {
+	case []byte:
+		col.col.Append(v)
+	default:
+		return &ColumnConverterError{
+			Op:   "AppendRow",
+			To:   SharedVariantTypeName,
+			From: fmt.Sprintf("%T", v),
+		}
+	}
+	return nil
+}
+
+func (col *SharedVariant) Decode(reader *proto.Reader, rows int) error {
+	return col.col.DecodeColumn(reader, rows)
+}
+
+func (col *SharedVariant) Encode(buffer *proto.Buffer) {
+	col.col.EncodeColumn(buffer)
+}
+
+var _ Interface = (*SharedVariant)(nil)
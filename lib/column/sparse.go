@@ -0,0 +1,157 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// kinds of column serializations, see ISerialization::Kind
+const (
+	serializationDefault = 0
+	serializationSparse  = 1
+)
+
+// sparseEndOfGranule flags the last group of the offsets of a sparse column
+const sparseEndOfGranule = 1 << 62
+
+// Serialization describes how the server serialized a column of a block. A sparse column only holds the rows whose
+// value isn't the default of its type, the elements of a Tuple have their own serialization.
+type Serialization struct {
+	Sparse   bool
+	Elements []Serialization
+}
+
+// ReadSerialization reads the serialization kinds of a column, the kind of a Tuple is followed by the kinds of its
+// elements, see SerializationInfo::deserializeFromKindsBinary
+func ReadSerialization(reader *proto.Reader, col Interface) (s Serialization, err error) {
+	kind, err := reader.UInt8()
+	if err != nil {
+		return s, err
+	}
+	switch kind {
+	case serializationDefault:
+	case serializationSparse:
+		s.Sparse = true
+	default:
+		return s, fmt.Errorf("unsupported serialization kind %d", kind)
+	}
+	if tuple, ok := col.(*Tuple); ok {
+		s.Elements = make([]Serialization, len(tuple.columns))
+		for i, c := range tuple.columns {
+			if s.Elements[i], err = ReadSerialization(reader, c); err != nil {
+				return s, err
+			}
+		}
+	}
+	return s, nil
+}
+
+// DecodeSerialized decodes rows of a column serialized as s
+func DecodeSerialized(reader *proto.Reader, col Interface, s Serialization, rows int) error {
+	if s.Sparse {
+		return decodeSparse(reader, col, Serialization{Elements: s.Elements}, rows)
+	}
+	if tuple, ok := col.(*Tuple); ok && len(s.Elements) == len(tuple.columns) {
+		for i, c := range tuple.columns {
+			if err := DecodeSerialized(reader, c, s.Elements[i], rows); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return col.Decode(reader, rows)
+}
+
+// decodeSparse reads the offsets of the rows that aren't default, followed by their values, and appends every row to
+// col with the default value in the other rows. The default of the types that support sparse serialization is
+// encoded with zero bytes, e.g. 0, an empty String or a FixedString of zeros.
+func decodeSparse(reader *proto.Reader, col Interface, values Serialization, rows int) error {
+	offsets, err := readSparseOffsets(reader, rows)
+	if err != nil {
+		return err
+	}
+	valuesCol, err := col.Type().Column(col.Name(), time.UTC)
+	if err != nil {
+		return err
+	}
+	if len(offsets) != 0 {
+		if err := DecodeSerialized(reader, valuesCol, values, len(offsets)); err != nil {
+			return err
+		}
+	}
+	defaultCol, err := col.Type().Column(col.Name(), time.UTC)
+	if err != nil {
+		return err
+	}
+	if err := defaultCol.Decode(proto.NewReader(zeros{}), 1); err != nil {
+		return err
+	}
+	defaultValue := defaultCol.Row(0, false)
+	for row, next := 0, 0; row < rows; row++ {
+		value := defaultValue
+		if next < len(offsets) && offsets[next] == row {
+			value = valuesCol.Row(next, false)
+			next++
+		}
+		if err := col.AppendRow(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readSparseOffsets reads the rows of a sparse column that have a value, each group is the number of default rows
+// before the next value, see SerializationSparse
+func readSparseOffsets(reader *proto.Reader, rows int) ([]int, error) {
+	var (
+		offsets []int
+		start   int
+	)
+	for {
+		group, err := reader.UVarInt()
+		if err != nil {
+			return nil, err
+		}
+		end := group&sparseEndOfGranule != 0
+		start += int(group &^ sparseEndOfGranule)
+		if end {
+			break
+		}
+		if start >= rows {
+			return nil, fmt.Errorf("sparse offset %d out of %d rows", start, rows)
+		}
+		offsets = append(offsets, start)
+		start++
+	}
+	if start != rows {
+		return nil, fmt.Errorf("sparse offsets cover %d of %d rows", start, rows)
+	}
+	return offsets, nil
+}
+
+// zeros reads an endless stream of zero bytes
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
@@ -0,0 +1,122 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"testing"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeSparse writes the offsets of the values of a sparse column of the given rows, then the values
+func encodeSparse(t *testing.T, buffer *proto.Buffer, chType Type, rows int, values map[int]any) {
+	col, err := chType.Column("a", nil)
+	require.NoError(t, err)
+	start := 0
+	for row := 0; row < rows; row++ {
+		value, ok := values[row]
+		if !ok {
+			continue
+		}
+		buffer.PutUVarInt(uint64(row - start))
+		start = row + 1
+		require.NoError(t, col.AppendRow(value))
+	}
+	buffer.PutUVarInt(uint64(rows-start) | sparseEndOfGranule)
+	col.Encode(buffer)
+}
+
+func TestDecodeSparse(t *testing.T) {
+	for _, tt := range []struct {
+		chType   Type
+		values   map[int]any
+		expected []any
+	}{
+		{"UInt64", map[int]any{1: uint64(7), 4: uint64(9)}, []any{uint64(0), uint64(7), uint64(0), uint64(0), uint64(9)}},
+		{"String", map[int]any{0: "a", 4: "b"}, []any{"a", "", "", "", "b"}},
+		{"FixedString(2)", map[int]any{2: "xy"}, []any{"\x00\x00", "\x00\x00", "xy", "\x00\x00", "\x00\x00"}},
+		{"String", map[int]any{}, []any{"", "", "", "", ""}},
+	} {
+		t.Run(string(tt.chType), func(t *testing.T) {
+			var buffer proto.Buffer
+			buffer.PutUInt8(serializationSparse)
+			encodeSparse(t, &buffer, tt.chType, len(tt.expected), tt.values)
+
+			col, err := tt.chType.Column("a", nil)
+			require.NoError(t, err)
+			reader := proto.NewReader(buffer.Reader())
+			s, err := ReadSerialization(reader, col)
+			require.NoError(t, err)
+			assert.True(t, s.Sparse)
+			require.NoError(t, DecodeSerialized(reader, col, s, len(tt.expected)))
+			_, err = reader.ReadByte()
+			require.Error(t, err, "all bytes must be consumed")
+			require.Equal(t, len(tt.expected), col.Rows())
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected, col.Row(i, false))
+			}
+		})
+	}
+}
+
+func TestDecodeSparseTupleElement(t *testing.T) {
+	var buffer proto.Buffer
+	// the tuple itself, its String element and its sparse UInt64 element
+	buffer.PutUInt8(serializationDefault)
+	buffer.PutUInt8(serializationDefault)
+	buffer.PutUInt8(serializationSparse)
+	names, err := Type("String").Column("a", nil)
+	require.NoError(t, err)
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, names.AppendRow(name))
+	}
+	names.Encode(&buffer)
+	encodeSparse(t, &buffer, "UInt64", 3, map[int]any{2: uint64(5)})
+
+	col, err := Type("Tuple(String, UInt64)").Column("t", nil)
+	require.NoError(t, err)
+	reader := proto.NewReader(buffer.Reader())
+	s, err := ReadSerialization(reader, col)
+	require.NoError(t, err)
+	assert.Equal(t, Serialization{Elements: []Serialization{{}, {Sparse: true}}}, s)
+	require.NoError(t, DecodeSerialized(reader, col, s, 3))
+	assert.Equal(t, []any{"a", uint64(0)}, col.Row(0, false))
+	assert.Equal(t, []any{"c", uint64(5)}, col.Row(2, false))
+}
+
+func TestDecodeSparseErrors(t *testing.T) {
+	col, err := Type("UInt64").Column("a", nil)
+	require.NoError(t, err)
+
+	var buffer proto.Buffer
+	buffer.PutUInt8(2)
+	_, err = ReadSerialization(proto.NewReader(buffer.Reader()), col)
+	assert.Error(t, err)
+
+	// the offsets cover 2 rows of 3
+	buffer = proto.Buffer{}
+	buffer.PutUVarInt(2 | sparseEndOfGranule)
+	assert.Error(t, DecodeSerialized(proto.NewReader(buffer.Reader()), col, Serialization{Sparse: true}, 3))
+
+	// a value after the last row
+	buffer = proto.Buffer{}
+	buffer.PutUVarInt(5)
+	assert.Error(t, DecodeSerialized(proto.NewReader(buffer.Reader()), col, Serialization{Sparse: true}, 3))
+}
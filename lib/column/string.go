@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"github.com/ClickHouse/ch-go/proto"
 	"reflect"
+	"unsafe"
 
 	"github.com/ClickHouse/clickhouse-go/v2/lib/binary"
 )
@@ -53,6 +54,20 @@ func (col *String) Rows() int {
 	return col.col.Rows()
 }
 
+// Data returns the values of the column without copying them. The strings share memory with the buffer of the
+// column, so they stay valid as long as the block holding the column: rows never reuse a block returned by
+// Block, but reusing the column after Reset or handing its buffer back to a ColStrProvider pool overwrites them.
+// Copy a string, e.g. with strings.Clone, to keep it longer.
+func (col *String) Data() []string {
+	values := make([]string, len(col.col.Pos))
+	for i, p := range col.col.Pos {
+		if p.End > p.Start {
+			values[i] = unsafe.String(&col.col.Buf[p.Start], p.End-p.Start)
+		}
+	}
+	return values
+}
+
 func (col *String) Row(i int, ptr bool) any {
 	val := col.col.Row(i)
 	if ptr {
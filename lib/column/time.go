@@ -0,0 +1,270 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
+)
+
+var (
+	scanTypeDuration = reflect.TypeOf(time.Duration(0))
+	// ClickHouse Time and Time64 values are limited to ±999:59:59.999999999
+	maxTime = 999*time.Hour + 59*time.Minute + 59*time.Second + time.Second - 1
+)
+
+// Time stores a time of day or duration with second precision as Int32 seconds
+type Time struct {
+	chType Type
+	name   string
+	col    proto.ColInt32
+}
+
+func (col *Time) Reset() {
+	col.col.Reset()
+}
+
+func (col *Time) Name() string {
+	return col.name
+}
+
+func (col *Time) Type() Type {
+	return col.chType
+}
+
+func (col *Time) ScanType() reflect.Type {
+	return scanTypeDuration
+}
+
+func (col *Time) Rows() int {
+	return col.col.Rows()
+}
+
+func (col *Time) Row(i int, ptr bool) any {
+	value := col.row(i)
+	if ptr {
+		return &value
+	}
+	return value
+}
+
+func (col *Time) ScanRow(dest any, row int) error {
+	return scanTime(dest, col.row(row), 0, "Time")
+}
+
+func (col *Time) Append(v any) (nulls []uint8, err error) {
+	return appendTimes(col, v, "Time")
+}
+
+func (col *Time) AppendRow(v any) error {
+	d, err := timeValue(v, "Time")
+	if err != nil {
+		return err
+	}
+	col.col.Append(int32(d / time.Second))
+	return nil
+}
+
+func (col *Time) Decode(reader *proto.Reader, rows int) error {
+	return col.col.DecodeColumn(reader, rows)
+}
+
+func (col *Time) Encode(buffer *proto.Buffer) {
+	col.col.EncodeColumn(buffer)
+}
+
+func (col *Time) row(i int) time.Duration {
+	return time.Duration(col.col.Row(i)) * time.Second
+}
+
+// scanTime assigns a Time or Time64 value to a *time.Duration, *chcol.Time, string or sql.Null* destination.
+// sql.NullInt64 holds the duration in nanoseconds.
+func scanTime(dest any, value time.Duration, precision int, chType string) error {
+	switch d := dest.(type) {
+	case *time.Duration:
+		*d = value
+	case **time.Duration:
+		*d = new(time.Duration)
+		**d = value
+	case *chcol.Time:
+		*d = chcol.NewTime(value)
+	case **chcol.Time:
+		*d = new(chcol.Time)
+		**d = chcol.NewTime(value)
+	case *string:
+		*d = chcol.FormatDuration(value, precision)
+	case **string:
+		*d = new(string)
+		**d = chcol.FormatDuration(value, precision)
+	case *int64:
+		*d = int64(value)
+	case **int64:
+		*d = new(int64)
+		**d = int64(value)
+	case *sql.NullInt64:
+		return d.Scan(int64(value))
+	case *sql.NullString:
+		return d.Scan(chcol.FormatDuration(value, precision))
+	default:
+		if scan, ok := dest.(sql.Scanner); ok {
+			return scan.Scan(value)
+		}
+		return &ColumnConverterError{
+			Op:   "ScanRow",
+			To:   fmt.Sprintf("%T", dest),
+			From: chType,
+		}
+	}
+	return nil
+}
+
+// timeValue converts a value appended to a Time or Time64 column into a duration. Integers are durations in
+// nanoseconds and strings use the ClickHouse text format [-]HHH:MM:SS[.fffffffff]. Nil values are appended as zero.
+func timeValue(v any, chType string) (d time.Duration, err error) {
+	switch v := v.(type) {
+	case nil:
+	case time.Duration:
+		d = v
+	case *time.Duration:
+		if v != nil {
+			d = *v
+		}
+	case chcol.Time:
+		d = v.Duration()
+	case *chcol.Time:
+		if v != nil {
+			d = v.Duration()
+		}
+	case int64:
+		d = time.Duration(v)
+	case *int64:
+		if v != nil {
+			d = time.Duration(*v)
+		}
+	case sql.NullInt64:
+		if v.Valid {
+			d = time.Duration(v.Int64)
+		}
+	case *sql.NullInt64:
+		if v != nil && v.Valid {
+			d = time.Duration(v.Int64)
+		}
+	case string:
+		t, err := chcol.ParseTime(v)
+		if err != nil {
+			return 0, &ColumnConverterError{
+				Op:   "AppendRow",
+				To:   chType,
+				From: "string",
+				Hint: err.Error(),
+			}
+		}
+		d = t.Duration()
+	case *string:
+		if v != nil {
+			return timeValue(*v, chType)
+		}
+	case sql.NullString:
+		if v.Valid {
+			return timeValue(v.String, chType)
+		}
+	case *sql.NullString:
+		if v != nil && v.Valid {
+			return timeValue(v.String, chType)
+		}
+	default:
+		if valuer, ok := v.(driver.Valuer); ok {
+			val, err := valuer.Value()
+			if err != nil {
+				return 0, &ColumnConverterError{
+					Op:   "AppendRow",
+					To:   chType,
+					From: fmt.Sprintf("%T", v),
+					Hint: "could not get driver.Valuer value",
+				}
+			}
+			return timeValue(val, chType)
+		}
+		return 0, &ColumnConverterError{
+			Op:   "AppendRow",
+			To:   chType,
+			From: fmt.Sprintf("%T", v),
+		}
+	}
+	if d > maxTime || d < -maxTime {
+		return 0, &ColumnConverterError{
+			Op:   "AppendRow",
+			To:   chType,
+			From: fmt.Sprintf("%T", v),
+			Hint: fmt.Sprintf("%s is outside the range ±999:59:59", d),
+		}
+	}
+	return d, nil
+}
+
+// appendTimes appends a slice of values one by one with the AppendRow of col, marking nil pointers as NULL
+func appendTimes(col Interface, v any, chType string) (nulls []uint8, err error) {
+	switch v := v.(type) {
+	case []time.Duration:
+		nulls = make([]uint8, len(v))
+		for i := range v {
+			if err := col.AppendRow(v[i]); err != nil {
+				return nil, err
+			}
+		}
+		return nulls, nil
+	case []*time.Duration:
+		nulls = make([]uint8, len(v))
+		for i := range v {
+			if v[i] == nil {
+				nulls[i] = 1
+			}
+			if err := col.AppendRow(v[i]); err != nil {
+				return nil, err
+			}
+		}
+		return nulls, nil
+	}
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Slice {
+		return nil, &ColumnConverterError{
+			Op:   "Append",
+			To:   chType,
+			From: fmt.Sprintf("%T", v),
+		}
+	}
+	nulls = make([]uint8, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		elem := value.Index(i)
+		if elem.Kind() == reflect.Pointer && elem.IsNil() {
+			nulls[i] = 1
+		}
+		if err := col.AppendRow(elem.Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return nulls, nil
+}
+
+var _ Interface = (*Time)(nil)
@@ -0,0 +1,111 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// Time64 stores a time of day or duration as Int64 ticks of 10^-precision seconds
+type Time64 struct {
+	chType    Type
+	name      string
+	precision int
+	col       proto.ColInt64
+}
+
+func (col *Time64) Reset() {
+	col.col.Reset()
+}
+
+func (col *Time64) Name() string {
+	return col.name
+}
+
+func (col *Time64) parse(t Type) (_ *Time64, err error) {
+	col.chType = t
+	precision, err := strconv.ParseInt(t.params(), 10, 8)
+	if err != nil || precision < 0 || precision > 9 {
+		return nil, &UnsupportedColumnTypeError{
+			t: t,
+		}
+	}
+	col.precision = int(precision)
+	return col, nil
+}
+
+func (col *Time64) Type() Type {
+	return col.chType
+}
+
+func (col *Time64) ScanType() reflect.Type {
+	return scanTypeDuration
+}
+
+func (col *Time64) Rows() int {
+	return col.col.Rows()
+}
+
+func (col *Time64) Row(i int, ptr bool) any {
+	value := col.row(i)
+	if ptr {
+		return &value
+	}
+	return value
+}
+
+func (col *Time64) ScanRow(dest any, row int) error {
+	return scanTime(dest, col.row(row), col.precision, "Time64")
+}
+
+func (col *Time64) Append(v any) (nulls []uint8, err error) {
+	return appendTimes(col, v, "Time64")
+}
+
+func (col *Time64) AppendRow(v any) error {
+	d, err := timeValue(v, "Time64")
+	if err != nil {
+		return err
+	}
+	col.col.Append(int64(d) / col.tick())
+	return nil
+}
+
+func (col *Time64) Decode(reader *proto.Reader, rows int) error {
+	return col.col.DecodeColumn(reader, rows)
+}
+
+func (col *Time64) Encode(buffer *proto.Buffer) {
+	col.col.EncodeColumn(buffer)
+}
+
+func (col *Time64) row(i int) time.Duration {
+	return time.Duration(col.col.Row(i) * col.tick())
+}
+
+// tick is the number of nanoseconds in one unit of the column precision
+func (col *Time64) tick() int64 {
+	return int64(math.Pow10(9 - col.precision))
+}
+
+var _ Interface = (*Time64)(nil)
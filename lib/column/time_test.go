@@ -0,0 +1,90 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeEncodeDecode(t *testing.T) {
+	d := 12*time.Hour + 34*time.Minute + 56*time.Second + 789123456*time.Nanosecond
+	tests := []struct {
+		chType   Type
+		expected time.Duration
+		text     string
+	}{
+		{chType: "Time", expected: d.Truncate(time.Second), text: "12:34:56"},
+		{chType: "Time64(3)", expected: d.Truncate(time.Millisecond), text: "12:34:56.789"},
+		{chType: "Time64(9)", expected: d, text: "12:34:56.789123456"},
+	}
+	for _, test := range tests {
+		t.Run(string(test.chType), func(t *testing.T) {
+			col, err := test.chType.Column("t", nil)
+			require.NoError(t, err)
+			require.NoError(t, col.AppendRow(d))
+			require.NoError(t, col.AppendRow(chcol.NewTime(-d)))
+			require.NoError(t, col.AppendRow("12:34:56.789123456"))
+			require.NoError(t, col.AppendRow(sql.NullInt64{}))
+			_, err = col.Append([]*time.Duration{&d, nil})
+			require.NoError(t, err)
+			require.Error(t, col.AppendRow(1000*time.Hour))
+
+			var buffer proto.Buffer
+			col.Encode(&buffer)
+			decoded, err := test.chType.Column("t", nil)
+			require.NoError(t, err)
+			require.NoError(t, decoded.Decode(proto.NewReader(buffer.Reader()), 6))
+
+			assert.Equal(t, test.expected, decoded.Row(0, false))
+			assert.Equal(t, -test.expected, decoded.Row(1, false))
+			assert.Equal(t, test.expected, decoded.Row(2, false))
+			assert.Equal(t, time.Duration(0), decoded.Row(3, false))
+			assert.Equal(t, test.expected, decoded.Row(4, false))
+
+			var (
+				civil chcol.Time
+				text  string
+				null  sql.NullInt64
+			)
+			require.NoError(t, decoded.ScanRow(&civil, 0))
+			assert.Equal(t, test.expected, civil.Duration())
+			require.NoError(t, decoded.ScanRow(&text, 0))
+			assert.Equal(t, test.text, text)
+			require.NoError(t, decoded.ScanRow(&null, 0))
+			assert.Equal(t, sql.NullInt64{Int64: int64(test.expected), Valid: true}, null)
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	value, err := chcol.ParseTime("-100:01:02.5")
+	require.NoError(t, err)
+	assert.Equal(t, chcol.Time{Negative: true, Hour: 100, Minute: 1, Second: 2, Nanosecond: 500000000}, value)
+	assert.Equal(t, "-100:01:02.5", value.String())
+	_, err = chcol.ParseTime("01:60:00")
+	require.Error(t, err)
+	_, err = Type("Time64(10)").Column("t", nil)
+	require.Error(t, err)
+}
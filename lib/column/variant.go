@@ -0,0 +1,445 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
+)
+
+const (
+	// SupportedVariantSerializationVersion is the discriminators mode used by the native protocol (basic mode)
+	SupportedVariantSerializationVersion = 0
+	// NullVariantDiscriminator marks a NULL row in a Variant column
+	NullVariantDiscriminator uint8 = 255
+)
+
+var scanTypeVariant = reflect.TypeOf(chcol.Variant{})
+
+// https://github.com/ClickHouse/ClickHouse/blob/master/src/Columns/ColumnVariant.h
+type Variant struct {
+	chType Type
+	name   string
+	tz     *time.Location
+
+	discriminators proto.ColUInt8
+	// offsets maps each row to its position in the member column selected by its discriminator
+	offsets []int
+
+	columns   []Interface
+	types     []string
+	typeIndex map[string]uint8
+	// scratch columns used to check which member types accept an untyped value
+	trial []Interface
+}
+
+func (col *Variant) Reset() {
+	col.discriminators.Reset()
+	col.offsets = col.offsets[:0]
+	for i := range col.columns {
+		col.columns[i].Reset()
+	}
+}
+
+func (col *Variant) Name() string {
+	return col.name
+}
+
+func (col *Variant) parse(t Type, tz *time.Location) (_ *Variant, err error) {
+	col.chType = t
+	if err := col.init(splitTypeParams(t.params()), tz); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+// init creates the member columns in discriminator order. ClickHouse orders variant members by type name,
+// which is the order the server reports them in.
+func (col *Variant) init(types []string, tz *time.Location) error {
+	if len(types) == 0 || len(types) >= int(NullVariantDiscriminator) {
+		return &UnsupportedColumnTypeError{
+			t: col.chType,
+		}
+	}
+	col.tz = tz
+	col.types = types
+	col.columns = make([]Interface, 0, len(types))
+	col.typeIndex = make(map[string]uint8, len(types))
+	col.trial = make([]Interface, len(types))
+	for i, typeName := range types {
+		column, err := Type(typeName).Column(col.name, tz)
+		if err != nil {
+			return err
+		}
+		col.columns = append(col.columns, column)
+		col.typeIndex[typeName] = uint8(i)
+		col.typeIndex[string(column.Type())] = uint8(i)
+	}
+	return nil
+}
+
+// addType adds a member column for typeName and returns its discriminator
+func (col *Variant) addType(typeName string) (uint8, error) {
+	if len(col.types) >= int(NullVariantDiscriminator)-1 {
+		return 0, &Error{
+			ColumnType: string(col.chType),
+			Err:        fmt.Errorf("too many types, cannot add %s", typeName),
+		}
+	}
+	column, err := Type(typeName).Column(col.name, col.tz)
+	if err != nil {
+		return 0, err
+	}
+	discriminator := uint8(len(col.columns))
+	col.columns = append(col.columns, column)
+	col.types = append(col.types, typeName)
+	col.trial = append(col.trial, nil)
+	col.typeIndex[typeName] = discriminator
+	col.typeIndex[string(column.Type())] = discriminator
+	return discriminator, nil
+}
+
+// sortTypes reorders the member columns by type name, which is the order ClickHouse assigns discriminators in,
+// and rewrites the discriminators of the appended rows to match
+func (col *Variant) sortTypes() {
+	if sort.StringsAreSorted(col.types) {
+		return
+	}
+	order := make([]int, len(col.types))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return col.types[order[i]] < col.types[order[j]]
+	})
+	var (
+		remap   = make([]uint8, len(order))
+		columns = make([]Interface, len(order))
+		types   = make([]string, len(order))
+		trial   = make([]Interface, len(order))
+	)
+	col.typeIndex = make(map[string]uint8, len(order))
+	for i, from := range order {
+		remap[from] = uint8(i)
+		columns[i], types[i], trial[i] = col.columns[from], col.types[from], col.trial[from]
+		col.typeIndex[types[i]] = uint8(i)
+		col.typeIndex[string(columns[i].Type())] = uint8(i)
+	}
+	col.columns, col.types, col.trial = columns, types, trial
+	for i, discriminator := range col.discriminators {
+		if discriminator != NullVariantDiscriminator {
+			col.discriminators[i] = remap[discriminator]
+		}
+	}
+}
+
+func (col *Variant) Type() Type {
+	return col.chType
+}
+
+func (col *Variant) ScanType() reflect.Type {
+	return scanTypeVariant
+}
+
+func (col *Variant) Rows() int {
+	return col.discriminators.Rows()
+}
+
+func (col *Variant) Row(i int, ptr bool) any {
+	value := col.row(i)
+	if ptr {
+		return &value
+	}
+	return value
+}
+
+func (col *Variant) row(i int) chcol.Variant {
+	discriminator := col.discriminators.Row(i)
+	if discriminator == NullVariantDiscriminator {
+		return chcol.Variant{}
+	}
+	return chcol.NewVariantWithType(col.columns[discriminator].Row(col.offsets[i], false), col.types[discriminator])
+}
+
+func (col *Variant) ScanRow(dest any, row int) error {
+	switch d := dest.(type) {
+	case *chcol.Variant:
+		*d = col.row(row)
+		return nil
+	case **chcol.Variant:
+		*d = new(chcol.Variant)
+		**d = col.row(row)
+		return nil
+	}
+	discriminator := col.discriminators.Row(row)
+	if discriminator == NullVariantDiscriminator {
+		if scan, ok := dest.(sql.Scanner); ok {
+			return scan.Scan(nil)
+		}
+		if value := reflect.ValueOf(dest); value.Kind() == reflect.Pointer && !value.IsNil() {
+			value.Elem().Set(reflect.Zero(value.Elem().Type()))
+		}
+		return nil
+	}
+	return col.columns[discriminator].ScanRow(dest, col.offsets[row])
+}
+
+func (col *Variant) Append(v any) (nulls []uint8, err error) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Slice {
+		return nil, &ColumnConverterError{
+			Op:   "Append",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+			Hint: "value must be a slice",
+		}
+	}
+	for i := 0; i < value.Len(); i++ {
+		if err := col.AppendRow(value.Index(i).Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func (col *Variant) AppendRow(v any) error {
+	var typeName string
+	switch value := v.(type) {
+	case chcol.Variant:
+		v, typeName = value.Any(), value.Type()
+	case *chcol.Variant:
+		if value != nil {
+			v, typeName = value.Any(), value.Type()
+		} else {
+			v = nil
+		}
+	}
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		col.appendNull()
+		return nil
+	}
+	if typeName != "" {
+		discriminator, ok := col.typeIndex[typeName]
+		if !ok {
+			return &ColumnConverterError{
+				Op:   "AppendRow",
+				To:   string(col.chType),
+				From: fmt.Sprintf("%T", v),
+				Hint: fmt.Sprintf("type %s is not a member of the variant", typeName),
+			}
+		}
+		return col.appendTo(discriminator, v)
+	}
+	discriminator, err := col.resolve(v)
+	if err != nil {
+		return err
+	}
+	return col.appendTo(discriminator, v)
+}
+
+func (col *Variant) appendNull() {
+	col.discriminators.Append(NullVariantDiscriminator)
+	col.offsets = append(col.offsets, 0)
+}
+
+func (col *Variant) appendTo(discriminator uint8, v any) error {
+	column := col.columns[discriminator]
+	offset := column.Rows()
+	if err := column.AppendRow(v); err != nil {
+		return err
+	}
+	col.discriminators.Append(discriminator)
+	col.offsets = append(col.offsets, offset)
+	return nil
+}
+
+// resolve picks the single member type that accepts v. A member whose scan type matches the Go type of v
+// exactly wins, otherwise every member is tried and exactly one of them must accept the value.
+func (col *Variant) resolve(v any) (uint8, error) {
+	var (
+		candidates []uint8
+		t          = reflect.TypeOf(v)
+	)
+	for i, column := range col.columns {
+		if st := column.ScanType(); st == t || (t.Kind() == reflect.Pointer && st == t.Elem()) {
+			candidates = append(candidates, uint8(i))
+		}
+	}
+	if len(candidates) == 0 {
+		for i := range col.columns {
+			trial, err := col.trialColumn(i)
+			if err != nil {
+				return 0, err
+			}
+			if err := trial.AppendRow(v); err == nil {
+				candidates = append(candidates, uint8(i))
+			}
+			trial.Reset()
+		}
+	}
+	switch len(candidates) {
+	case 1:
+		return candidates[0], nil
+	case 0:
+		return 0, &ColumnConverterError{
+			Op:   "AppendRow",
+			To:   string(col.chType),
+			From: fmt.Sprintf("%T", v),
+		}
+	}
+	names := make([]string, 0, len(candidates))
+	for _, i := range candidates {
+		names = append(names, col.types[i])
+	}
+	return 0, &ColumnConverterError{
+		Op:   "AppendRow",
+		To:   string(col.chType),
+		From: fmt.Sprintf("%T", v),
+		Hint: fmt.Sprintf("value matches multiple variant types (%s), use chcol.NewVariantWithType to choose one", strings.Join(names, ", ")),
+	}
+}
+
+func (col *Variant) trialColumn(i int) (Interface, error) {
+	if col.trial[i] == nil {
+		column, err := Type(col.types[i]).Column(col.name, col.tz)
+		if err != nil {
+			return nil, err
+		}
+		col.trial[i] = column
+	}
+	return col.trial[i], nil
+}
+
+func (col *Variant) Decode(reader *proto.Reader, rows int) error {
+	if err := col.discriminators.DecodeColumn(reader, rows); err != nil {
+		return err
+	}
+	counts := make([]int, len(col.columns))
+	col.offsets = make([]int, rows)
+	for i, discriminator := range col.discriminators {
+		if discriminator == NullVariantDiscriminator {
+			continue
+		}
+		if int(discriminator) >= len(col.columns) {
+			return &Error{
+				ColumnType: string(col.chType),
+				Err:        fmt.Errorf("invalid discriminator %d", discriminator),
+			}
+		}
+		col.offsets[i] = counts[discriminator]
+		counts[discriminator]++
+	}
+	for i, column := range col.columns {
+		// members without rows in this block are not present in the stream
+		if counts[i] == 0 {
+			continue
+		}
+		if err := column.Decode(reader, counts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (col *Variant) Encode(buffer *proto.Buffer) {
+	col.discriminators.EncodeColumn(buffer)
+	for _, column := range col.columns {
+		if column.Rows() == 0 {
+			continue
+		}
+		column.Encode(buffer)
+	}
+}
+
+func (col *Variant) ReadStatePrefix(reader *proto.Reader) error {
+	mode, err := reader.UInt64()
+	if err != nil {
+		return err
+	}
+	if mode != SupportedVariantSerializationVersion {
+		return &Error{
+			ColumnType: string(col.chType),
+			Err:        errors.New(fmt.Sprintf("unsupported discriminators serialization mode %d", mode)),
+		}
+	}
+	for _, column := range col.columns {
+		if serialize, ok := column.(CustomSerialization); ok {
+			if err := serialize.ReadStatePrefix(reader); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (col *Variant) WriteStatePrefix(buffer *proto.Buffer) error {
+	buffer.PutUInt64(SupportedVariantSerializationVersion)
+	for _, column := range col.columns {
+		if serialize, ok := column.(CustomSerialization); ok {
+			if err := serialize.WriteStatePrefix(buffer); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// splitTypeParams splits a comma separated list of types, ignoring commas nested in brackets or quotes
+func splitTypeParams(params string) []string {
+	var (
+		types    []string
+		start    int
+		brackets int
+		quoted   bool
+	)
+	for i := 0; i < len(params); i++ {
+		switch c := params[i]; {
+		case c == '\\' && quoted:
+			i++
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			brackets++
+		case c == ')':
+			brackets--
+		case c == ',' && brackets == 0:
+			if typeName := strings.TrimSpace(params[start:i]); typeName != "" {
+				types = append(types, typeName)
+			}
+			start = i + 1
+		}
+	}
+	if typeName := strings.TrimSpace(params[start:]); typeName != "" {
+		types = append(types, typeName)
+	}
+	return types
+}
+
+var (
+	_ Interface           = (*Variant)(nil)
+	_ CustomSerialization = (*Variant)(nil)
+)
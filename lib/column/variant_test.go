@@ -0,0 +1,97 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package column
+
+import (
+	"testing"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTypeParams(t *testing.T) {
+	tests := []struct {
+		params   string
+		expected []string
+	}{
+		{params: "Int64, String", expected: []string{"Int64", "String"}},
+		{params: "Array(Map(String, Int64)), Tuple(a Int64, b String)", expected: []string{"Array(Map(String, Int64))", "Tuple(a Int64, b String)"}},
+		{params: "Enum8('a,b' = 1, 'c)' = 2), UInt8", expected: []string{"Enum8('a,b' = 1, 'c)' = 2)", "UInt8"}},
+		{params: "", expected: nil},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, splitTypeParams(test.params), test.params)
+	}
+}
+
+func TestVariantEncodeDecode(t *testing.T) {
+	col, err := Type("Variant(Array(String), Int64, String)").Column("v", nil)
+	require.NoError(t, err)
+	variant := col.(*Variant)
+
+	require.NoError(t, variant.AppendRow(int64(42)))
+	require.NoError(t, variant.AppendRow("hello"))
+	require.NoError(t, variant.AppendRow(nil))
+	require.NoError(t, variant.AppendRow([]string{"a", "b"}))
+	require.NoError(t, variant.AppendRow(chcol.NewVariantWithType("world", "String")))
+	require.NoError(t, variant.AppendRow(7))
+	require.Equal(t, 6, variant.Rows())
+
+	var buffer proto.Buffer
+	require.NoError(t, variant.WriteStatePrefix(&buffer))
+	variant.Encode(&buffer)
+
+	decoded, err := Type("Variant(Array(String), Int64, String)").Column("v", nil)
+	require.NoError(t, err)
+	reader := proto.NewReader(buffer.Reader())
+	require.NoError(t, decoded.(CustomSerialization).ReadStatePrefix(reader))
+	require.NoError(t, decoded.Decode(reader, 6))
+
+	var v chcol.Variant
+	require.NoError(t, decoded.ScanRow(&v, 0))
+	assert.Equal(t, int64(42), v.Any())
+	assert.Equal(t, "Int64", v.Type())
+	require.NoError(t, decoded.ScanRow(&v, 2))
+	assert.True(t, v.Nil())
+	require.NoError(t, decoded.ScanRow(&v, 3))
+	assert.Equal(t, []string{"a", "b"}, v.Any())
+	assert.Equal(t, "Array(String)", v.Type())
+
+	var s string
+	require.NoError(t, decoded.ScanRow(&s, 4))
+	assert.Equal(t, "world", s)
+	var i *int64
+	require.NoError(t, decoded.ScanRow(&i, 5))
+	require.NotNil(t, i)
+	assert.Equal(t, int64(7), *i)
+	require.NoError(t, decoded.ScanRow(&i, 2))
+	assert.Nil(t, i)
+}
+
+func TestVariantAppendAmbiguous(t *testing.T) {
+	col, err := Type("Variant(Float64, Int64)").Column("v", nil)
+	require.NoError(t, err)
+	require.Error(t, col.AppendRow(1))
+	require.NoError(t, col.AppendRow(chcol.NewVariantWithType(1, "Int64")))
+	require.NoError(t, col.AppendRow(1.5))
+	require.Error(t, col.AppendRow(chcol.NewVariantWithType(1, "String")))
+	require.Error(t, col.AppendRow(struct{}{}))
+	assert.Equal(t, 2, col.Rows())
+}
@@ -19,6 +19,7 @@ package driver
 
 import (
 	"context"
+	"io"
 	"reflect"
 	"time"
 
@@ -28,12 +29,21 @@ import (
 
 type ServerVersion = proto.ServerHandshake
 
+// TableColumn describes a column of the table a batch inserts into, with its default expression
+type TableColumn = proto.TableColumn
+
 type (
 	NamedValue struct {
 		Name  string
 		Value any
 	}
 
+	// NamedParam is a server side query parameter, its value is serialized for the type of the {name:Type} placeholder
+	NamedParam struct {
+		Name  string
+		Value any
+	}
+
 	NamedDateValue struct {
 		Name  string
 		Value time.Time
@@ -45,6 +55,35 @@ type (
 		MaxIdleConns int
 		Open         int
 		Idle         int
+
+		WaitCount       int64         // acquires that had to wait for a free connection slot
+		WaitDuration    time.Duration // total time spent waiting for a free connection slot
+		AcquireTimeouts int64         // acquires that failed with ErrAcquireConnTimeout
+
+		DialCount    int64            // connection attempts, including failed ones
+		DialFailures map[string]int64 // failed connection attempts by address
+
+		BadClosed         int64 // idle connections closed because they failed the health check
+		ErrorClosed       int64 // connections closed because they were released after an error
+		MaxLifetimeClosed int64 // connections closed because they exceeded ConnMaxLifetime
+		MaxIdleClosed     int64 // connections closed because the idle pool was full
+		UnhealthyClosed   int64 // connections closed because the load balancer ejected their host
+	}
+
+	// QueryStats describes the work the server did for a query. Elapsed is the time reported by the server, or the
+	// time measured by the client when the server doesn't report it. ProfileEvents sums the increments of the
+	// query level profile events by name and keeps the last value of gauges, they are only sent over the native
+	// protocol. Over HTTP the statistics come from the X-ClickHouse-Summary header, which is sent before the result,
+	// so they only cover the whole of a query that returns rows with the wait_end_of_query setting.
+	QueryStats struct {
+		ReadRows      uint64
+		ReadBytes     uint64
+		WrittenRows   uint64
+		WrittenBytes  uint64
+		ResultRows    uint64
+		ResultBytes   uint64
+		Elapsed       time.Duration
+		ProfileEvents map[string]int64
 	}
 )
 
@@ -99,3 +138,47 @@ type (
 		DatabaseTypeName() string
 	}
 )
+
+// The interfaces below extend Conn, Rows and Batch with capabilities added after them. The Conn, Rows and Batch of
+// this driver implement them, check for them with a type assertion. Keeping them apart leaves other
+// implementations of Conn, Rows and Batch, e.g. mocks, compiling.
+type (
+	// StatsExecer is implemented by a Conn that returns the statistics of a query
+	StatsExecer interface {
+		// ExecWithStats is Exec returning the statistics of the query
+		ExecWithStats(ctx context.Context, query string, args ...any) (QueryStats, error)
+	}
+	// RawInserter inserts pre-formatted data, e.g. InsertFrom(ctx, "INSERT INTO t FORMAT CSV", file)
+	RawInserter interface {
+		InsertFrom(ctx context.Context, query string, reader io.Reader) error
+	}
+	// BlockRows is implemented by Rows that can be read a block at a time
+	BlockRows interface {
+		// NextBlock moves to the next block of the result that has rows
+		NextBlock() bool
+		// Block returns the block the last call to Next or NextBlock moved to
+		Block() *proto.Block
+	}
+	// ExtremesRows is implemented by Rows that read the extremes of a query run with the extremes setting
+	ExtremesRows interface {
+		Extremes(dest ...any) error
+	}
+	// StatsRows is implemented by Rows that return the statistics of their query
+	StatsRows interface {
+		// Stats returns the statistics of the query, they are complete once all rows were read
+		Stats() QueryStats
+	}
+	// StatsBatch is implemented by a Batch that returns the statistics of its insert
+	StatsBatch interface {
+		// SendWithStats is Send returning the statistics of the insert
+		SendWithStats() (QueryStats, error)
+	}
+	// TableColumnsBatch is implemented by a Batch that knows the columns of its table and their defaults
+	TableColumnsBatch interface {
+		// TableColumns describes every column of the table, it's empty when the server didn't send the
+		// description, e.g. with input_format_defaults_for_omitted_fields = 0
+		TableColumns() []TableColumn
+		// DefaultedColumns lists the stored columns the batch doesn't write, the server fills them with their defaults
+		DefaultedColumns() []string
+	}
+)
@@ -3,6 +3,7 @@ package driver
 type PrepareBatchOptions struct {
 	ReleaseConnection bool
 	CloseOnFlush      bool
+	OmitDefaulted     bool
 }
 
 type PrepareBatchOption func(options *PrepareBatchOptions)
@@ -19,3 +20,11 @@ func WithCloseOnFlush() PrepareBatchOption {
 		options.CloseOnFlush = true
 	}
 }
+
+// WithOmitDefaultedColumns lets AppendStruct leave the columns the struct has no field for out of the INSERT,
+// when every one of them has a default expression, so the server fills them. It only applies to an empty batch.
+func WithOmitDefaultedColumns() PrepareBatchOption {
+	return func(options *PrepareBatchOptions) {
+		options.OmitDefaulted = true
+	}
+}
@@ -219,15 +219,19 @@ func (b *Block) Decode(reader *proto.Reader, revision uint64) (err error) {
 			return err
 		}
 
+		var serialization column.Serialization
 		if revision >= DBMS_MIN_REVISION_WITH_CUSTOM_SERIALIZATION {
 			hasCustom, err := reader.Bool()
 			if err != nil {
 				return err
 			}
 			if hasCustom {
-				return &BlockError{
-					Op:  "Decode",
-					Err: errors.New(fmt.Sprintf("custom serialization for column %s. not supported by clickhouse-go driver", columnName)),
+				if serialization, err = column.ReadSerialization(reader, c); err != nil {
+					return &BlockError{
+						Op:         "Decode",
+						Err:        err,
+						ColumnName: columnName,
+					}
 				}
 			}
 		}
@@ -242,7 +246,7 @@ func (b *Block) Decode(reader *proto.Reader, revision uint64) (err error) {
 					}
 				}
 			}
-			if err := c.Decode(reader, int(numRows)); err != nil {
+			if err := column.DecodeSerialized(reader, c, serialization, int(numRows)); err != nil {
 				return &BlockError{
 					Op:         "Decode",
 					Err:        err,
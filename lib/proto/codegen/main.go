@@ -0,0 +1,106 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var (
+	//go:embed error_codes.txt
+	errorCodesSrc string
+	//go:embed error_codes.tpl
+	errorCodesTpl string
+)
+
+type errorCode struct {
+	Code   int32
+	Name   string
+	GoName string
+}
+
+func parseErrorCodes(src string) ([]errorCode, error) {
+	var (
+		codes   []errorCode
+		scanner = bufio.NewScanner(strings.NewReader(src))
+	)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		code, name, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid error code line %q", line)
+		}
+		value, err := strconv.ParseInt(code, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid error code line %q: %w", line, err)
+		}
+		codes = append(codes, errorCode{
+			Code:   int32(value),
+			Name:   name,
+			GoName: "Err" + camelCase(name),
+		})
+	}
+	return codes, scanner.Err()
+}
+
+// camelCase turns an upper snake case name, e.g. TOO_MANY_PARTS, into TooManyParts
+func camelCase(name string) string {
+	var out strings.Builder
+	for _, part := range strings.Split(strings.ToLower(name), "_") {
+		if part == "" {
+			continue
+		}
+		out.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return out.String()
+}
+
+func main() {
+	codes, err := parseErrorCodes(errorCodesSrc)
+	if err != nil {
+		log.Fatal(err)
+	}
+	out := new(bytes.Buffer)
+	if err := template.Must(template.New("error_codes").Parse(errorCodesTpl)).Execute(out, codes); err != nil {
+		log.Fatal(err)
+	}
+	data, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(cwd, "lib/proto/error_codes_gen.go"), data, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
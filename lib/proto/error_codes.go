@@ -0,0 +1,38 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package proto
+
+import "fmt"
+
+// ErrorCode is a ClickHouse server error code, as listed in src/Common/ErrorCodes.cpp. Error codes are
+// sentinel errors matched by errors.Is against an *Exception with the same code:
+//
+//	errors.Is(err, proto.ErrTooManyParts)
+type ErrorCode int32
+
+// String returns the name of the code, e.g. TOO_MANY_PARTS
+func (c ErrorCode) String() string {
+	if name, ok := errorCodeNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN_ERROR_CODE_%d", int32(c))
+}
+
+func (c ErrorCode) Error() string {
+	return fmt.Sprintf("code: %d, %s", int32(c), c.String())
+}
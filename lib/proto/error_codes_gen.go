@@ -0,0 +1,781 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by make codegen DO NOT EDIT.
+// source: lib/proto/codegen/error_codes.txt
+
+package proto
+
+const (
+	ErrUnsupportedMethod                            ErrorCode = 1
+	ErrUnsupportedParameter                         ErrorCode = 2
+	ErrUnexpectedEndOfFile                          ErrorCode = 3
+	ErrExpectedEndOfFile                            ErrorCode = 4
+	ErrCannotParseText                              ErrorCode = 6
+	ErrIncorrectNumberOfColumns                     ErrorCode = 7
+	ErrThereIsNoColumn                              ErrorCode = 8
+	ErrSizesOfColumnsDoesntMatch                    ErrorCode = 9
+	ErrNotFoundColumnInBlock                        ErrorCode = 10
+	ErrPositionOutOfBound                           ErrorCode = 11
+	ErrParameterOutOfBound                          ErrorCode = 12
+	ErrSizesOfColumnsInTupleDoesntMatch             ErrorCode = 13
+	ErrDuplicateColumn                              ErrorCode = 15
+	ErrNoSuchColumnInTable                          ErrorCode = 16
+	ErrDelimiterInStringLiteralDoesntMatch          ErrorCode = 17
+	ErrCannotInsertElementIntoConstantColumn        ErrorCode = 18
+	ErrSizeOfFixedStringDoesntMatch                 ErrorCode = 19
+	ErrNumberOfColumnsDoesntMatch                   ErrorCode = 20
+	ErrCannotReadAllDataFromTabSeparatedInput       ErrorCode = 21
+	ErrCannotParseAllValueFromTabSeparatedInput     ErrorCode = 22
+	ErrCannotReadFromIstream                        ErrorCode = 23
+	ErrCannotWriteToOstream                         ErrorCode = 24
+	ErrCannotParseEscapeSequence                    ErrorCode = 25
+	ErrCannotParseQuotedString                      ErrorCode = 26
+	ErrCannotParseInputAssertionFailed              ErrorCode = 27
+	ErrCannotPrintFloatOrDoubleNumber               ErrorCode = 28
+	ErrCannotPrintInteger                           ErrorCode = 29
+	ErrCannotReadSizeOfCompressedChunk              ErrorCode = 30
+	ErrCannotReadCompressedChunk                    ErrorCode = 31
+	ErrAttemptToReadAfterEof                        ErrorCode = 32
+	ErrCannotReadAllData                            ErrorCode = 33
+	ErrTooManyArgumentsForFunction                  ErrorCode = 34
+	ErrTooFewArgumentsForFunction                   ErrorCode = 35
+	ErrBadArguments                                 ErrorCode = 36
+	ErrUnknownElementInAst                          ErrorCode = 37
+	ErrCannotParseDate                              ErrorCode = 38
+	ErrTooLargeSizeCompressed                       ErrorCode = 39
+	ErrChecksumDoesntMatch                          ErrorCode = 40
+	ErrCannotParseDatetime                          ErrorCode = 41
+	ErrNumberOfArgumentsDoesntMatch                 ErrorCode = 42
+	ErrIllegalTypeOfArgument                        ErrorCode = 43
+	ErrIllegalColumn                                ErrorCode = 44
+	ErrIllegalNumberOfResultColumns                 ErrorCode = 45
+	ErrUnknownFunction                              ErrorCode = 46
+	ErrUnknownIdentifier                            ErrorCode = 47
+	ErrNotImplemented                               ErrorCode = 48
+	ErrLogicalError                                 ErrorCode = 49
+	ErrUnknownType                                  ErrorCode = 50
+	ErrEmptyListOfColumnsQueried                    ErrorCode = 51
+	ErrColumnQueriedMoreThanOnce                    ErrorCode = 52
+	ErrTypeMismatch                                 ErrorCode = 53
+	ErrStorageDoesntAllowParameters                 ErrorCode = 54
+	ErrStorageRequiresParameter                     ErrorCode = 55
+	ErrUnknownStorage                               ErrorCode = 56
+	ErrTableAlreadyExists                           ErrorCode = 57
+	ErrTableMetadataAlreadyExists                   ErrorCode = 58
+	ErrIllegalTypeOfColumnForFilter                 ErrorCode = 59
+	ErrUnknownTable                                 ErrorCode = 60
+	ErrOnlyFilterColumnInBlock                      ErrorCode = 61
+	ErrSyntaxError                                  ErrorCode = 62
+	ErrUnknownAggregateFunction                     ErrorCode = 63
+	ErrCannotReadAggregateFunctionFromText          ErrorCode = 64
+	ErrCannotWriteAggregateFunctionAsText           ErrorCode = 65
+	ErrNotAColumn                                   ErrorCode = 66
+	ErrIllegalKeyOfAggregation                      ErrorCode = 67
+	ErrCannotGetSizeOfField                         ErrorCode = 68
+	ErrArgumentOutOfBound                           ErrorCode = 69
+	ErrCannotConvertType                            ErrorCode = 70
+	ErrCannotWriteAfterEndOfBuffer                  ErrorCode = 71
+	ErrCannotParseNumber                            ErrorCode = 72
+	ErrUnknownFormat                                ErrorCode = 73
+	ErrCannotReadFromFileDescriptor                 ErrorCode = 74
+	ErrCannotWriteToFileDescriptor                  ErrorCode = 75
+	ErrCannotOpenFile                               ErrorCode = 76
+	ErrCannotCloseFile                              ErrorCode = 77
+	ErrUnknownTypeOfQuery                           ErrorCode = 78
+	ErrIncorrectFileName                            ErrorCode = 79
+	ErrIncorrectQuery                               ErrorCode = 80
+	ErrUnknownDatabase                              ErrorCode = 81
+	ErrDatabaseAlreadyExists                        ErrorCode = 82
+	ErrDirectoryDoesntExist                         ErrorCode = 83
+	ErrDirectoryAlreadyExists                       ErrorCode = 84
+	ErrFormatIsNotSuitableForInput                  ErrorCode = 85
+	ErrReceivedErrorFromRemoteIoServer              ErrorCode = 86
+	ErrCannotSeekThroughFile                        ErrorCode = 87
+	ErrCannotTruncateFile                           ErrorCode = 88
+	ErrUnknownCompressionMethod                     ErrorCode = 89
+	ErrEmptyListOfColumnsPassed                     ErrorCode = 90
+	ErrSizesOfMarksFilesAreInconsistent             ErrorCode = 91
+	ErrEmptyDataPassed                              ErrorCode = 92
+	ErrUnknownAggregatedDataVariant                 ErrorCode = 93
+	ErrCannotMergeDifferentAggregatedDataVariants   ErrorCode = 94
+	ErrCannotReadFromSocket                         ErrorCode = 95
+	ErrCannotWriteToSocket                          ErrorCode = 96
+	ErrCannotReadAllDataFromChunkedInput            ErrorCode = 97
+	ErrCannotWriteToEmptyBlockOutputStream          ErrorCode = 98
+	ErrUnknownPacketFromClient                      ErrorCode = 99
+	ErrUnknownPacketFromServer                      ErrorCode = 100
+	ErrUnexpectedPacketFromClient                   ErrorCode = 101
+	ErrUnexpectedPacketFromServer                   ErrorCode = 102
+	ErrReceivedDataForWrongQueryId                  ErrorCode = 103
+	ErrTooSmallBufferSize                           ErrorCode = 104
+	ErrCannotReadHistory                            ErrorCode = 105
+	ErrCannotAppendHistory                          ErrorCode = 106
+	ErrFileDoesntExist                              ErrorCode = 107
+	ErrNoDataToInsert                               ErrorCode = 108
+	ErrCannotBlockSignal                            ErrorCode = 109
+	ErrCannotUnblockSignal                          ErrorCode = 110
+	ErrCannotManipulateSigset                       ErrorCode = 111
+	ErrCannotWaitForSignal                          ErrorCode = 112
+	ErrThereIsNoSession                             ErrorCode = 113
+	ErrCannotClockGettime                           ErrorCode = 114
+	ErrUnknownSetting                               ErrorCode = 115
+	ErrThereIsNoDefaultValue                        ErrorCode = 116
+	ErrIncorrectData                                ErrorCode = 117
+	ErrEngineRequired                               ErrorCode = 119
+	ErrCannotInsertValueOfDifferentSizeIntoTuple    ErrorCode = 120
+	ErrUnknownSetDataVariant                        ErrorCode = 121
+	ErrIncompatibleColumns                          ErrorCode = 122
+	ErrUnknownTypeOfAstNode                         ErrorCode = 123
+	ErrIncorrectElementOfSet                        ErrorCode = 124
+	ErrIncorrectResultOfScalarSubquery              ErrorCode = 125
+	ErrCannotGetReturnType                          ErrorCode = 126
+	ErrIllegalIndex                                 ErrorCode = 127
+	ErrTooLargeArraySize                            ErrorCode = 128
+	ErrFunctionIsSpecial                            ErrorCode = 129
+	ErrCannotReadArrayFromText                      ErrorCode = 130
+	ErrTooLargeStringSize                           ErrorCode = 131
+	ErrCannotCreateTableFromMetadata                ErrorCode = 132
+	ErrAggregateFunctionDoesntAllowParameters       ErrorCode = 133
+	ErrParametersToAggregateFunctionsMustBeLiterals ErrorCode = 134
+	ErrZeroArrayOrTupleIndex                        ErrorCode = 135
+	ErrUnknownElementInConfig                       ErrorCode = 137
+	ErrExcessiveElementInConfig                     ErrorCode = 138
+	ErrNoElementsInConfig                           ErrorCode = 139
+	ErrAllRequestedColumnsAreMissing                ErrorCode = 140
+	ErrSamplingNotSupported                         ErrorCode = 141
+	ErrNotFoundNode                                 ErrorCode = 142
+	ErrFoundMoreThanOneNode                         ErrorCode = 143
+	ErrFirstDateIsBiggerThanLastDate                ErrorCode = 144
+	ErrUnknownOverflowMode                          ErrorCode = 145
+	ErrQuerySectionDoesntMakeSense                  ErrorCode = 146
+	ErrNotFoundFunctionElementForAggregate          ErrorCode = 147
+	ErrNotFoundRelationElementForCondition          ErrorCode = 148
+	ErrNotFoundRhsElementForCondition               ErrorCode = 149
+	ErrNoAttributesListed                           ErrorCode = 150
+	ErrIndexOfColumnInSortClauseIsOutOfRange        ErrorCode = 151
+	ErrUnknownDirectionOfSorting                    ErrorCode = 152
+	ErrIllegalDivision                              ErrorCode = 153
+	ErrAggregateFunctionNotApplicable               ErrorCode = 154
+	ErrUnknownRelation                              ErrorCode = 155
+	ErrDictionariesWasNotLoaded                     ErrorCode = 156
+	ErrIllegalOverflowMode                          ErrorCode = 157
+	ErrTooManyRows                                  ErrorCode = 158
+	ErrTimeoutExceeded                              ErrorCode = 159
+	ErrTooSlow                                      ErrorCode = 160
+	ErrTooManyColumns                               ErrorCode = 161
+	ErrTooDeepSubqueries                            ErrorCode = 162
+	ErrTooDeepPipeline                              ErrorCode = 163
+	ErrReadonly                                     ErrorCode = 164
+	ErrTooManyTemporaryColumns                      ErrorCode = 165
+	ErrTooManyTemporaryNonConstColumns              ErrorCode = 166
+	ErrTooDeepAst                                   ErrorCode = 167
+	ErrTooBigAst                                    ErrorCode = 168
+	ErrBadTypeOfField                               ErrorCode = 169
+	ErrBadGet                                       ErrorCode = 170
+	ErrBlocksHaveDifferentStructure                 ErrorCode = 171
+	ErrCannotCreateDirectory                        ErrorCode = 172
+	ErrCannotAllocateMemory                         ErrorCode = 173
+	ErrCyclicAliases                                ErrorCode = 174
+	ErrChunkNotFound                                ErrorCode = 176
+	ErrDuplicateChunkName                           ErrorCode = 177
+	ErrMultipleAliasesForExpression                 ErrorCode = 178
+	ErrMultipleExpressionsForAlias                  ErrorCode = 179
+	ErrThereIsNoProfile                             ErrorCode = 180
+	ErrIllegalFinal                                 ErrorCode = 181
+	ErrIllegalPrewhere                              ErrorCode = 182
+	ErrUnexpectedExpression                         ErrorCode = 183
+	ErrIllegalAggregation                           ErrorCode = 184
+	ErrUnsupportedMyisamBlockType                   ErrorCode = 185
+	ErrUnsupportedCollationLocale                   ErrorCode = 186
+	ErrCollationComparisonFailed                    ErrorCode = 187
+	ErrUnknownAction                                ErrorCode = 188
+	ErrTableMustNotBeCreatedManually                ErrorCode = 189
+	ErrSizesOfArraysDoesntMatch                     ErrorCode = 190
+	ErrSetSizeLimitExceeded                         ErrorCode = 191
+	ErrUnknownUser                                  ErrorCode = 192
+	ErrWrongPassword                                ErrorCode = 193
+	ErrRequiredPassword                             ErrorCode = 194
+	ErrIpAddressNotAllowed                          ErrorCode = 195
+	ErrUnknownAddressPatternType                    ErrorCode = 196
+	ErrServerRevisionIsTooOld                       ErrorCode = 197
+	ErrDnsError                                     ErrorCode = 198
+	ErrUnknownQuota                                 ErrorCode = 199
+	ErrQuotaDoesntAllowKeys                         ErrorCode = 200
+	ErrQuotaExceeded                                ErrorCode = 201
+	ErrTooManySimultaneousQueries                   ErrorCode = 202
+	ErrNoFreeConnection                             ErrorCode = 203
+	ErrCannotFsync                                  ErrorCode = 204
+	ErrNestedTypeTooDeep                            ErrorCode = 205
+	ErrAliasRequired                                ErrorCode = 206
+	ErrAmbiguousIdentifier                          ErrorCode = 207
+	ErrEmptyNestedTable                             ErrorCode = 208
+	ErrSocketTimeout                                ErrorCode = 209
+	ErrNetworkError                                 ErrorCode = 210
+	ErrEmptyQuery                                   ErrorCode = 211
+	ErrUnknownLoadBalancing                         ErrorCode = 212
+	ErrUnknownTotalsMode                            ErrorCode = 213
+	ErrCannotStatvfs                                ErrorCode = 214
+	ErrNotAnAggregate                               ErrorCode = 215
+	ErrQueryWithSameIdIsAlreadyRunning              ErrorCode = 216
+	ErrClientHasConnectedToWrongPort                ErrorCode = 217
+	ErrTableIsDropped                               ErrorCode = 218
+	ErrDatabaseNotEmpty                             ErrorCode = 219
+	ErrDuplicateInterserverIoEndpoint               ErrorCode = 220
+	ErrNoSuchInterserverIoEndpoint                  ErrorCode = 221
+	ErrAddingReplicaToNonEmptyTable                 ErrorCode = 222
+	ErrUnexpectedAstStructure                       ErrorCode = 223
+	ErrReplicaIsAlreadyActive                       ErrorCode = 224
+	ErrNoZookeeper                                  ErrorCode = 225
+	ErrNoFileInDataPart                             ErrorCode = 226
+	ErrUnexpectedFileInDataPart                     ErrorCode = 227
+	ErrBadSizeOfFileInDataPart                      ErrorCode = 228
+	ErrQueryIsTooLarge                              ErrorCode = 229
+	ErrNotFoundExpectedDataPart                     ErrorCode = 230
+	ErrTooManyUnexpectedDataParts                   ErrorCode = 231
+	ErrNoSuchDataPart                               ErrorCode = 232
+	ErrBadDataPartName                              ErrorCode = 233
+	ErrNoReplicaHasPart                             ErrorCode = 234
+	ErrDuplicateDataPart                            ErrorCode = 235
+	ErrAborted                                      ErrorCode = 236
+	ErrNoReplicaNameGiven                           ErrorCode = 237
+	ErrFormatVersionTooOld                          ErrorCode = 238
+	ErrCannotMunmap                                 ErrorCode = 239
+	ErrCannotMremap                                 ErrorCode = 240
+	ErrMemoryLimitExceeded                          ErrorCode = 241
+	ErrTableIsReadOnly                              ErrorCode = 242
+	ErrNotEnoughSpace                               ErrorCode = 243
+	ErrUnexpectedZookeeperError                     ErrorCode = 244
+	ErrCorruptedData                                ErrorCode = 246
+	ErrIncorrectMark                                ErrorCode = 247
+	ErrInvalidPartitionValue                        ErrorCode = 248
+	ErrNotEnoughBlockNumbers                        ErrorCode = 250
+	ErrNoSuchReplica                                ErrorCode = 251
+	ErrTooManyParts                                 ErrorCode = 252
+	ErrReplicaIsAlreadyExist                        ErrorCode = 253
+	ErrNoActiveReplicas                             ErrorCode = 254
+	ErrTooManyRetriesToFetchParts                   ErrorCode = 255
+	ErrPartitionAlreadyExists                       ErrorCode = 256
+	ErrPartitionDoesntExist                         ErrorCode = 257
+	ErrUnionAllResultStructuresMismatch             ErrorCode = 258
+	ErrClientOutputFormatSpecified                  ErrorCode = 260
+	ErrUnknownBlockInfoField                        ErrorCode = 261
+	ErrBadCollation                                 ErrorCode = 262
+	ErrCannotCompileCode                            ErrorCode = 263
+	ErrIncompatibleTypeOfJoin                       ErrorCode = 264
+	ErrNoAvailableReplica                           ErrorCode = 265
+	ErrMismatchReplicasDataSources                  ErrorCode = 266
+	ErrStorageDoesntSupportParallelReplicas         ErrorCode = 267
+	ErrCpuidError                                   ErrorCode = 268
+	ErrInfiniteLoop                                 ErrorCode = 269
+	ErrCannotCompress                               ErrorCode = 270
+	ErrCannotDecompress                             ErrorCode = 271
+	ErrAioSubmitError                               ErrorCode = 272
+	ErrAioCompletionError                           ErrorCode = 273
+	ErrAioReadError                                 ErrorCode = 274
+	ErrAioWriteError                                ErrorCode = 275
+	ErrIndexNotUsed                                 ErrorCode = 277
+	ErrLeadershipLost                               ErrorCode = 278
+	ErrAllConnectionTriesFailed                     ErrorCode = 279
+	ErrNoAvailableData                              ErrorCode = 280
+	ErrDictionaryIsEmpty                            ErrorCode = 281
+	ErrIncorrectIndex                               ErrorCode = 282
+	ErrUnknownDistributedProductMode                ErrorCode = 283
+	ErrUnknownGlobalSubqueriesMethod                ErrorCode = 284
+	ErrTooFewLiveReplicas                           ErrorCode = 285
+	ErrUnsatisfiedQuorumForPreviousWrite            ErrorCode = 286
+	ErrUnknownFormatVersion                         ErrorCode = 287
+	ErrDistributedInJoinSubqueryDenied              ErrorCode = 288
+	ErrReplicaIsNotInQuorum                         ErrorCode = 289
+	ErrLimitExceeded                                ErrorCode = 290
+	ErrDatabaseAccessDenied                         ErrorCode = 291
+	ErrLeadershipChanged                            ErrorCode = 292
+	ErrMongodbCannotAuthenticate                    ErrorCode = 293
+	ErrInvalidBlockExtraInfo                        ErrorCode = 294
+	ErrReceivedEmptyData                            ErrorCode = 295
+	ErrNoRemoteShardFound                           ErrorCode = 296
+	ErrShardHasNoConnections                        ErrorCode = 297
+	ErrCannotPipe                                   ErrorCode = 298
+	ErrCannotFork                                   ErrorCode = 299
+	ErrCannotDlsym                                  ErrorCode = 300
+	ErrCannotCreateChildProcess                     ErrorCode = 301
+	ErrChildWasNotExitedNormally                    ErrorCode = 302
+	ErrCannotSelect                                 ErrorCode = 303
+	ErrCannotWaitpid                                ErrorCode = 304
+	ErrTableWasNotDropped                           ErrorCode = 305
+	ErrTooDeepRecursion                             ErrorCode = 306
+	ErrTooManyBytes                                 ErrorCode = 307
+	ErrUnexpectedNodeInZookeeper                    ErrorCode = 308
+	ErrFunctionCannotHaveParameters                 ErrorCode = 309
+	ErrInvalidShardWeight                           ErrorCode = 317
+	ErrInvalidConfigParameter                       ErrorCode = 318
+	ErrUnknownStatusOfInsert                        ErrorCode = 319
+	ErrValueIsOutOfRangeOfDataType                  ErrorCode = 321
+	ErrBarrierTimeout                               ErrorCode = 335
+	ErrUnknownDatabaseEngine                        ErrorCode = 336
+	ErrDdlGuardIsActive                             ErrorCode = 337
+	ErrUnfinished                                   ErrorCode = 341
+	ErrMetadataMismatch                             ErrorCode = 342
+	ErrSupportIsDisabled                            ErrorCode = 344
+	ErrTableDiffersTooMuch                          ErrorCode = 345
+	ErrCannotConvertCharset                         ErrorCode = 346
+	ErrCannotLoadConfig                             ErrorCode = 347
+	ErrCannotInsertNullInOrdinaryColumn             ErrorCode = 349
+	ErrIncompatibleSourceTables                     ErrorCode = 350
+	ErrAmbiguousTableName                           ErrorCode = 351
+	ErrAmbiguousColumnName                          ErrorCode = 352
+	ErrIndexOfPositionalArgumentIsOutOfRange        ErrorCode = 353
+	ErrZlibInflateFailed                            ErrorCode = 354
+	ErrZlibDeflateFailed                            ErrorCode = 355
+	ErrBadLambda                                    ErrorCode = 356
+	ErrReservedIdentifierName                       ErrorCode = 357
+	ErrIntoOutfileNotAllowed                        ErrorCode = 358
+	ErrTableSizeExceedsMaxDropSizeLimit             ErrorCode = 359
+	ErrCannotCreateCharsetConverter                 ErrorCode = 360
+	ErrSeekPositionOutOfBound                       ErrorCode = 361
+	ErrCurrentWriteBufferIsExhausted                ErrorCode = 362
+	ErrCannotCreateIoBuffer                         ErrorCode = 363
+	ErrReceivedErrorTooManyRequests                 ErrorCode = 364
+	ErrOutputIsNotSorted                            ErrorCode = 365
+	ErrSizesOfNestedColumnsAreInconsistent          ErrorCode = 366
+	ErrTooManyFetches                               ErrorCode = 367
+	ErrBadCast                                      ErrorCode = 368
+	ErrAllReplicasAreStale                          ErrorCode = 369
+	ErrDataTypeCannotBeUsedInTables                 ErrorCode = 370
+	ErrInconsistentClusterDefinition                ErrorCode = 371
+	ErrSessionNotFound                              ErrorCode = 372
+	ErrSessionIsLocked                              ErrorCode = 373
+	ErrInvalidSessionTimeout                        ErrorCode = 374
+	ErrCannotDlopen                                 ErrorCode = 375
+	ErrCannotParseUuid                              ErrorCode = 376
+	ErrIllegalSyntaxForDataType                     ErrorCode = 377
+	ErrDataTypeCannotHaveArguments                  ErrorCode = 378
+	ErrUnknownStatusOfDistributedDdlTask            ErrorCode = 379
+	ErrCannotKill                                   ErrorCode = 380
+	ErrHttpLengthRequired                           ErrorCode = 381
+	ErrCannotLoadCatboostModel                      ErrorCode = 382
+	ErrCannotApplyCatboostModel                     ErrorCode = 383
+	ErrPartIsTemporarilyLocked                      ErrorCode = 384
+	ErrMultipleStreamsRequired                      ErrorCode = 385
+	ErrNoCommonType                                 ErrorCode = 386
+	ErrExternalLoadableAlreadyExists                ErrorCode = 387
+	ErrCannotAssignOptimize                         ErrorCode = 388
+	ErrInsertWasDeduplicated                        ErrorCode = 389
+	ErrCannotGetCreateTableQuery                    ErrorCode = 390
+	ErrExternalLibraryError                         ErrorCode = 391
+	ErrQueryIsProhibited                            ErrorCode = 392
+	ErrThereIsNoQuery                               ErrorCode = 393
+	ErrQueryWasCancelled                            ErrorCode = 394
+	ErrFunctionThrowIfValueIsNonZero                ErrorCode = 395
+	ErrTooManyRowsOrBytes                           ErrorCode = 396
+	ErrQueryIsNotSupportedInMaterializedView        ErrorCode = 397
+	ErrSystemError                                  ErrorCode = 425
+	ErrCannotScheduleTask                           ErrorCode = 439
+	ErrCannotParseDomainValueFromString             ErrorCode = 441
+	ErrSettingConstraintViolation                   ErrorCode = 452
+	ErrViolatedConstraint                           ErrorCode = 469
+	ErrDeadlockAvoided                              ErrorCode = 473
+	ErrAccessDenied                                 ErrorCode = 497
+	ErrS3Error                                      ErrorCode = 499
+	ErrAuthenticationFailed                         ErrorCode = 516
+	ErrKeeperException                              ErrorCode = 999
+	ErrPocoException                                ErrorCode = 1000
+	ErrStdException                                 ErrorCode = 1001
+	ErrUnknownException                             ErrorCode = 1002
+	ErrConditionalTreeParentNotFound                ErrorCode = 2001
+	ErrIllegalProjectionManipulator                 ErrorCode = 2002
+)
+
+var errorCodeNames = map[ErrorCode]string{
+	ErrUnsupportedMethod:                            "UNSUPPORTED_METHOD",
+	ErrUnsupportedParameter:                         "UNSUPPORTED_PARAMETER",
+	ErrUnexpectedEndOfFile:                          "UNEXPECTED_END_OF_FILE",
+	ErrExpectedEndOfFile:                            "EXPECTED_END_OF_FILE",
+	ErrCannotParseText:                              "CANNOT_PARSE_TEXT",
+	ErrIncorrectNumberOfColumns:                     "INCORRECT_NUMBER_OF_COLUMNS",
+	ErrThereIsNoColumn:                              "THERE_IS_NO_COLUMN",
+	ErrSizesOfColumnsDoesntMatch:                    "SIZES_OF_COLUMNS_DOESNT_MATCH",
+	ErrNotFoundColumnInBlock:                        "NOT_FOUND_COLUMN_IN_BLOCK",
+	ErrPositionOutOfBound:                           "POSITION_OUT_OF_BOUND",
+	ErrParameterOutOfBound:                          "PARAMETER_OUT_OF_BOUND",
+	ErrSizesOfColumnsInTupleDoesntMatch:             "SIZES_OF_COLUMNS_IN_TUPLE_DOESNT_MATCH",
+	ErrDuplicateColumn:                              "DUPLICATE_COLUMN",
+	ErrNoSuchColumnInTable:                          "NO_SUCH_COLUMN_IN_TABLE",
+	ErrDelimiterInStringLiteralDoesntMatch:          "DELIMITER_IN_STRING_LITERAL_DOESNT_MATCH",
+	ErrCannotInsertElementIntoConstantColumn:        "CANNOT_INSERT_ELEMENT_INTO_CONSTANT_COLUMN",
+	ErrSizeOfFixedStringDoesntMatch:                 "SIZE_OF_FIXED_STRING_DOESNT_MATCH",
+	ErrNumberOfColumnsDoesntMatch:                   "NUMBER_OF_COLUMNS_DOESNT_MATCH",
+	ErrCannotReadAllDataFromTabSeparatedInput:       "CANNOT_READ_ALL_DATA_FROM_TAB_SEPARATED_INPUT",
+	ErrCannotParseAllValueFromTabSeparatedInput:     "CANNOT_PARSE_ALL_VALUE_FROM_TAB_SEPARATED_INPUT",
+	ErrCannotReadFromIstream:                        "CANNOT_READ_FROM_ISTREAM",
+	ErrCannotWriteToOstream:                         "CANNOT_WRITE_TO_OSTREAM",
+	ErrCannotParseEscapeSequence:                    "CANNOT_PARSE_ESCAPE_SEQUENCE",
+	ErrCannotParseQuotedString:                      "CANNOT_PARSE_QUOTED_STRING",
+	ErrCannotParseInputAssertionFailed:              "CANNOT_PARSE_INPUT_ASSERTION_FAILED",
+	ErrCannotPrintFloatOrDoubleNumber:               "CANNOT_PRINT_FLOAT_OR_DOUBLE_NUMBER",
+	ErrCannotPrintInteger:                           "CANNOT_PRINT_INTEGER",
+	ErrCannotReadSizeOfCompressedChunk:              "CANNOT_READ_SIZE_OF_COMPRESSED_CHUNK",
+	ErrCannotReadCompressedChunk:                    "CANNOT_READ_COMPRESSED_CHUNK",
+	ErrAttemptToReadAfterEof:                        "ATTEMPT_TO_READ_AFTER_EOF",
+	ErrCannotReadAllData:                            "CANNOT_READ_ALL_DATA",
+	ErrTooManyArgumentsForFunction:                  "TOO_MANY_ARGUMENTS_FOR_FUNCTION",
+	ErrTooFewArgumentsForFunction:                   "TOO_FEW_ARGUMENTS_FOR_FUNCTION",
+	ErrBadArguments:                                 "BAD_ARGUMENTS",
+	ErrUnknownElementInAst:                          "UNKNOWN_ELEMENT_IN_AST",
+	ErrCannotParseDate:                              "CANNOT_PARSE_DATE",
+	ErrTooLargeSizeCompressed:                       "TOO_LARGE_SIZE_COMPRESSED",
+	ErrChecksumDoesntMatch:                          "CHECKSUM_DOESNT_MATCH",
+	ErrCannotParseDatetime:                          "CANNOT_PARSE_DATETIME",
+	ErrNumberOfArgumentsDoesntMatch:                 "NUMBER_OF_ARGUMENTS_DOESNT_MATCH",
+	ErrIllegalTypeOfArgument:                        "ILLEGAL_TYPE_OF_ARGUMENT",
+	ErrIllegalColumn:                                "ILLEGAL_COLUMN",
+	ErrIllegalNumberOfResultColumns:                 "ILLEGAL_NUMBER_OF_RESULT_COLUMNS",
+	ErrUnknownFunction:                              "UNKNOWN_FUNCTION",
+	ErrUnknownIdentifier:                            "UNKNOWN_IDENTIFIER",
+	ErrNotImplemented:                               "NOT_IMPLEMENTED",
+	ErrLogicalError:                                 "LOGICAL_ERROR",
+	ErrUnknownType:                                  "UNKNOWN_TYPE",
+	ErrEmptyListOfColumnsQueried:                    "EMPTY_LIST_OF_COLUMNS_QUERIED",
+	ErrColumnQueriedMoreThanOnce:                    "COLUMN_QUERIED_MORE_THAN_ONCE",
+	ErrTypeMismatch:                                 "TYPE_MISMATCH",
+	ErrStorageDoesntAllowParameters:                 "STORAGE_DOESNT_ALLOW_PARAMETERS",
+	ErrStorageRequiresParameter:                     "STORAGE_REQUIRES_PARAMETER",
+	ErrUnknownStorage:                               "UNKNOWN_STORAGE",
+	ErrTableAlreadyExists:                           "TABLE_ALREADY_EXISTS",
+	ErrTableMetadataAlreadyExists:                   "TABLE_METADATA_ALREADY_EXISTS",
+	ErrIllegalTypeOfColumnForFilter:                 "ILLEGAL_TYPE_OF_COLUMN_FOR_FILTER",
+	ErrUnknownTable:                                 "UNKNOWN_TABLE",
+	ErrOnlyFilterColumnInBlock:                      "ONLY_FILTER_COLUMN_IN_BLOCK",
+	ErrSyntaxError:                                  "SYNTAX_ERROR",
+	ErrUnknownAggregateFunction:                     "UNKNOWN_AGGREGATE_FUNCTION",
+	ErrCannotReadAggregateFunctionFromText:          "CANNOT_READ_AGGREGATE_FUNCTION_FROM_TEXT",
+	ErrCannotWriteAggregateFunctionAsText:           "CANNOT_WRITE_AGGREGATE_FUNCTION_AS_TEXT",
+	ErrNotAColumn:                                   "NOT_A_COLUMN",
+	ErrIllegalKeyOfAggregation:                      "ILLEGAL_KEY_OF_AGGREGATION",
+	ErrCannotGetSizeOfField:                         "CANNOT_GET_SIZE_OF_FIELD",
+	ErrArgumentOutOfBound:                           "ARGUMENT_OUT_OF_BOUND",
+	ErrCannotConvertType:                            "CANNOT_CONVERT_TYPE",
+	ErrCannotWriteAfterEndOfBuffer:                  "CANNOT_WRITE_AFTER_END_OF_BUFFER",
+	ErrCannotParseNumber:                            "CANNOT_PARSE_NUMBER",
+	ErrUnknownFormat:                                "UNKNOWN_FORMAT",
+	ErrCannotReadFromFileDescriptor:                 "CANNOT_READ_FROM_FILE_DESCRIPTOR",
+	ErrCannotWriteToFileDescriptor:                  "CANNOT_WRITE_TO_FILE_DESCRIPTOR",
+	ErrCannotOpenFile:                               "CANNOT_OPEN_FILE",
+	ErrCannotCloseFile:                              "CANNOT_CLOSE_FILE",
+	ErrUnknownTypeOfQuery:                           "UNKNOWN_TYPE_OF_QUERY",
+	ErrIncorrectFileName:                            "INCORRECT_FILE_NAME",
+	ErrIncorrectQuery:                               "INCORRECT_QUERY",
+	ErrUnknownDatabase:                              "UNKNOWN_DATABASE",
+	ErrDatabaseAlreadyExists:                        "DATABASE_ALREADY_EXISTS",
+	ErrDirectoryDoesntExist:                         "DIRECTORY_DOESNT_EXIST",
+	ErrDirectoryAlreadyExists:                       "DIRECTORY_ALREADY_EXISTS",
+	ErrFormatIsNotSuitableForInput:                  "FORMAT_IS_NOT_SUITABLE_FOR_INPUT",
+	ErrReceivedErrorFromRemoteIoServer:              "RECEIVED_ERROR_FROM_REMOTE_IO_SERVER",
+	ErrCannotSeekThroughFile:                        "CANNOT_SEEK_THROUGH_FILE",
+	ErrCannotTruncateFile:                           "CANNOT_TRUNCATE_FILE",
+	ErrUnknownCompressionMethod:                     "UNKNOWN_COMPRESSION_METHOD",
+	ErrEmptyListOfColumnsPassed:                     "EMPTY_LIST_OF_COLUMNS_PASSED",
+	ErrSizesOfMarksFilesAreInconsistent:             "SIZES_OF_MARKS_FILES_ARE_INCONSISTENT",
+	ErrEmptyDataPassed:                              "EMPTY_DATA_PASSED",
+	ErrUnknownAggregatedDataVariant:                 "UNKNOWN_AGGREGATED_DATA_VARIANT",
+	ErrCannotMergeDifferentAggregatedDataVariants:   "CANNOT_MERGE_DIFFERENT_AGGREGATED_DATA_VARIANTS",
+	ErrCannotReadFromSocket:                         "CANNOT_READ_FROM_SOCKET",
+	ErrCannotWriteToSocket:                          "CANNOT_WRITE_TO_SOCKET",
+	ErrCannotReadAllDataFromChunkedInput:            "CANNOT_READ_ALL_DATA_FROM_CHUNKED_INPUT",
+	ErrCannotWriteToEmptyBlockOutputStream:          "CANNOT_WRITE_TO_EMPTY_BLOCK_OUTPUT_STREAM",
+	ErrUnknownPacketFromClient:                      "UNKNOWN_PACKET_FROM_CLIENT",
+	ErrUnknownPacketFromServer:                      "UNKNOWN_PACKET_FROM_SERVER",
+	ErrUnexpectedPacketFromClient:                   "UNEXPECTED_PACKET_FROM_CLIENT",
+	ErrUnexpectedPacketFromServer:                   "UNEXPECTED_PACKET_FROM_SERVER",
+	ErrReceivedDataForWrongQueryId:                  "RECEIVED_DATA_FOR_WRONG_QUERY_ID",
+	ErrTooSmallBufferSize:                           "TOO_SMALL_BUFFER_SIZE",
+	ErrCannotReadHistory:                            "CANNOT_READ_HISTORY",
+	ErrCannotAppendHistory:                          "CANNOT_APPEND_HISTORY",
+	ErrFileDoesntExist:                              "FILE_DOESNT_EXIST",
+	ErrNoDataToInsert:                               "NO_DATA_TO_INSERT",
+	ErrCannotBlockSignal:                            "CANNOT_BLOCK_SIGNAL",
+	ErrCannotUnblockSignal:                          "CANNOT_UNBLOCK_SIGNAL",
+	ErrCannotManipulateSigset:                       "CANNOT_MANIPULATE_SIGSET",
+	ErrCannotWaitForSignal:                          "CANNOT_WAIT_FOR_SIGNAL",
+	ErrThereIsNoSession:                             "THERE_IS_NO_SESSION",
+	ErrCannotClockGettime:                           "CANNOT_CLOCK_GETTIME",
+	ErrUnknownSetting:                               "UNKNOWN_SETTING",
+	ErrThereIsNoDefaultValue:                        "THERE_IS_NO_DEFAULT_VALUE",
+	ErrIncorrectData:                                "INCORRECT_DATA",
+	ErrEngineRequired:                               "ENGINE_REQUIRED",
+	ErrCannotInsertValueOfDifferentSizeIntoTuple:    "CANNOT_INSERT_VALUE_OF_DIFFERENT_SIZE_INTO_TUPLE",
+	ErrUnknownSetDataVariant:                        "UNKNOWN_SET_DATA_VARIANT",
+	ErrIncompatibleColumns:                          "INCOMPATIBLE_COLUMNS",
+	ErrUnknownTypeOfAstNode:                         "UNKNOWN_TYPE_OF_AST_NODE",
+	ErrIncorrectElementOfSet:                        "INCORRECT_ELEMENT_OF_SET",
+	ErrIncorrectResultOfScalarSubquery:              "INCORRECT_RESULT_OF_SCALAR_SUBQUERY",
+	ErrCannotGetReturnType:                          "CANNOT_GET_RETURN_TYPE",
+	ErrIllegalIndex:                                 "ILLEGAL_INDEX",
+	ErrTooLargeArraySize:                            "TOO_LARGE_ARRAY_SIZE",
+	ErrFunctionIsSpecial:                            "FUNCTION_IS_SPECIAL",
+	ErrCannotReadArrayFromText:                      "CANNOT_READ_ARRAY_FROM_TEXT",
+	ErrTooLargeStringSize:                           "TOO_LARGE_STRING_SIZE",
+	ErrCannotCreateTableFromMetadata:                "CANNOT_CREATE_TABLE_FROM_METADATA",
+	ErrAggregateFunctionDoesntAllowParameters:       "AGGREGATE_FUNCTION_DOESNT_ALLOW_PARAMETERS",
+	ErrParametersToAggregateFunctionsMustBeLiterals: "PARAMETERS_TO_AGGREGATE_FUNCTIONS_MUST_BE_LITERALS",
+	ErrZeroArrayOrTupleIndex:                        "ZERO_ARRAY_OR_TUPLE_INDEX",
+	ErrUnknownElementInConfig:                       "UNKNOWN_ELEMENT_IN_CONFIG",
+	ErrExcessiveElementInConfig:                     "EXCESSIVE_ELEMENT_IN_CONFIG",
+	ErrNoElementsInConfig:                           "NO_ELEMENTS_IN_CONFIG",
+	ErrAllRequestedColumnsAreMissing:                "ALL_REQUESTED_COLUMNS_ARE_MISSING",
+	ErrSamplingNotSupported:                         "SAMPLING_NOT_SUPPORTED",
+	ErrNotFoundNode:                                 "NOT_FOUND_NODE",
+	ErrFoundMoreThanOneNode:                         "FOUND_MORE_THAN_ONE_NODE",
+	ErrFirstDateIsBiggerThanLastDate:                "FIRST_DATE_IS_BIGGER_THAN_LAST_DATE",
+	ErrUnknownOverflowMode:                          "UNKNOWN_OVERFLOW_MODE",
+	ErrQuerySectionDoesntMakeSense:                  "QUERY_SECTION_DOESNT_MAKE_SENSE",
+	ErrNotFoundFunctionElementForAggregate:          "NOT_FOUND_FUNCTION_ELEMENT_FOR_AGGREGATE",
+	ErrNotFoundRelationElementForCondition:          "NOT_FOUND_RELATION_ELEMENT_FOR_CONDITION",
+	ErrNotFoundRhsElementForCondition:               "NOT_FOUND_RHS_ELEMENT_FOR_CONDITION",
+	ErrNoAttributesListed:                           "NO_ATTRIBUTES_LISTED",
+	ErrIndexOfColumnInSortClauseIsOutOfRange:        "INDEX_OF_COLUMN_IN_SORT_CLAUSE_IS_OUT_OF_RANGE",
+	ErrUnknownDirectionOfSorting:                    "UNKNOWN_DIRECTION_OF_SORTING",
+	ErrIllegalDivision:                              "ILLEGAL_DIVISION",
+	ErrAggregateFunctionNotApplicable:               "AGGREGATE_FUNCTION_NOT_APPLICABLE",
+	ErrUnknownRelation:                              "UNKNOWN_RELATION",
+	ErrDictionariesWasNotLoaded:                     "DICTIONARIES_WAS_NOT_LOADED",
+	ErrIllegalOverflowMode:                          "ILLEGAL_OVERFLOW_MODE",
+	ErrTooManyRows:                                  "TOO_MANY_ROWS",
+	ErrTimeoutExceeded:                              "TIMEOUT_EXCEEDED",
+	ErrTooSlow:                                      "TOO_SLOW",
+	ErrTooManyColumns:                               "TOO_MANY_COLUMNS",
+	ErrTooDeepSubqueries:                            "TOO_DEEP_SUBQUERIES",
+	ErrTooDeepPipeline:                              "TOO_DEEP_PIPELINE",
+	ErrReadonly:                                     "READONLY",
+	ErrTooManyTemporaryColumns:                      "TOO_MANY_TEMPORARY_COLUMNS",
+	ErrTooManyTemporaryNonConstColumns:              "TOO_MANY_TEMPORARY_NON_CONST_COLUMNS",
+	ErrTooDeepAst:                                   "TOO_DEEP_AST",
+	ErrTooBigAst:                                    "TOO_BIG_AST",
+	ErrBadTypeOfField:                               "BAD_TYPE_OF_FIELD",
+	ErrBadGet:                                       "BAD_GET",
+	ErrBlocksHaveDifferentStructure:                 "BLOCKS_HAVE_DIFFERENT_STRUCTURE",
+	ErrCannotCreateDirectory:                        "CANNOT_CREATE_DIRECTORY",
+	ErrCannotAllocateMemory:                         "CANNOT_ALLOCATE_MEMORY",
+	ErrCyclicAliases:                                "CYCLIC_ALIASES",
+	ErrChunkNotFound:                                "CHUNK_NOT_FOUND",
+	ErrDuplicateChunkName:                           "DUPLICATE_CHUNK_NAME",
+	ErrMultipleAliasesForExpression:                 "MULTIPLE_ALIASES_FOR_EXPRESSION",
+	ErrMultipleExpressionsForAlias:                  "MULTIPLE_EXPRESSIONS_FOR_ALIAS",
+	ErrThereIsNoProfile:                             "THERE_IS_NO_PROFILE",
+	ErrIllegalFinal:                                 "ILLEGAL_FINAL",
+	ErrIllegalPrewhere:                              "ILLEGAL_PREWHERE",
+	ErrUnexpectedExpression:                         "UNEXPECTED_EXPRESSION",
+	ErrIllegalAggregation:                           "ILLEGAL_AGGREGATION",
+	ErrUnsupportedMyisamBlockType:                   "UNSUPPORTED_MYISAM_BLOCK_TYPE",
+	ErrUnsupportedCollationLocale:                   "UNSUPPORTED_COLLATION_LOCALE",
+	ErrCollationComparisonFailed:                    "COLLATION_COMPARISON_FAILED",
+	ErrUnknownAction:                                "UNKNOWN_ACTION",
+	ErrTableMustNotBeCreatedManually:                "TABLE_MUST_NOT_BE_CREATED_MANUALLY",
+	ErrSizesOfArraysDoesntMatch:                     "SIZES_OF_ARRAYS_DOESNT_MATCH",
+	ErrSetSizeLimitExceeded:                         "SET_SIZE_LIMIT_EXCEEDED",
+	ErrUnknownUser:                                  "UNKNOWN_USER",
+	ErrWrongPassword:                                "WRONG_PASSWORD",
+	ErrRequiredPassword:                             "REQUIRED_PASSWORD",
+	ErrIpAddressNotAllowed:                          "IP_ADDRESS_NOT_ALLOWED",
+	ErrUnknownAddressPatternType:                    "UNKNOWN_ADDRESS_PATTERN_TYPE",
+	ErrServerRevisionIsTooOld:                       "SERVER_REVISION_IS_TOO_OLD",
+	ErrDnsError:                                     "DNS_ERROR",
+	ErrUnknownQuota:                                 "UNKNOWN_QUOTA",
+	ErrQuotaDoesntAllowKeys:                         "QUOTA_DOESNT_ALLOW_KEYS",
+	ErrQuotaExceeded:                                "QUOTA_EXCEEDED",
+	ErrTooManySimultaneousQueries:                   "TOO_MANY_SIMULTANEOUS_QUERIES",
+	ErrNoFreeConnection:                             "NO_FREE_CONNECTION",
+	ErrCannotFsync:                                  "CANNOT_FSYNC",
+	ErrNestedTypeTooDeep:                            "NESTED_TYPE_TOO_DEEP",
+	ErrAliasRequired:                                "ALIAS_REQUIRED",
+	ErrAmbiguousIdentifier:                          "AMBIGUOUS_IDENTIFIER",
+	ErrEmptyNestedTable:                             "EMPTY_NESTED_TABLE",
+	ErrSocketTimeout:                                "SOCKET_TIMEOUT",
+	ErrNetworkError:                                 "NETWORK_ERROR",
+	ErrEmptyQuery:                                   "EMPTY_QUERY",
+	ErrUnknownLoadBalancing:                         "UNKNOWN_LOAD_BALANCING",
+	ErrUnknownTotalsMode:                            "UNKNOWN_TOTALS_MODE",
+	ErrCannotStatvfs:                                "CANNOT_STATVFS",
+	ErrNotAnAggregate:                               "NOT_AN_AGGREGATE",
+	ErrQueryWithSameIdIsAlreadyRunning:              "QUERY_WITH_SAME_ID_IS_ALREADY_RUNNING",
+	ErrClientHasConnectedToWrongPort:                "CLIENT_HAS_CONNECTED_TO_WRONG_PORT",
+	ErrTableIsDropped:                               "TABLE_IS_DROPPED",
+	ErrDatabaseNotEmpty:                             "DATABASE_NOT_EMPTY",
+	ErrDuplicateInterserverIoEndpoint:               "DUPLICATE_INTERSERVER_IO_ENDPOINT",
+	ErrNoSuchInterserverIoEndpoint:                  "NO_SUCH_INTERSERVER_IO_ENDPOINT",
+	ErrAddingReplicaToNonEmptyTable:                 "ADDING_REPLICA_TO_NON_EMPTY_TABLE",
+	ErrUnexpectedAstStructure:                       "UNEXPECTED_AST_STRUCTURE",
+	ErrReplicaIsAlreadyActive:                       "REPLICA_IS_ALREADY_ACTIVE",
+	ErrNoZookeeper:                                  "NO_ZOOKEEPER",
+	ErrNoFileInDataPart:                             "NO_FILE_IN_DATA_PART",
+	ErrUnexpectedFileInDataPart:                     "UNEXPECTED_FILE_IN_DATA_PART",
+	ErrBadSizeOfFileInDataPart:                      "BAD_SIZE_OF_FILE_IN_DATA_PART",
+	ErrQueryIsTooLarge:                              "QUERY_IS_TOO_LARGE",
+	ErrNotFoundExpectedDataPart:                     "NOT_FOUND_EXPECTED_DATA_PART",
+	ErrTooManyUnexpectedDataParts:                   "TOO_MANY_UNEXPECTED_DATA_PARTS",
+	ErrNoSuchDataPart:                               "NO_SUCH_DATA_PART",
+	ErrBadDataPartName:                              "BAD_DATA_PART_NAME",
+	ErrNoReplicaHasPart:                             "NO_REPLICA_HAS_PART",
+	ErrDuplicateDataPart:                            "DUPLICATE_DATA_PART",
+	ErrAborted:                                      "ABORTED",
+	ErrNoReplicaNameGiven:                           "NO_REPLICA_NAME_GIVEN",
+	ErrFormatVersionTooOld:                          "FORMAT_VERSION_TOO_OLD",
+	ErrCannotMunmap:                                 "CANNOT_MUNMAP",
+	ErrCannotMremap:                                 "CANNOT_MREMAP",
+	ErrMemoryLimitExceeded:                          "MEMORY_LIMIT_EXCEEDED",
+	ErrTableIsReadOnly:                              "TABLE_IS_READ_ONLY",
+	ErrNotEnoughSpace:                               "NOT_ENOUGH_SPACE",
+	ErrUnexpectedZookeeperError:                     "UNEXPECTED_ZOOKEEPER_ERROR",
+	ErrCorruptedData:                                "CORRUPTED_DATA",
+	ErrIncorrectMark:                                "INCORRECT_MARK",
+	ErrInvalidPartitionValue:                        "INVALID_PARTITION_VALUE",
+	ErrNotEnoughBlockNumbers:                        "NOT_ENOUGH_BLOCK_NUMBERS",
+	ErrNoSuchReplica:                                "NO_SUCH_REPLICA",
+	ErrTooManyParts:                                 "TOO_MANY_PARTS",
+	ErrReplicaIsAlreadyExist:                        "REPLICA_IS_ALREADY_EXIST",
+	ErrNoActiveReplicas:                             "NO_ACTIVE_REPLICAS",
+	ErrTooManyRetriesToFetchParts:                   "TOO_MANY_RETRIES_TO_FETCH_PARTS",
+	ErrPartitionAlreadyExists:                       "PARTITION_ALREADY_EXISTS",
+	ErrPartitionDoesntExist:                         "PARTITION_DOESNT_EXIST",
+	ErrUnionAllResultStructuresMismatch:             "UNION_ALL_RESULT_STRUCTURES_MISMATCH",
+	ErrClientOutputFormatSpecified:                  "CLIENT_OUTPUT_FORMAT_SPECIFIED",
+	ErrUnknownBlockInfoField:                        "UNKNOWN_BLOCK_INFO_FIELD",
+	ErrBadCollation:                                 "BAD_COLLATION",
+	ErrCannotCompileCode:                            "CANNOT_COMPILE_CODE",
+	ErrIncompatibleTypeOfJoin:                       "INCOMPATIBLE_TYPE_OF_JOIN",
+	ErrNoAvailableReplica:                           "NO_AVAILABLE_REPLICA",
+	ErrMismatchReplicasDataSources:                  "MISMATCH_REPLICAS_DATA_SOURCES",
+	ErrStorageDoesntSupportParallelReplicas:         "STORAGE_DOESNT_SUPPORT_PARALLEL_REPLICAS",
+	ErrCpuidError:                                   "CPUID_ERROR",
+	ErrInfiniteLoop:                                 "INFINITE_LOOP",
+	ErrCannotCompress:                               "CANNOT_COMPRESS",
+	ErrCannotDecompress:                             "CANNOT_DECOMPRESS",
+	ErrAioSubmitError:                               "AIO_SUBMIT_ERROR",
+	ErrAioCompletionError:                           "AIO_COMPLETION_ERROR",
+	ErrAioReadError:                                 "AIO_READ_ERROR",
+	ErrAioWriteError:                                "AIO_WRITE_ERROR",
+	ErrIndexNotUsed:                                 "INDEX_NOT_USED",
+	ErrLeadershipLost:                               "LEADERSHIP_LOST",
+	ErrAllConnectionTriesFailed:                     "ALL_CONNECTION_TRIES_FAILED",
+	ErrNoAvailableData:                              "NO_AVAILABLE_DATA",
+	ErrDictionaryIsEmpty:                            "DICTIONARY_IS_EMPTY",
+	ErrIncorrectIndex:                               "INCORRECT_INDEX",
+	ErrUnknownDistributedProductMode:                "UNKNOWN_DISTRIBUTED_PRODUCT_MODE",
+	ErrUnknownGlobalSubqueriesMethod:                "UNKNOWN_GLOBAL_SUBQUERIES_METHOD",
+	ErrTooFewLiveReplicas:                           "TOO_FEW_LIVE_REPLICAS",
+	ErrUnsatisfiedQuorumForPreviousWrite:            "UNSATISFIED_QUORUM_FOR_PREVIOUS_WRITE",
+	ErrUnknownFormatVersion:                         "UNKNOWN_FORMAT_VERSION",
+	ErrDistributedInJoinSubqueryDenied:              "DISTRIBUTED_IN_JOIN_SUBQUERY_DENIED",
+	ErrReplicaIsNotInQuorum:                         "REPLICA_IS_NOT_IN_QUORUM",
+	ErrLimitExceeded:                                "LIMIT_EXCEEDED",
+	ErrDatabaseAccessDenied:                         "DATABASE_ACCESS_DENIED",
+	ErrLeadershipChanged:                            "LEADERSHIP_CHANGED",
+	ErrMongodbCannotAuthenticate:                    "MONGODB_CANNOT_AUTHENTICATE",
+	ErrInvalidBlockExtraInfo:                        "INVALID_BLOCK_EXTRA_INFO",
+	ErrReceivedEmptyData:                            "RECEIVED_EMPTY_DATA",
+	ErrNoRemoteShardFound:                           "NO_REMOTE_SHARD_FOUND",
+	ErrShardHasNoConnections:                        "SHARD_HAS_NO_CONNECTIONS",
+	ErrCannotPipe:                                   "CANNOT_PIPE",
+	ErrCannotFork:                                   "CANNOT_FORK",
+	ErrCannotDlsym:                                  "CANNOT_DLSYM",
+	ErrCannotCreateChildProcess:                     "CANNOT_CREATE_CHILD_PROCESS",
+	ErrChildWasNotExitedNormally:                    "CHILD_WAS_NOT_EXITED_NORMALLY",
+	ErrCannotSelect:                                 "CANNOT_SELECT",
+	ErrCannotWaitpid:                                "CANNOT_WAITPID",
+	ErrTableWasNotDropped:                           "TABLE_WAS_NOT_DROPPED",
+	ErrTooDeepRecursion:                             "TOO_DEEP_RECURSION",
+	ErrTooManyBytes:                                 "TOO_MANY_BYTES",
+	ErrUnexpectedNodeInZookeeper:                    "UNEXPECTED_NODE_IN_ZOOKEEPER",
+	ErrFunctionCannotHaveParameters:                 "FUNCTION_CANNOT_HAVE_PARAMETERS",
+	ErrInvalidShardWeight:                           "INVALID_SHARD_WEIGHT",
+	ErrInvalidConfigParameter:                       "INVALID_CONFIG_PARAMETER",
+	ErrUnknownStatusOfInsert:                        "UNKNOWN_STATUS_OF_INSERT",
+	ErrValueIsOutOfRangeOfDataType:                  "VALUE_IS_OUT_OF_RANGE_OF_DATA_TYPE",
+	ErrBarrierTimeout:                               "BARRIER_TIMEOUT",
+	ErrUnknownDatabaseEngine:                        "UNKNOWN_DATABASE_ENGINE",
+	ErrDdlGuardIsActive:                             "DDL_GUARD_IS_ACTIVE",
+	ErrUnfinished:                                   "UNFINISHED",
+	ErrMetadataMismatch:                             "METADATA_MISMATCH",
+	ErrSupportIsDisabled:                            "SUPPORT_IS_DISABLED",
+	ErrTableDiffersTooMuch:                          "TABLE_DIFFERS_TOO_MUCH",
+	ErrCannotConvertCharset:                         "CANNOT_CONVERT_CHARSET",
+	ErrCannotLoadConfig:                             "CANNOT_LOAD_CONFIG",
+	ErrCannotInsertNullInOrdinaryColumn:             "CANNOT_INSERT_NULL_IN_ORDINARY_COLUMN",
+	ErrIncompatibleSourceTables:                     "INCOMPATIBLE_SOURCE_TABLES",
+	ErrAmbiguousTableName:                           "AMBIGUOUS_TABLE_NAME",
+	ErrAmbiguousColumnName:                          "AMBIGUOUS_COLUMN_NAME",
+	ErrIndexOfPositionalArgumentIsOutOfRange:        "INDEX_OF_POSITIONAL_ARGUMENT_IS_OUT_OF_RANGE",
+	ErrZlibInflateFailed:                            "ZLIB_INFLATE_FAILED",
+	ErrZlibDeflateFailed:                            "ZLIB_DEFLATE_FAILED",
+	ErrBadLambda:                                    "BAD_LAMBDA",
+	ErrReservedIdentifierName:                       "RESERVED_IDENTIFIER_NAME",
+	ErrIntoOutfileNotAllowed:                        "INTO_OUTFILE_NOT_ALLOWED",
+	ErrTableSizeExceedsMaxDropSizeLimit:             "TABLE_SIZE_EXCEEDS_MAX_DROP_SIZE_LIMIT",
+	ErrCannotCreateCharsetConverter:                 "CANNOT_CREATE_CHARSET_CONVERTER",
+	ErrSeekPositionOutOfBound:                       "SEEK_POSITION_OUT_OF_BOUND",
+	ErrCurrentWriteBufferIsExhausted:                "CURRENT_WRITE_BUFFER_IS_EXHAUSTED",
+	ErrCannotCreateIoBuffer:                         "CANNOT_CREATE_IO_BUFFER",
+	ErrReceivedErrorTooManyRequests:                 "RECEIVED_ERROR_TOO_MANY_REQUESTS",
+	ErrOutputIsNotSorted:                            "OUTPUT_IS_NOT_SORTED",
+	ErrSizesOfNestedColumnsAreInconsistent:          "SIZES_OF_NESTED_COLUMNS_ARE_INCONSISTENT",
+	ErrTooManyFetches:                               "TOO_MANY_FETCHES",
+	ErrBadCast:                                      "BAD_CAST",
+	ErrAllReplicasAreStale:                          "ALL_REPLICAS_ARE_STALE",
+	ErrDataTypeCannotBeUsedInTables:                 "DATA_TYPE_CANNOT_BE_USED_IN_TABLES",
+	ErrInconsistentClusterDefinition:                "INCONSISTENT_CLUSTER_DEFINITION",
+	ErrSessionNotFound:                              "SESSION_NOT_FOUND",
+	ErrSessionIsLocked:                              "SESSION_IS_LOCKED",
+	ErrInvalidSessionTimeout:                        "INVALID_SESSION_TIMEOUT",
+	ErrCannotDlopen:                                 "CANNOT_DLOPEN",
+	ErrCannotParseUuid:                              "CANNOT_PARSE_UUID",
+	ErrIllegalSyntaxForDataType:                     "ILLEGAL_SYNTAX_FOR_DATA_TYPE",
+	ErrDataTypeCannotHaveArguments:                  "DATA_TYPE_CANNOT_HAVE_ARGUMENTS",
+	ErrUnknownStatusOfDistributedDdlTask:            "UNKNOWN_STATUS_OF_DISTRIBUTED_DDL_TASK",
+	ErrCannotKill:                                   "CANNOT_KILL",
+	ErrHttpLengthRequired:                           "HTTP_LENGTH_REQUIRED",
+	ErrCannotLoadCatboostModel:                      "CANNOT_LOAD_CATBOOST_MODEL",
+	ErrCannotApplyCatboostModel:                     "CANNOT_APPLY_CATBOOST_MODEL",
+	ErrPartIsTemporarilyLocked:                      "PART_IS_TEMPORARILY_LOCKED",
+	ErrMultipleStreamsRequired:                      "MULTIPLE_STREAMS_REQUIRED",
+	ErrNoCommonType:                                 "NO_COMMON_TYPE",
+	ErrExternalLoadableAlreadyExists:                "EXTERNAL_LOADABLE_ALREADY_EXISTS",
+	ErrCannotAssignOptimize:                         "CANNOT_ASSIGN_OPTIMIZE",
+	ErrInsertWasDeduplicated:                        "INSERT_WAS_DEDUPLICATED",
+	ErrCannotGetCreateTableQuery:                    "CANNOT_GET_CREATE_TABLE_QUERY",
+	ErrExternalLibraryError:                         "EXTERNAL_LIBRARY_ERROR",
+	ErrQueryIsProhibited:                            "QUERY_IS_PROHIBITED",
+	ErrThereIsNoQuery:                               "THERE_IS_NO_QUERY",
+	ErrQueryWasCancelled:                            "QUERY_WAS_CANCELLED",
+	ErrFunctionThrowIfValueIsNonZero:                "FUNCTION_THROW_IF_VALUE_IS_NON_ZERO",
+	ErrTooManyRowsOrBytes:                           "TOO_MANY_ROWS_OR_BYTES",
+	ErrQueryIsNotSupportedInMaterializedView:        "QUERY_IS_NOT_SUPPORTED_IN_MATERIALIZED_VIEW",
+	ErrSystemError:                                  "SYSTEM_ERROR",
+	ErrCannotScheduleTask:                           "CANNOT_SCHEDULE_TASK",
+	ErrCannotParseDomainValueFromString:             "CANNOT_PARSE_DOMAIN_VALUE_FROM_STRING",
+	ErrSettingConstraintViolation:                   "SETTING_CONSTRAINT_VIOLATION",
+	ErrViolatedConstraint:                           "VIOLATED_CONSTRAINT",
+	ErrDeadlockAvoided:                              "DEADLOCK_AVOIDED",
+	ErrAccessDenied:                                 "ACCESS_DENIED",
+	ErrS3Error:                                      "S3_ERROR",
+	ErrAuthenticationFailed:                         "AUTHENTICATION_FAILED",
+	ErrKeeperException:                              "KEEPER_EXCEPTION",
+	ErrPocoException:                                "POCO_EXCEPTION",
+	ErrStdException:                                 "STD_EXCEPTION",
+	ErrUnknownException:                             "UNKNOWN_EXCEPTION",
+	ErrConditionalTreeParentNotFound:                "CONDITIONAL_TREE_PARENT_NOT_FOUND",
+	ErrIllegalProjectionManipulator:                 "ILLEGAL_PROJECTION_MANIPULATOR",
+}
@@ -37,6 +37,22 @@ func (e *Exception) Error() string {
 	return fmt.Sprintf("code: %d, message: %s", e.Code, e.Message)
 }
 
+// ErrorCode returns the code of the exception as an ErrorCode
+func (e *Exception) ErrorCode() ErrorCode {
+	return ErrorCode(e.Code)
+}
+
+// Is matches an ErrorCode with the code of the exception, or an *Exception with the same code
+func (e *Exception) Is(target error) bool {
+	switch target := target.(type) {
+	case ErrorCode:
+		return e.Code == int32(target)
+	case *Exception:
+		return target != nil && e.Code == target.Code
+	}
+	return false
+}
+
 func (e *Exception) Decode(reader *proto.Reader) (err error) {
 	var exceptions []Exception
 	for {
@@ -19,12 +19,43 @@ package proto
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+
 	chproto "github.com/ClickHouse/ch-go/proto"
 )
 
+// Kinds of column default expressions
+const (
+	ColumnDefault      = "DEFAULT"
+	ColumnMaterialized = "MATERIALIZED"
+	ColumnAlias        = "ALIAS"
+	ColumnEphemeral    = "EPHEMERAL"
+)
+
+// TableColumns is sent before the header block of an INSERT, Second holds the description of the table columns
 type TableColumns struct {
-	First  string
-	Second string
+	First   string
+	Second  string
+	Columns []TableColumn
+}
+
+// TableColumn describes a column of a table and its default expression
+type TableColumn struct {
+	Name              string
+	Type              string
+	DefaultKind       string // DEFAULT, MATERIALIZED, ALIAS or EPHEMERAL, empty when the column has no default expression
+	DefaultExpression string
+}
+
+// Insertable reports whether the column can be written by an INSERT, MATERIALIZED and ALIAS columns can't
+func (c TableColumn) Insertable() bool {
+	return c.DefaultKind != ColumnMaterialized && c.DefaultKind != ColumnAlias
+}
+
+// HasDefault reports whether the server computes the column from an expression when it's omitted from an INSERT
+func (c TableColumn) HasDefault() bool {
+	return c.DefaultKind == ColumnDefault || c.DefaultKind == ColumnEphemeral
 }
 
 func (t *TableColumns) Decode(reader *chproto.Reader, revision uint64) (err error) {
@@ -37,6 +68,102 @@ func (t *TableColumns) Decode(reader *chproto.Reader, revision uint64) (err erro
 	return nil
 }
 
+// ParseColumns fills Columns from the description in Second, Columns is left empty when it can't be parsed
+func (t *TableColumns) ParseColumns() (err error) {
+	if t.Columns, err = ParseTableColumns(t.Second); err != nil {
+		t.Columns = nil
+	}
+	return err
+}
+
 func (t *TableColumns) String() string {
 	return fmt.Sprintf("first=%s, second=%s", t.First, t.Second)
 }
+
+// ParseTableColumns parses the text format of ColumnsDescription, see ColumnsDescription::writeText:
+//
+//	columns format version: 1
+//	2 columns:
+//	`id` UInt64
+//	`created` DateTime	DEFAULT	now()
+func ParseTableColumns(text string) ([]TableColumn, error) {
+	if len(text) == 0 {
+		return nil, nil
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if len(lines) < 2 || lines[0] != "columns format version: 1" {
+		return nil, fmt.Errorf("unexpected table columns format: %q", text)
+	}
+	count, err := strconv.Atoi(strings.TrimSuffix(lines[1], " columns:"))
+	if err != nil || count != len(lines)-2 {
+		return nil, fmt.Errorf("unexpected table columns count: %q", lines[1])
+	}
+	columns := make([]TableColumn, 0, count)
+	for _, line := range lines[2:] {
+		column, err := parseTableColumn(line)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func parseTableColumn(line string) (column TableColumn, err error) {
+	if !strings.HasPrefix(line, "`") {
+		return column, fmt.Errorf("unexpected table column: %q", line)
+	}
+	// the name is back quoted, the other fields are escaped and separated by tabs
+	end := 1
+	for ; end < len(line) && line[end] != '`'; end++ {
+		if line[end] == '\\' {
+			end++
+		}
+	}
+	if end >= len(line) || !strings.HasPrefix(line[end+1:], " ") {
+		return column, fmt.Errorf("unexpected table column: %q", line)
+	}
+	column.Name = unescapeText(line[1:end])
+	fields := strings.Split(line[end+2:], "\t")
+	column.Type = unescapeText(fields[0])
+	if len(fields) >= 3 {
+		switch kind := fields[1]; kind {
+		case ColumnDefault, ColumnMaterialized, ColumnAlias, ColumnEphemeral:
+			column.DefaultKind = kind
+			column.DefaultExpression = unescapeText(fields[2])
+		}
+	}
+	return column, nil
+}
+
+// unescapeText reverses the escaping of writeEscapedString and writeBackQuotedString
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case '0':
+			b.WriteByte(0)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
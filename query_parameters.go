@@ -18,22 +18,47 @@
 package clickhouse
 
 import (
-	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
-	"github.com/pkg/errors"
+	std_driver "database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 )
 
 var (
 	ErrExpectedStringValueInNamedValueForQueryParameter = errors.New("expected string value in NamedValue for query parameter")
 
 	hasQueryParamsRe = regexp.MustCompile("{.+:.+}")
+	queryParamsRe    = regexp.MustCompile(`{\s*([a-zA-Z_][0-9a-zA-Z_]*)\s*:\s*([^{}]+?)\s*}`)
 )
 
+// Param binds a value to a server side {name:Type} query parameter. The value is serialized
+// according to the placeholder type, e.g.
+//
+//	conn.Query(ctx, "SELECT * FROM t WHERE id IN {ids:Array(UInt64)}", clickhouse.Param("ids", []uint64{1, 2, 3}))
+//
+// Slices and arrays map to Array and Tuple, maps to Map, time.Time to Date, DateTime and DateTime64,
+// nil and nil pointers to NULL. Unlike Named, string values are escaped rather than sent verbatim.
+func Param(name string, value any) driver.NamedParam {
+	return driver.NamedParam{
+		Name:  name,
+		Value: value,
+	}
+}
+
 func bindQueryOrAppendParameters(paramsProtocolSupport bool, options *QueryOptions, query string, timezone *time.Location, args ...any) (string, error) {
-	// prefer native query parameters over legacy bind if query parameters provided explicit
-	if len(options.parameters) > 0 {
-		return query, nil
+	if err := options.bindParams(query, timezone); err != nil {
+		return "", err
 	}
 
 	// validate if query contains a {<name>:<data type>} syntax, so it's intentional use of query parameters
@@ -41,20 +66,311 @@ func bindQueryOrAppendParameters(paramsProtocolSupport bool, options *QueryOptio
 	if paramsProtocolSupport &&
 		len(args) > 0 &&
 		hasQueryParamsRe.MatchString(query) {
-		options.parameters = make(Parameters, len(args))
+		var (
+			types      = queryParameterTypes(query)
+			parameters = make(Parameters, len(options.parameters)+len(args))
+		)
+		for k, v := range options.parameters {
+			parameters[k] = v
+		}
 		for _, a := range args {
-			if p, ok := a.(driver.NamedValue); ok {
+			switch p := a.(type) {
+			case driver.NamedValue:
+				// strings are sent verbatim, they may hold a value formatted by the caller
 				if str, ok := p.Value.(string); ok {
-					options.parameters[p.Name] = str
+					parameters[p.Name] = str
 					continue
 				}
+				value, err := formatQueryParameter(timezone, types[p.Name], p.Value)
+				if err != nil {
+					return "", errors.Wrapf(err, "query parameter %s", p.Name)
+				}
+				parameters[p.Name] = value
+				continue
+			case driver.NamedParam:
+				value, err := formatQueryParameter(timezone, types[p.Name], p.Value)
+				if err != nil {
+					return "", errors.Wrapf(err, "query parameter %s", p.Name)
+				}
+				parameters[p.Name] = value
+				continue
 			}
 
 			return "", ErrExpectedStringValueInNamedValueForQueryParameter
 		}
+		options.parameters = parameters
 
 		return query, nil
 	}
 
+	// prefer native query parameters over legacy bind if query parameters provided explicit
+	if len(options.parameters) > 0 {
+		return query, nil
+	}
+
 	return bind(timezone, query, args...)
 }
+
+// bindParams formats the parameters set with WithParams for the placeholders of the query
+func (o *QueryOptions) bindParams(query string, timezone *time.Location) error {
+	if len(o.params) == 0 {
+		return nil
+	}
+	var (
+		types      = queryParameterTypes(query)
+		parameters = make(Parameters, len(o.parameters)+len(o.params))
+	)
+	// the map is shared by every query using the context, don't modify it
+	for k, v := range o.parameters {
+		parameters[k] = v
+	}
+	for _, p := range o.params {
+		value, err := formatQueryParameter(timezone, types[p.Name], p.Value)
+		if err != nil {
+			return errors.Wrapf(err, "query parameter %s", p.Name)
+		}
+		parameters[p.Name] = value
+	}
+	o.parameters, o.params = parameters, nil
+	return nil
+}
+
+// queryParameterTypes returns the type of every {name:Type} placeholder of the query
+func queryParameterTypes(query string) map[string]string {
+	types := make(map[string]string)
+	for _, match := range queryParamsRe.FindAllStringSubmatch(query, -1) {
+		types[match[1]] = match[2]
+	}
+	return types
+}
+
+// formatQueryParameter renders a value in the text format the server parses a query parameter of the given type from.
+// The type may be empty when the parameter has no placeholder, the value then picks the format.
+func formatQueryParameter(tz *time.Location, typ string, value any) (string, error) {
+	return formatParameterValue(tz, typ, value, false)
+}
+
+var (
+	parameterEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+	parameterQuoter  = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+)
+
+// formatParameterValue writes the escaped text of a value, or its quoted text when it's nested in an Array, Tuple or Map
+func formatParameterValue(tz *time.Location, typ string, value any, quoted bool) (string, error) {
+	var err error
+	if valuer, ok := value.(std_driver.Valuer); ok {
+		if value, err = valuer.Value(); err != nil {
+			return "", err
+		}
+	}
+	typ = unwrapParameterType(strings.TrimSpace(typ))
+	str := func(v string) string {
+		if quoted {
+			return "'" + parameterQuoter.Replace(v) + "'"
+		}
+		return parameterEscaper.Replace(v)
+	}
+	switch v := value.(type) {
+	case nil:
+		if quoted {
+			return "NULL", nil
+		}
+		return `\N`, nil
+	case string:
+		return str(v), nil
+	case []byte:
+		if !strings.HasPrefix(typ, "Array") {
+			return str(string(v)), nil
+		}
+	case bool:
+		if strings.HasPrefix(typ, "Int") || strings.HasPrefix(typ, "UInt") {
+			if v {
+				return "1", nil
+			}
+			return "0", nil
+		}
+		return strconv.FormatBool(v), nil
+	case float32:
+		return formatParameterFloat(float64(v), 32), nil
+	case float64:
+		return formatParameterFloat(v, 64), nil
+	case time.Time:
+		return str(formatParameterTime(tz, typ, v)), nil
+	case uuid.UUID:
+		return str(v.String()), nil
+	case decimal.Decimal:
+		return v.String(), nil
+	case *big.Int:
+		if v == nil {
+			return formatParameterValue(tz, typ, nil, quoted)
+		}
+		return v.String(), nil
+	}
+
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return formatParameterValue(tz, typ, nil, quoted)
+		}
+		return formatParameterValue(tz, typ, v.Elem().Interface(), quoted)
+	case reflect.String:
+		return str(v.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() && !quoted && !strings.HasPrefix(typ, "Array") {
+			return `\N`, nil
+		}
+		name, params := splitParameterType(typ)
+		elements := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			var elementType string
+			switch {
+			case name == "Array" && len(params) == 1:
+				elementType = params[0]
+			case name == "Tuple" && i < len(params):
+				elementType = params[i]
+			}
+			element, err := formatParameterValue(tz, elementType, v.Index(i).Interface(), true)
+			if err != nil {
+				return "", err
+			}
+			elements = append(elements, element)
+		}
+		if name == "Tuple" {
+			return "(" + strings.Join(elements, ",") + ")", nil
+		}
+		return "[" + strings.Join(elements, ",") + "]", nil
+	case reflect.Map:
+		var keyType, valueType string
+		if name, params := splitParameterType(typ); name == "Map" && len(params) == 2 {
+			keyType, valueType = params[0], params[1]
+		}
+		entries := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			k, err := formatParameterValue(tz, keyType, key.Interface(), true)
+			if err != nil {
+				return "", err
+			}
+			val, err := formatParameterValue(tz, valueType, v.MapIndex(key).Interface(), true)
+			if err != nil {
+				return "", err
+			}
+			entries = append(entries, k+":"+val)
+		}
+		// map iteration order is random, sort so the same map always gives the same parameter
+		sort.Strings(entries)
+		return "{" + strings.Join(entries, ",") + "}", nil
+	case reflect.Bool:
+		return formatParameterValue(tz, typ, v.Bool(), quoted)
+	case reflect.Float32, reflect.Float64:
+		return formatParameterFloat(v.Float(), v.Type().Bits()), nil
+	}
+	if stringer, ok := value.(fmt.Stringer); ok {
+		return str(stringer.String()), nil
+	}
+	return "", fmt.Errorf("unsupported parameter type %T", value)
+}
+
+func formatParameterFloat(v float64, bits int) string {
+	switch {
+	case math.IsNaN(v):
+		return "nan"
+	case math.IsInf(v, 1):
+		return "inf"
+	case math.IsInf(v, -1):
+		return "-inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, bits)
+}
+
+// formatParameterTime formats a time for a Date, DateTime or DateTime64 parameter. Date times are converted
+// to the timezone of the type or to the server timezone, which the server parses them in.
+func formatParameterTime(tz *time.Location, typ string, v time.Time) string {
+	name, params := splitParameterType(typ)
+	switch name {
+	case "Date", "Date32":
+		return v.Format("2006-01-02")
+	case "DateTime", "DateTime64":
+		precision := 0
+		if name == "DateTime64" && len(params) > 0 {
+			precision, _ = strconv.Atoi(params[0])
+			params = params[1:]
+		}
+		if len(params) > 0 {
+			if loc, err := time.LoadLocation(strings.Trim(params[0], "'")); err == nil {
+				tz = loc
+			}
+		}
+		if tz != nil {
+			v = v.In(tz)
+		}
+		if precision > 0 {
+			return v.Format("2006-01-02 15:04:05." + strings.Repeat("0", precision))
+		}
+		return v.Format("2006-01-02 15:04:05")
+	}
+	if tz != nil {
+		v = v.In(tz)
+	}
+	if v.Nanosecond() != 0 {
+		return v.Format("2006-01-02 15:04:05.999999999")
+	}
+	return v.Format("2006-01-02 15:04:05")
+}
+
+// unwrapParameterType strips the wrappers that don't change the text format of a value
+func unwrapParameterType(typ string) string {
+	for {
+		name, params := splitParameterType(typ)
+		if (name != "Nullable" && name != "LowCardinality") || len(params) != 1 {
+			return typ
+		}
+		typ = params[0]
+	}
+}
+
+// splitParameterType splits a type into its name and top level parameters, e.g. Map(String, Array(UInt8))
+// gives Map and [String Array(UInt8)]. The element names of a named Tuple are dropped.
+func splitParameterType(typ string) (string, []string) {
+	start := strings.Index(typ, "(")
+	if start <= 0 || !strings.HasSuffix(typ, ")") {
+		return typ, nil
+	}
+	var (
+		name   = strings.TrimSpace(typ[:start])
+		body   = typ[start+1 : len(typ)-1]
+		params []string
+		depth  int
+		quoted bool
+		last   int
+	)
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case c == '\\' && quoted:
+			i++
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			params = append(params, strings.TrimSpace(body[last:i]))
+			last = i + 1
+		}
+	}
+	params = append(params, strings.TrimSpace(body[last:]))
+	if name == "Tuple" {
+		for i, param := range params {
+			// Tuple(a UInt8, b String)
+			if space := strings.IndexAny(param, " \t"); space > 0 && !strings.ContainsAny(param[:space], "('") {
+				params[i] = strings.TrimSpace(param[space:])
+			}
+		}
+	}
+	return name, params
+}
@@ -0,0 +1,140 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatQueryParameter(t *testing.T) {
+	var (
+		str      = "x"
+		nilStr   *string
+		tz, _    = time.LoadLocation("Europe/Berlin")
+		datetime = time.Date(2024, 2, 29, 23, 30, 15, 123456789, time.UTC)
+	)
+	testCases := []struct {
+		typ      string
+		value    any
+		expected string
+	}{
+		{"UInt64", uint64(42), "42"},
+		{"Int8", int8(-8), "-8"},
+		{"Float64", 1.5, "1.5"},
+		{"Float32", float32(math.Inf(-1)), "-inf"},
+		{"Float64", math.NaN(), "nan"},
+		{"Bool", true, "true"},
+		{"UInt8", true, "1"},
+		{"String", "it's a\ttab\\", `it's a\ttab\\`},
+		{"String", []byte("bytes"), "bytes"},
+		{"Nullable(String)", &str, "x"},
+		{"Nullable(String)", nilStr, `\N`},
+		{"Nullable(String)", nil, `\N`},
+		{"LowCardinality(Nullable(String))", "a\nb", `a\nb`},
+		{"UUID", uuid.MustParse("a2f5b2f0-5c3a-4f7a-9d6e-0c3f1b2a3c4d"), "a2f5b2f0-5c3a-4f7a-9d6e-0c3f1b2a3c4d"},
+		{"Decimal(10, 2)", decimal.RequireFromString("12.34"), "12.34"},
+		{"Date", datetime, "2024-02-29"},
+		{"Date32", datetime, "2024-02-29"},
+		{"DateTime", datetime, "2024-03-01 00:30:15"},
+		{"DateTime('UTC')", datetime, "2024-02-29 23:30:15"},
+		{"DateTime64(3)", datetime, "2024-03-01 00:30:15.123"},
+		{"DateTime64(6, 'UTC')", datetime, "2024-02-29 23:30:15.123456"},
+		{"", datetime, "2024-03-01 00:30:15.123456789"},
+		{"Array(UInt64)", []uint64{1, 2, 3}, "[1,2,3]"},
+		{"Array(String)", []string{"a", "b'c", `d\`}, `['a','b\'c','d\\']`},
+		{"Array(Nullable(String))", []*string{&str, nil}, "['x',NULL]"},
+		{"Array(Array(Date))", [][]time.Time{{datetime}, {}}, "[['2024-02-29'],[]]"},
+		{"Array(UInt8)", []byte{1, 2}, "[1,2]"},
+		{"Tuple(String, DateTime('UTC'))", []any{"a", datetime}, "('a','2024-02-29 23:30:15')"},
+		{"Tuple(a UInt8, b Date)", [2]any{uint8(1), datetime}, "(1,'2024-02-29')"},
+		{"Map(String, Array(UInt8))", map[string][]uint8{"b": {2}, "a": {1}}, "{'a':[1],'b':[2]}"},
+		{"Map(UInt8, String)", map[uint8]string{1: "x"}, "{1:'x'}"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.typ, func(t *testing.T) {
+			actual, err := formatQueryParameter(tz, testCase.typ, testCase.value)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+
+	_, err := formatQueryParameter(tz, "Tuple(UInt8)", struct{ A uint8 }{1})
+	assert.EqualError(t, err, "unsupported parameter type struct { A uint8 }")
+}
+
+func TestSplitParameterType(t *testing.T) {
+	testCases := []struct {
+		typ    string
+		name   string
+		params []string
+	}{
+		{"String", "String", nil},
+		{"Array(UInt8)", "Array", []string{"UInt8"}},
+		{"Map(String, Array(Tuple(UInt8, String)))", "Map", []string{"String", "Array(Tuple(UInt8, String))"}},
+		{"DateTime64(3, 'Europe/Berlin')", "DateTime64", []string{"3", "'Europe/Berlin'"}},
+		{"Enum8('a,b' = 1, 'c' = 2)", "Enum8", []string{"'a,b' = 1", "'c' = 2"}},
+		{"Tuple(a UInt8, b Nullable(String), DateTime64(3, 'UTC'))", "Tuple", []string{"UInt8", "Nullable(String)", "DateTime64(3, 'UTC')"}},
+	}
+	for _, testCase := range testCases {
+		name, params := splitParameterType(testCase.typ)
+		assert.Equal(t, testCase.name, name)
+		assert.Equal(t, testCase.params, params)
+	}
+}
+
+func TestBindQueryParameters(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	ctx := Context(context.Background(),
+		WithParameters(Parameters{"raw": "['a']"}),
+		WithParams(Param("day", day)),
+	)
+	options := queryOptions(ctx)
+	query, err := bindQueryOrAppendParameters(true, &options, "SELECT {ids:Array(UInt64)}, {day:Date}, {name:String}, {raw:Array(String)}", time.UTC,
+		Param("ids", []uint64{1, 2}),
+		Named("name", `verbatim\t`),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT {ids:Array(UInt64)}, {day:Date}, {name:String}, {raw:Array(String)}", query)
+	assert.Equal(t, Parameters{
+		"ids":  "[1,2]",
+		"day":  "2024-01-02",
+		"name": `verbatim\t`,
+		"raw":  "['a']",
+	}, options.parameters)
+	// the parameters of the context are left untouched
+	assert.Equal(t, Parameters{"raw": "['a']"}, queryOptions(ctx).parameters)
+
+	options = queryOptions(context.Background())
+	_, err = bindQueryOrAppendParameters(true, &options, "SELECT {v:UInt8}", time.UTC, 1)
+	assert.ErrorIs(t, err, ErrExpectedStringValueInNamedValueForQueryParameter)
+
+	// without server side parameters the legacy client side bind is used
+	options = queryOptions(context.Background())
+	query, err = bindQueryOrAppendParameters(true, &options, "SELECT @v", time.UTC, Param("v", "a"))
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT 'a'", query)
+}
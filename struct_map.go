@@ -53,17 +53,9 @@ func (m *structMap) Map(op string, columns []string, s any, ptr bool) ([]any, er
 	}
 
 	var (
-		index  map[string][]int
+		index  = m.index(t)
 		values = make([]any, 0, len(columns))
 	)
-
-	switch idx, found := m.cache.Load(t); {
-	case found:
-		index = idx.(map[string][]int)
-	default:
-		index = structIdx(t)
-		m.cache.Store(t, index)
-	}
 	for _, name := range columns {
 		idx, found := index[name]
 		if !found {
@@ -82,6 +74,16 @@ func (m *structMap) Map(op string, columns []string, s any, ptr bool) ([]any, er
 	return values, nil
 }
 
+// index returns the field indexes of a struct type by column name
+func (m *structMap) index(t reflect.Type) map[string][]int {
+	if idx, found := m.cache.Load(t); found {
+		return idx.(map[string][]int)
+	}
+	index := structIdx(t)
+	m.cache.Store(t, index)
+	return index
+}
+
 func structIdx(t reflect.Type) map[string][]int {
 	fields := make(map[string][]int)
 	for i := 0; i < t.NumField(); i++ {
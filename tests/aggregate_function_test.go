@@ -0,0 +1,109 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateFunction(t *testing.T) {
+	conn, err := GetNativeConnection(nil, nil, &clickhouse.Compression{
+		Method: clickhouse.CompressionLZ4,
+	})
+	ctx := context.Background()
+	require.NoError(t, err)
+	const ddl = `
+		CREATE TABLE %s (
+			  id   UInt64
+			, cnt  AggregateFunction(count)
+			, total AggregateFunction(sum, UInt32)
+			, low  AggregateFunction(min, Int64)
+			, mean AggregateFunction(avg, Float64)
+			, uniq AggregateFunction(uniq, String)
+			, ids  AggregateFunction(groupBitmap, UInt32)
+		) Engine AggregatingMergeTree() ORDER BY id
+		`
+	defer func() {
+		conn.Exec(ctx, "DROP TABLE IF EXISTS test_aggregate_function_source")
+		conn.Exec(ctx, "DROP TABLE IF EXISTS test_aggregate_function_copy")
+	}()
+	for _, table := range []string{"test_aggregate_function_source", "test_aggregate_function_copy"} {
+		require.NoError(t, conn.Exec(ctx, fmt.Sprintf(ddl, table)))
+	}
+	require.NoError(t, conn.Exec(ctx, `
+		INSERT INTO test_aggregate_function_source
+		SELECT
+			  number % 2
+			, countState()
+			, sumState(toUInt32(number))
+			, minState(toInt64(number) - 10)
+			, avgState(toFloat64(number))
+			, uniqState(toString(number % 7))
+			, groupBitmapState(toUInt32(number * 1000))
+		FROM numbers(10000)
+		GROUP BY number % 2
+	`))
+
+	rows, err := conn.Query(ctx, "SELECT * FROM test_aggregate_function_source ORDER BY id")
+	require.NoError(t, err)
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_aggregate_function_copy")
+	require.NoError(t, err)
+	for rows.Next() {
+		var (
+			id                                     uint64
+			count, total, low, mean, uniq, idState []byte
+		)
+		require.NoError(t, rows.Scan(&id, &count, &total, &low, &mean, &uniq, &idState))
+		require.NoError(t, batch.Append(id, count, total, low, mean, uniq, idState))
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, batch.Send())
+
+	var (
+		source, copied string
+		query          = "SELECT groupArray((id, finalizeAggregation(cnt), finalizeAggregation(total), finalizeAggregation(low), finalizeAggregation(mean), finalizeAggregation(uniq), finalizeAggregation(ids)))::String FROM (SELECT * FROM %s ORDER BY id)"
+	)
+	require.NoError(t, conn.QueryRow(ctx, fmt.Sprintf(query, "test_aggregate_function_source")).Scan(&source))
+	require.NoError(t, conn.QueryRow(ctx, fmt.Sprintf(query, "test_aggregate_function_copy")).Scan(&copied))
+	assert.Equal(t, source, copied)
+
+	var (
+		count       uint64
+		total       uint64
+		low         int64
+		mean        float64
+		ids         []uint32
+		cardinality uint64
+	)
+	require.NoError(t, conn.QueryRow(ctx, "SELECT cnt, total, low, mean, ids, ids FROM test_aggregate_function_copy WHERE id = 0").
+		Scan(&count, &total, &low, &mean, &ids, &cardinality))
+	assert.Equal(t, uint64(5000), count)
+	assert.Equal(t, uint64(24995000), total)
+	assert.Equal(t, int64(-10), low)
+	assert.Equal(t, 4999.0, mean)
+	assert.Len(t, ids, 5000)
+	assert.Equal(t, uint32(0), ids[0])
+	assert.Equal(t, uint32(9998000), ids[4999])
+	assert.Equal(t, uint64(5000), cardinality)
+}
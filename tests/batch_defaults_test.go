@@ -0,0 +1,113 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchColumnDefaults(t *testing.T) {
+	env, err := GetTestEnvironment(testSet)
+	require.NoError(t, err)
+	opts := ClientOptionsFromEnv(env, clickhouse.Settings{}, false)
+	conn, err := clickhouse.Open(&opts)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx := context.Background()
+	const ddl = `
+		CREATE TABLE test_batch_column_defaults (
+			  id      UInt64
+			, created DateTime DEFAULT toDateTime('2024-01-02 03:04:05', 'UTC')
+			, label   String DEFAULT concat('id-', toString(id))
+			, day     Date MATERIALIZED toDate(created)
+			, name    String ALIAS concat('name-', toString(id))
+		) Engine MergeTree() ORDER BY id`
+	require.NoError(t, conn.Exec(ctx, "DROP TABLE IF EXISTS test_batch_column_defaults"))
+	require.NoError(t, conn.Exec(ctx, ddl))
+	defer conn.Exec(ctx, "DROP TABLE IF EXISTS test_batch_column_defaults")
+
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_batch_column_defaults")
+	require.NoError(t, err)
+
+	tableColumns := batch.(driver.TableColumnsBatch).TableColumns()
+	require.Len(t, tableColumns, 5)
+	assert.Equal(t, "created", tableColumns[1].Name)
+	assert.Equal(t, proto.ColumnDefault, tableColumns[1].DefaultKind)
+	assert.Equal(t, proto.ColumnMaterialized, tableColumns[3].DefaultKind)
+	assert.Equal(t, proto.ColumnAlias, tableColumns[4].DefaultKind)
+	// MATERIALIZED and ALIAS columns aren't part of the insert
+	assert.Equal(t, []string{"id", "created", "label"}, columnNames(batch.Columns()))
+	assert.Equal(t, []string{"day"}, batch.(driver.TableColumnsBatch).DefaultedColumns())
+
+	// the struct has neither created nor label, by default that's an error
+	type row struct {
+		ID   uint64 `ch:"id"`
+		Name string `ch:"name"`
+	}
+	assert.Error(t, batch.AppendStruct(&row{ID: 1}))
+	require.NoError(t, batch.Abort())
+
+	// unless the batch leaves them to the server
+	batch, err = conn.PrepareBatch(ctx, "INSERT INTO test_batch_column_defaults", driver.WithOmitDefaultedColumns())
+	require.NoError(t, err)
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, batch.AppendStruct(&row{ID: uint64(i)}))
+	}
+	assert.Equal(t, []string{"id"}, columnNames(batch.Columns()))
+	assert.ElementsMatch(t, []string{"created", "label", "day"}, batch.(driver.TableColumnsBatch).DefaultedColumns())
+	require.NoError(t, batch.Send())
+
+	var (
+		created time.Time
+		label   string
+		day     time.Time
+		count   uint64
+	)
+	require.NoError(t, conn.QueryRow(ctx, "SELECT count() FROM test_batch_column_defaults").Scan(&count))
+	assert.Equal(t, uint64(3), count)
+	require.NoError(t, conn.QueryRow(ctx, "SELECT created, label, day FROM test_batch_column_defaults WHERE id = 2").Scan(&created, &label, &day))
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), created.UTC())
+	assert.Equal(t, "id-2", label)
+	assert.Equal(t, "2024-01-02", day.Format("2006-01-02"))
+
+	// a missing field for a column without default is still an error
+	batch, err = conn.PrepareBatch(ctx, "INSERT INTO test_batch_column_defaults", driver.WithOmitDefaultedColumns())
+	require.NoError(t, err)
+	type noID struct {
+		Label string `ch:"label"`
+	}
+	assert.Error(t, batch.AppendStruct(&noID{Label: "x"}))
+	require.NoError(t, batch.Abort())
+}
+
+func columnNames[T interface{ Name() string }](columns []T) []string {
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.Name())
+	}
+	return names
+}
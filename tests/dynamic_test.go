@@ -0,0 +1,94 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamic(t *testing.T) {
+	conn, err := GetNativeConnection(clickhouse.Settings{
+		"allow_experimental_dynamic_type": true,
+	}, nil, &clickhouse.Compression{
+		Method: clickhouse.CompressionLZ4,
+	})
+	ctx := context.Background()
+	require.NoError(t, err)
+	if !CheckMinServerServerVersion(conn, 24, 8, 0) {
+		t.Skip("Dynamic type is not supported by this ClickHouse version")
+		return
+	}
+	const ddl = `
+			CREATE TABLE test_dynamic (
+				  id UInt64
+				, c  Dynamic
+			) Engine MergeTree() ORDER BY id
+		`
+	defer func() {
+		conn.Exec(ctx, "DROP TABLE IF EXISTS test_dynamic")
+	}()
+	require.NoError(t, conn.Exec(ctx, ddl))
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_dynamic")
+	require.NoError(t, err)
+	values := []any{
+		int64(42),
+		"test",
+		true,
+		[]string{"a", "b"},
+		map[string]int64{"key": 1},
+		nil,
+		clickhouse.NewDynamicWithType(uint16(7), "UInt16"),
+	}
+	for i, v := range values {
+		require.NoError(t, batch.Append(uint64(i), v))
+	}
+	require.NoError(t, batch.Send())
+
+	rows, err := conn.Query(ctx, "SELECT c, dynamicType(c) FROM test_dynamic ORDER BY id")
+	require.NoError(t, err)
+	var (
+		result []chcol.Dynamic
+		types  []string
+	)
+	for rows.Next() {
+		var (
+			v        chcol.Dynamic
+			typeName string
+		)
+		require.NoError(t, rows.Scan(&v, &typeName))
+		result = append(result, v)
+		types = append(types, typeName)
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, result, len(values))
+	assert.Equal(t, []string{"Int64", "String", "Bool", "Array(String)", "Map(String, Int64)", "None", "UInt16"}, types)
+	assert.Equal(t, int64(42), result[0].Any())
+	assert.Equal(t, "test", result[1].Any())
+	assert.Equal(t, true, result[2].Any())
+	assert.Equal(t, []string{"a", "b"}, result[3].Any())
+	assert.Equal(t, map[string]int64{"key": 1}, result[4].Any())
+	assert.True(t, result[5].Nil())
+	assert.Equal(t, uint16(7), result[6].Any())
+	assert.Equal(t, "UInt16", result[6].Type())
+}
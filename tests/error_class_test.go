@@ -0,0 +1,52 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorClass(t *testing.T) {
+	conn, err := GetNativeConnection(nil, nil, &clickhouse.Compression{
+		Method: clickhouse.CompressionLZ4,
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	err = conn.Exec(ctx, "SELECT * FROM table_that_does_not_exist")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, proto.ErrUnknownTable))
+	assert.Equal(t, clickhouse.ErrorClassPermanent, clickhouse.ClassifyError(err))
+
+	err = conn.Exec(ctx, "SELECT sleep(1) SETTINGS max_execution_time = 0.1, timeout_overflow_mode = 'throw'")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, proto.ErrTimeoutExceeded))
+	assert.Equal(t, clickhouse.ErrorClassTransient, clickhouse.ClassifyError(err))
+
+	err = conn.Exec(ctx, "SELECT count() FROM numbers(1000) SETTINGS max_rows_to_read = 10")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, proto.ErrTooManyRows))
+	assert.Equal(t, clickhouse.ErrorClassQuota, clickhouse.ClassifyError(err))
+}
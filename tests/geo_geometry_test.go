@@ -0,0 +1,86 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoGeometry(t *testing.T) {
+	conn, err := GetNativeConnection(nil, nil, &clickhouse.Compression{
+		Method: clickhouse.CompressionLZ4,
+	})
+	ctx := context.Background()
+	require.NoError(t, err)
+	if !CheckMinServerServerVersion(conn, 25, 9, 0) {
+		t.Skip(fmt.Errorf("unsupported clickhouse version"))
+		return
+	}
+	const ddl = `
+		CREATE TABLE test_geo_geometry (
+			  ID   UInt8
+			, Col1 Geometry
+		) Engine MergeTree() ORDER BY ID
+		`
+	defer func() {
+		conn.Exec(ctx, "DROP TABLE IF EXISTS test_geo_geometry")
+	}()
+	require.NoError(t, conn.Exec(ctx, ddl))
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_geo_geometry")
+	require.NoError(t, err)
+	ring := orb.Ring{orb.Point{0, 0}, orb.Point{1, 0}, orb.Point{0, 1}, orb.Point{0, 0}}
+	values := []orb.Geometry{
+		orb.Point{1, 2},
+		ring,
+		orb.LineString{orb.Point{1, 2}, orb.Point{3, 4}},
+		orb.MultiLineString{orb.LineString{orb.Point{1, 2}}, orb.LineString{orb.Point{3, 4}}},
+		orb.Polygon{ring},
+		orb.MultiPolygon{orb.Polygon{ring}, orb.Polygon{ring, ring}},
+		nil,
+	}
+	for i, v := range values {
+		require.NoError(t, batch.Append(uint8(i), v))
+	}
+	line := orb.LineString{orb.Point{5, 6}}
+	require.NoError(t, batch.Append(uint8(len(values)), &line))
+	values = append(values, line)
+	require.NoError(t, batch.Send())
+
+	rows, err := conn.Query(ctx, "SELECT Col1 FROM test_geo_geometry ORDER BY ID")
+	require.NoError(t, err)
+	var i int
+	for ; rows.Next(); i++ {
+		var col1 orb.Geometry
+		require.NoError(t, rows.Scan(&col1))
+		assert.Equal(t, values[i], col1)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, len(values), i)
+
+	var point orb.Point
+	require.NoError(t, conn.QueryRow(ctx, "SELECT Col1 FROM test_geo_geometry WHERE ID = 0").Scan(&point))
+	assert.Equal(t, orb.Point{1, 2}, point)
+	require.Error(t, conn.QueryRow(ctx, "SELECT Col1 FROM test_geo_geometry WHERE ID = 2").Scan(&point))
+}
@@ -0,0 +1,132 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoLineString(t *testing.T) {
+	conn, err := GetNativeConnection(nil, nil, &clickhouse.Compression{
+		Method: clickhouse.CompressionLZ4,
+	})
+	ctx := context.Background()
+	require.NoError(t, err)
+	if !CheckMinServerServerVersion(conn, 23, 10, 0) {
+		t.Skip(fmt.Errorf("unsupported clickhouse version"))
+		return
+	}
+	const ddl = `
+		CREATE TABLE test_geo_linestring (
+			  Col1 LineString
+			, Col2 Array(LineString)
+		) Engine MergeTree() ORDER BY tuple()
+		`
+	defer func() {
+		conn.Exec(ctx, "DROP TABLE IF EXISTS test_geo_linestring")
+	}()
+	require.NoError(t, conn.Exec(ctx, ddl))
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_geo_linestring")
+	require.NoError(t, err)
+	var (
+		col1Data = orb.LineString{
+			orb.Point{1, 2},
+			orb.Point{3, 4},
+		}
+		col2Data = []orb.LineString{
+			orb.LineString{
+				orb.Point{1, 2},
+				orb.Point{3, 4},
+			},
+			orb.LineString{
+				orb.Point{5, 6},
+			},
+		}
+	)
+	require.NoError(t, batch.Append(col1Data, col2Data))
+	require.NoError(t, batch.Append(&col1Data, col2Data))
+	require.Equal(t, 2, batch.Rows())
+	require.NoError(t, batch.Send())
+	rows, err := conn.Query(ctx, "SELECT * FROM test_geo_linestring")
+	require.NoError(t, err)
+	var (
+		col1 orb.LineString
+		col2 []orb.LineString
+	)
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&col1, &col2))
+	assert.Equal(t, col1Data, col1)
+	assert.Equal(t, col2Data, col2)
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&col1, &col2))
+	assert.Equal(t, col1Data, col1)
+	require.False(t, rows.Next())
+	require.NoError(t, rows.Err())
+}
+
+func TestGeoLineStringFlush(t *testing.T) {
+	conn, err := GetNativeConnection(nil, nil, &clickhouse.Compression{
+		Method: clickhouse.CompressionLZ4,
+	})
+	ctx := context.Background()
+	require.NoError(t, err)
+	if !CheckMinServerServerVersion(conn, 23, 10, 0) {
+		t.Skip(fmt.Errorf("unsupported clickhouse version"))
+		return
+	}
+	const ddl = `
+		CREATE TABLE test_geo_linestring_flush (
+			  Col1 LineString
+		) Engine MergeTree() ORDER BY tuple()
+		`
+	defer func() {
+		conn.Exec(ctx, "DROP TABLE test_geo_linestring_flush")
+	}()
+	require.NoError(t, conn.Exec(ctx, ddl))
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_geo_linestring_flush")
+	require.NoError(t, err)
+	vals := [1000]orb.LineString{}
+	for i := 0; i < 1000; i++ {
+		vals[i] = orb.LineString{
+			orb.Point{1, 2},
+			orb.Point{float64(i), 4},
+		}
+		require.NoError(t, batch.Append(vals[i]))
+		require.Equal(t, 1, batch.Rows())
+		require.NoError(t, batch.Flush())
+	}
+	require.Equal(t, 0, batch.Rows())
+	require.NoError(t, batch.Send())
+	rows, err := conn.Query(ctx, "SELECT * FROM test_geo_linestring_flush")
+	require.NoError(t, err)
+	i := 0
+	for rows.Next() {
+		var col1 orb.LineString
+		require.NoError(t, rows.Scan(&col1))
+		require.Equal(t, vals[i], col1)
+		i += 1
+	}
+	require.Equal(t, 1000, i)
+}
@@ -0,0 +1,63 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertFrom(t *testing.T) {
+	conn, err := GetNativeConnection(nil, nil, &clickhouse.Compression{
+		Method: clickhouse.CompressionLZ4,
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+	const ddl = `
+		CREATE TABLE test_insert_from (
+			  id   UInt64
+			, name String
+		) Engine MergeTree() ORDER BY id
+	`
+	defer func() {
+		conn.Exec(ctx, "DROP TABLE IF EXISTS test_insert_from")
+	}()
+	require.NoError(t, conn.Exec(ctx, ddl))
+
+	data := "1,\"a\"\n2,\"b\"\n3,\"c\"\n"
+	inserter, ok := conn.(driver.RawInserter)
+	require.True(t, ok)
+	require.NoError(t, inserter.InsertFrom(ctx, "INSERT INTO test_insert_from FORMAT CSV", strings.NewReader(data)))
+
+	var count uint64
+	require.NoError(t, conn.QueryRow(ctx, "SELECT count() FROM test_insert_from").Scan(&count))
+	assert.Equal(t, uint64(3), count)
+
+	err = inserter.InsertFrom(ctx, "INSERT INTO test_insert_from FORMAT CSV", strings.NewReader("not,a,row\n"))
+	require.Error(t, err)
+	var opErr *clickhouse.OpError
+	assert.ErrorAs(t, err, &opErr)
+	var exception *clickhouse.Exception
+	assert.ErrorAs(t, err, &exception)
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/stretchr/testify/assert"
@@ -57,3 +58,29 @@ func TestInterval(t *testing.T) {
 	assert.Equal(t, "1 Minute", col3)
 	assert.Equal(t, "5 Minutes", col4)
 }
+
+func TestIntervalDuration(t *testing.T) {
+	conn, err := GetNativeConnection(nil, nil, nil)
+	ctx := context.Background()
+	require.NoError(t, err)
+	var (
+		seconds  time.Duration
+		weeks    *time.Duration
+		months   clickhouse.Interval
+		bound    time.Duration
+		interval clickhouse.Interval
+	)
+	require.NoError(t, conn.QueryRow(ctx, "SELECT INTERVAL 90 SECOND, INTERVAL 2 WEEK, INTERVAL 3 MONTH").Scan(&seconds, &weeks, &months))
+	assert.Equal(t, 90*time.Second, seconds)
+	assert.Equal(t, 14*24*time.Hour, *weeks)
+	assert.Equal(t, clickhouse.NewInterval(3, clickhouse.IntervalMonth), months)
+	require.Error(t, conn.QueryRow(ctx, "SELECT INTERVAL 1 YEAR").Scan(&seconds))
+
+	require.NoError(t, conn.QueryRow(ctx, "SELECT ?, ?", 1500*time.Millisecond, clickhouse.NewInterval(2, clickhouse.IntervalQuarter)).Scan(&bound, &interval))
+	assert.Equal(t, 1500*time.Millisecond, bound)
+	assert.Equal(t, clickhouse.NewInterval(2, clickhouse.IntervalQuarter), interval)
+
+	var date time.Time
+	require.NoError(t, conn.QueryRow(ctx, "SELECT toDateTime('2024-01-31 00:00:00', 'UTC') + ?", 36*time.Hour).Scan(&date))
+	assert.Equal(t, time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC), date.UTC())
+}
@@ -0,0 +1,101 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jsonTestEvent struct {
+	ID   uint32 `json:"id"`
+	Name string `json:"name"`
+	User struct {
+		Email string   `json:"email"`
+		Roles []string `json:"roles"`
+	} `json:"user"`
+}
+
+func TestJSON(t *testing.T) {
+	conn, err := GetNativeConnection(clickhouse.Settings{
+		"allow_experimental_json_type": true,
+	}, nil, &clickhouse.Compression{
+		Method: clickhouse.CompressionLZ4,
+	})
+	ctx := context.Background()
+	require.NoError(t, err)
+	if !CheckMinServerServerVersion(conn, 24, 8, 0) {
+		t.Skip("JSON type is not supported by this ClickHouse version")
+		return
+	}
+	const ddl = `
+			CREATE TABLE test_json (
+				  id UInt64
+				, c  JSON(id UInt32, SKIP secret)
+			) Engine MergeTree() ORDER BY id
+		`
+	defer func() {
+		conn.Exec(ctx, "DROP TABLE IF EXISTS test_json")
+	}()
+	require.NoError(t, conn.Exec(ctx, ddl))
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_json")
+	require.NoError(t, err)
+
+	var event jsonTestEvent
+	event.ID, event.Name = 1, "login"
+	event.User.Email = "a@example.com"
+	event.User.Roles = []string{"admin"}
+	row := clickhouse.NewJSON()
+	row.SetValueAtPath("id", uint32(3))
+	row.SetValueAtPath("name", clickhouse.NewDynamicWithType(int16(5), "Int16"))
+	require.NoError(t, batch.Append(uint64(1), event))
+	require.NoError(t, batch.Append(uint64(2), `{"id": 2, "name": "logout", "secret": "x", "count": 10}`))
+	require.NoError(t, batch.Append(uint64(3), row))
+	require.NoError(t, batch.Send())
+
+	rows, err := conn.Query(ctx, "SELECT c FROM test_json ORDER BY id")
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	var first jsonTestEvent
+	require.NoError(t, rows.Scan(&first))
+	assert.Equal(t, event, first)
+
+	require.True(t, rows.Next())
+	var second clickhouse.JSON
+	require.NoError(t, rows.Scan(&second))
+	assert.Equal(t, map[string]any{
+		"id":    uint32(2),
+		"name":  "logout",
+		"count": int64(10),
+	}, second.ValuesByPath())
+
+	require.True(t, rows.Next())
+	var third string
+	require.NoError(t, rows.Scan(&third))
+	assert.JSONEq(t, `{"id": 3, "name": 5}`, third)
+	require.False(t, rows.Next())
+	require.NoError(t, rows.Err())
+
+	var path string
+	require.NoError(t, conn.QueryRow(ctx, "SELECT dynamicType(c.name) FROM test_json WHERE id = 3").Scan(&path))
+	assert.Equal(t, "Int16", path)
+}
@@ -0,0 +1,57 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBalancer(t *testing.T) {
+	env, err := GetNativeTestEnvironment()
+	require.NoError(t, err)
+	const badAddr = "127.0.0.1:9790"
+	options := ClientOptionsFromEnv(env, clickhouse.Settings{}, false)
+	options.Addr = append([]string{badAddr}, options.Addr...)
+	options.ConnOpenStrategy = clickhouse.ConnOpenInOrder
+	options.MaxIdleConns = 1
+	options.LoadBalancer = &clickhouse.LoadBalancer{
+		Policy:       clickhouse.LoadBalanceLeastConnections,
+		MaxFailures:  1,
+		EjectionTime: time.Hour,
+	}
+	conn, err := clickhouse.Open(&options)
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := context.Background()
+
+	// the failing host is ejected after its first failure and not dialed again
+	for i := 0; i < 5; i++ {
+		rows, err := conn.Query(ctx, "SELECT 1")
+		require.NoError(t, err)
+		defer rows.Close()
+	}
+	stats := conn.Stats()
+	assert.Equal(t, map[string]int64{badAddr: 1}, stats.DialFailures)
+	assert.Equal(t, int64(6), stats.DialCount)
+}
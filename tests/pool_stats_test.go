@@ -0,0 +1,111 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolStats(t *testing.T) {
+	env, err := GetNativeTestEnvironment()
+	require.NoError(t, err)
+	const badAddr = "127.0.0.1:9790"
+	var (
+		mutex    sync.Mutex
+		dials    = map[string]int{}
+		acquires int
+		releases int
+		closes   = map[clickhouse.ConnCloseReason]int{}
+	)
+	options := ClientOptionsFromEnv(env, clickhouse.Settings{}, false)
+	options.Addr = append([]string{badAddr}, options.Addr...)
+	options.ConnOpenStrategy = clickhouse.ConnOpenInOrder
+	options.MaxOpenConns = 1
+	options.MaxIdleConns = 1
+	options.OnDial = func(addr string, duration time.Duration, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		dials[addr]++
+	}
+	options.OnAcquire = func(duration time.Duration, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		acquires++
+	}
+	options.OnRelease = func(connID int, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		releases++
+	}
+	options.OnClose = func(connID int, reason clickhouse.ConnCloseReason) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		closes[reason]++
+	}
+	conn, err := clickhouse.Open(&options)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, conn.Ping(ctx))
+	stats := conn.Stats()
+	assert.Equal(t, int64(2), stats.DialCount)
+	assert.Equal(t, map[string]int64{badAddr: 1}, stats.DialFailures)
+
+	// hold the only connection so the next acquire has to wait for it
+	rows, err := conn.Query(ctx, "SELECT 1")
+	require.NoError(t, err)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		rows.Close()
+	}()
+	require.NoError(t, conn.Ping(ctx))
+	stats = conn.Stats()
+	assert.Equal(t, int64(1), stats.WaitCount)
+	assert.GreaterOrEqual(t, stats.WaitDuration, 50*time.Millisecond)
+
+	// a failed query closes its connection
+	require.Error(t, conn.Exec(ctx, "SELECT * FROM table_that_does_not_exist"))
+	stats = conn.Stats()
+	assert.Equal(t, int64(1), stats.ErrorClosed)
+
+	// acquiring times out while the only connection is in use
+	rows, err = conn.Query(ctx, "SELECT 1")
+	require.NoError(t, err)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	err = conn.Ping(timeoutCtx)
+	cancel()
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.NoError(t, rows.Close())
+
+	require.NoError(t, conn.Close())
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, 1, dials[badAddr])
+	assert.Equal(t, 6, acquires)
+	assert.Equal(t, 5, releases)
+	assert.Equal(t, 1, closes[clickhouse.ConnCloseError])
+	assert.Equal(t, 1, closes[clickhouse.ConnClosePoolClosed])
+}
@@ -21,9 +21,11 @@ import (
 	"context"
 	"fmt"
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestQueryParameters(t *testing.T) {
@@ -91,14 +93,88 @@ func TestQueryParameters(t *testing.T) {
 		assert.Equal(t, uint64(100), actualNum)
 	})
 
-	t.Run("named args with only strings supported", func(t *testing.T) {
+	t.Run("named args with non string values", func(t *testing.T) {
+		var actualNum uint64
+		var actualStr string
 		row := client.QueryRow(
 			ctx,
 			"SELECT {num:UInt64}, {str:String}",
 			clickhouse.Named("num", 42),
 			clickhouse.Named("str", "hello"),
 		)
-		require.ErrorIs(t, row.Err(), clickhouse.ErrExpectedStringValueInNamedValueForQueryParameter)
+		require.NoError(t, row.Err())
+		require.NoError(t, row.Scan(&actualNum, &actualStr))
+
+		assert.Equal(t, uint64(42), actualNum)
+		assert.Equal(t, "hello", actualStr)
+	})
+
+	t.Run("with typed parameters", func(t *testing.T) {
+		var (
+			str      = "it's a\ttab\\ and\nnewline"
+			datetime = time.Date(2024, 2, 29, 23, 30, 15, 123000000, time.UTC)
+
+			actualIDs      []uint64
+			actualStr      string
+			actualNullable *string
+			actualDate     time.Time
+			actualDateTime time.Time
+			actualMap      map[string][]string
+			actualTuple    []any
+			actualDecimal  decimal.Decimal
+		)
+		row := client.QueryRow(
+			ctx,
+			`SELECT
+				{ids:Array(UInt64)},
+				{str:String},
+				{nullable:Nullable(String)},
+				{date:Date},
+				{datetime:DateTime64(3, 'UTC')},
+				{map:Map(String, Array(String))},
+				{tuple:Tuple(String, Array(Date))},
+				{decimal:Decimal(10, 2)}`,
+			clickhouse.Param("ids", []uint64{1, 2, 3}),
+			clickhouse.Param("str", str),
+			clickhouse.Param("nullable", nil),
+			clickhouse.Param("date", datetime),
+			clickhouse.Param("datetime", datetime),
+			clickhouse.Param("map", map[string][]string{"a'b": {"c\\d", "e"}}),
+			clickhouse.Param("tuple", []any{"x", []time.Time{datetime}}),
+			clickhouse.Param("decimal", decimal.RequireFromString("12.34")),
+		)
+		require.NoError(t, row.Err())
+		require.NoError(t, row.Scan(&actualIDs, &actualStr, &actualNullable, &actualDate, &actualDateTime, &actualMap, &actualTuple, &actualDecimal))
+
+		assert.Equal(t, []uint64{1, 2, 3}, actualIDs)
+		assert.Equal(t, str, actualStr)
+		assert.Nil(t, actualNullable)
+		assert.Equal(t, "2024-02-29", actualDate.Format("2006-01-02"))
+		assert.Equal(t, datetime, actualDateTime.UTC())
+		assert.Equal(t, map[string][]string{"a'b": {"c\\d", "e"}}, actualMap)
+		require.Len(t, actualTuple, 2)
+		assert.Equal(t, "x", actualTuple[0])
+		assert.True(t, decimal.RequireFromString("12.34").Equal(actualDecimal))
+	})
+
+	t.Run("with typed parameters in batch", func(t *testing.T) {
+		if !CheckMinServerServerVersion(client, 23, 8, 0) {
+			t.Skip(fmt.Errorf("unsupported clickhouse version"))
+			return
+		}
+		require.NoError(t, client.Exec(ctx, "DROP TABLE IF EXISTS test_typed_query_parameters"))
+		require.NoError(t, client.Exec(ctx, "CREATE TABLE test_typed_query_parameters (id UInt64) Engine MergeTree() ORDER BY tuple()"))
+		defer client.Exec(ctx, "DROP TABLE IF EXISTS test_typed_query_parameters")
+
+		chCtx := clickhouse.Context(ctx, clickhouse.WithParams(clickhouse.Param("table", "test_typed_query_parameters")))
+		batch, err := client.PrepareBatch(chCtx, "INSERT INTO {table:Identifier}")
+		require.NoError(t, err)
+		require.NoError(t, batch.Append(uint64(1)))
+		require.NoError(t, batch.Send())
+
+		var count uint64
+		require.NoError(t, client.QueryRow(chCtx, "SELECT count() FROM {table:Identifier}").Scan(&count))
+		assert.Equal(t, uint64(1), count)
 	})
 
 	t.Run("unsupported arg type", func(t *testing.T) {
@@ -0,0 +1,67 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryStats(t *testing.T) {
+	conn, err := GetNativeConnection(nil, nil, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := context.Background()
+	require.NoError(t, conn.Exec(ctx, "DROP TABLE IF EXISTS test_query_stats"))
+	require.NoError(t, conn.Exec(ctx, "CREATE TABLE test_query_stats (id UInt64) Engine MergeTree() ORDER BY id"))
+	defer conn.Exec(ctx, "DROP TABLE IF EXISTS test_query_stats")
+
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_query_stats")
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, batch.Append(uint64(i)))
+	}
+	stats, err := batch.(driver.StatsBatch).SendWithStats()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), stats.WrittenRows)
+	assert.Equal(t, uint64(800), stats.WrittenBytes)
+
+	stats, err = conn.(driver.StatsExecer).ExecWithStats(ctx, "INSERT INTO test_query_stats SELECT number FROM numbers(50)")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(50), stats.WrittenRows)
+	assert.NotZero(t, stats.Elapsed)
+
+	rows, err := conn.Query(ctx, "SELECT id FROM test_query_stats WHERE id < 10")
+	require.NoError(t, err)
+	var count uint64
+	for rows.Next() {
+		count++
+	}
+	require.NoError(t, rows.Err())
+	stats = rows.(driver.StatsRows).Stats()
+	assert.Equal(t, uint64(10), count)
+	assert.Equal(t, uint64(150), stats.ReadRows)
+	assert.Equal(t, uint64(10), stats.ResultRows)
+	assert.NotZero(t, stats.Elapsed)
+	assert.NotEmpty(t, stats.ProfileEvents)
+	assert.Positive(t, stats.ProfileEvents["SelectedRows"])
+}
@@ -0,0 +1,88 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	env, err := GetNativeTestEnvironment()
+	require.NoError(t, err)
+	var attempts atomic.Int32
+	options := ClientOptionsFromEnv(env, clickhouse.Settings{}, false)
+	options.RetryPolicy = &clickhouse.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     10 * time.Millisecond,
+		Retryable: func(err error, class clickhouse.ErrorClass) bool {
+			attempts.Add(1)
+			return true
+		},
+		DeduplicationToken: true,
+	}
+	conn, err := clickhouse.Open(&options)
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := context.Background()
+
+	require.Error(t, conn.Exec(ctx, "SELECT throwIf(1)"))
+	assert.Equal(t, int32(2), attempts.Load())
+
+	attempts.Store(0)
+	require.Error(t, conn.Exec(clickhouse.Context(ctx, clickhouse.WithRetryPolicy(nil)), "SELECT throwIf(1)"))
+	assert.Equal(t, int32(0), attempts.Load())
+
+	const ddl = `
+		CREATE TABLE test_retry_policy (
+			  id UInt64
+			, CONSTRAINT positive CHECK id > 0
+		) Engine MergeTree() ORDER BY id
+		SETTINGS non_replicated_deduplication_window = 100
+	`
+	defer func() {
+		conn.Exec(ctx, "DROP TABLE IF EXISTS test_retry_policy")
+	}()
+	require.NoError(t, conn.Exec(ctx, ddl))
+
+	// a failed batch is resent from its retained block
+	attempts.Store(0)
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_retry_policy")
+	require.NoError(t, err)
+	require.NoError(t, batch.Append(uint64(0)))
+	require.Error(t, batch.Send())
+	assert.Equal(t, int32(2), attempts.Load())
+
+	// sending the same batch twice stores it once
+	batch, err = conn.PrepareBatch(ctx, "INSERT INTO test_retry_policy")
+	require.NoError(t, err)
+	for i := 1; i <= 10; i++ {
+		require.NoError(t, batch.Append(uint64(i)))
+	}
+	require.NoError(t, batch.Send())
+	require.NoError(t, batch.Send())
+	var count uint64
+	require.NoError(t, conn.QueryRow(ctx, "SELECT count() FROM test_retry_policy").Scan(&count))
+	assert.Equal(t, uint64(10), count)
+}
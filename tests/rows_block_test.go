@@ -0,0 +1,78 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowsNextBlock(t *testing.T) {
+	env, err := GetTestEnvironment(testSet)
+	require.NoError(t, err)
+	opts := ClientOptionsFromEnv(env, clickhouse.Settings{
+		"max_block_size": 100,
+	}, false)
+	conn, err := clickhouse.Open(&opts)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	rows, err := conn.Query(context.Background(), `
+		SELECT
+			  number
+			, toString(number)
+			, range(number % 3)
+		FROM numbers(1000)
+	`)
+	require.NoError(t, err)
+	var (
+		count int
+		sum   uint64
+	)
+	blocks, ok := rows.(driver.BlockRows)
+	require.True(t, ok)
+	for blocks.NextBlock() {
+		block := blocks.Block()
+		numbers := block.Columns[0].(*column.UInt64).Data()
+		strings := block.Columns[1].(*column.String).Data()
+		array := block.Columns[2].(*column.Array)
+		offsets, values := array.Offsets(0), array.Base().(*column.UInt64).Data()
+		require.Len(t, numbers, block.Rows())
+		var start uint64
+		for i, n := range numbers {
+			assert.Equal(t, strconv.FormatUint(n, 10), strings[i])
+			assert.Equal(t, n%3, offsets[i]-start)
+			for j, v := range values[start:offsets[i]] {
+				assert.Equal(t, uint64(j), v)
+			}
+			start = offsets[i]
+			sum += n
+		}
+		count += block.Rows()
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, 1000, count)
+	assert.Equal(t, uint64(999*1000/2), sum)
+}
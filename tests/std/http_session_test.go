@@ -0,0 +1,56 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package std
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	clickhouse_tests "github.com/ClickHouse/clickhouse-go/v2/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdHttpSession(t *testing.T) {
+	useSSL, err := strconv.ParseBool(clickhouse_tests.GetEnv("CLICKHOUSE_USE_SSL", "false"))
+	require.NoError(t, err)
+	conn, err := GetStdDSNConnection(clickhouse.HTTP, useSSL, url.Values{
+		"http_session":         []string{"true"},
+		"http_session_timeout": []string{"30s"},
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+	// a single pooled connection keeps every statement in the same session
+	conn.SetMaxOpenConns(1)
+
+	_, err = conn.Exec("CREATE TEMPORARY TABLE test_http_session (id UInt64)")
+	require.NoError(t, err)
+	_, err = conn.Exec("INSERT INTO test_http_session SELECT number FROM numbers(10)")
+	require.NoError(t, err)
+	_, err = conn.Exec("SET max_result_rows = 1234")
+	require.NoError(t, err)
+
+	var count uint64
+	require.NoError(t, conn.QueryRow("SELECT count() FROM test_http_session").Scan(&count))
+	assert.Equal(t, uint64(10), count)
+	var maxResultRows string
+	require.NoError(t, conn.QueryRow("SELECT getSetting('max_result_rows')").Scan(&maxResultRows))
+	assert.Equal(t, "1234", maxResultRows)
+}
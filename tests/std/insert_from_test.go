@@ -0,0 +1,59 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package std
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	clickhouse_tests "github.com/ClickHouse/clickhouse-go/v2/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdInsertFrom(t *testing.T) {
+	useSSL, err := strconv.ParseBool(clickhouse_tests.GetEnv("CLICKHOUSE_USE_SSL", "false"))
+	require.NoError(t, err)
+	for name, protocol := range map[string]clickhouse.Protocol{"Http": clickhouse.HTTP, "Native": clickhouse.Native} {
+		t.Run(name, func(t *testing.T) {
+			db, err := GetStdDSNConnection(protocol, useSSL, nil)
+			require.NoError(t, err)
+			defer db.Close()
+			ctx := context.Background()
+			table := "test_std_insert_from_" + strings.ToLower(name)
+			_, err = db.Exec("CREATE TABLE " + table + " (id UInt64, name String) Engine MergeTree() ORDER BY id")
+			require.NoError(t, err)
+			defer db.Exec("DROP TABLE IF EXISTS " + table)
+
+			conn, err := db.Conn(ctx)
+			require.NoError(t, err)
+			defer conn.Close()
+			data := strings.Repeat("{\"id\": 1, \"name\": \"a\"}\n", 1000)
+			require.NoError(t, conn.Raw(func(driverConn any) error {
+				return driverConn.(clickhouse.RawInserter).InsertFrom(ctx, "INSERT INTO "+table+" FORMAT JSONEachRow", strings.NewReader(data))
+			}))
+
+			var count uint64
+			require.NoError(t, db.QueryRow("SELECT count() FROM "+table).Scan(&count))
+			assert.Equal(t, uint64(1000), count)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package std
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	clickhouse_tests "github.com/ClickHouse/clickhouse-go/v2/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdInterval(t *testing.T) {
+	useSSL, err := strconv.ParseBool(clickhouse_tests.GetEnv("CLICKHOUSE_USE_SSL", "false"))
+	require.NoError(t, err)
+	for name, protocol := range map[string]clickhouse.Protocol{"Http": clickhouse.HTTP, "Native": clickhouse.Native} {
+		t.Run(name, func(t *testing.T) {
+			conn, err := GetStdDSNConnection(protocol, useSSL, nil)
+			require.NoError(t, err)
+			defer conn.Close()
+			// database/sql scans intervals as their text
+			var (
+				seconds, months string
+				null            *string
+			)
+			require.NoError(t, conn.QueryRow("SELECT INTERVAL 90 SECOND, INTERVAL 3 MONTH, if(number = 0, NULL, INTERVAL 1 DAY) FROM numbers(1)").Scan(&seconds, &months, &null))
+			assert.Equal(t, "90 Seconds", seconds)
+			assert.Equal(t, "3 Months", months)
+			assert.Nil(t, null)
+
+			rows, err := conn.Query("SELECT INTERVAL 1 YEAR")
+			require.NoError(t, err)
+			defer rows.Close()
+			types, err := rows.ColumnTypes()
+			require.NoError(t, err)
+			assert.Equal(t, "string", types[0].ScanType().String())
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func TestQueryParameters(t *testing.T) {
@@ -59,13 +60,39 @@ func TestQueryParameters(t *testing.T) {
 				assert.Equal(t, "hello", actualStr)
 			})
 
-			t.Run("named args with only strings supported", func(t *testing.T) {
+			t.Run("named args with non string values", func(t *testing.T) {
+				var actualNum uint64
+				var actualIDs []uint64
 				row := conn.QueryRow(
-					"SELECT {num:UInt64}, {str:String}",
+					"SELECT {num:UInt64}, {ids:Array(UInt64)}",
 					clickhouse.Named("num", 42),
-					clickhouse.Named("str", "hello"),
+					clickhouse.Named("ids", []uint64{1, 2}),
 				)
-				require.ErrorIs(t, row.Err(), clickhouse.ErrExpectedStringValueInNamedValueForQueryParameter)
+				require.NoError(t, row.Err())
+				require.NoError(t, row.Scan(&actualNum, &actualIDs))
+
+				assert.Equal(t, uint64(42), actualNum)
+				assert.Equal(t, []uint64{1, 2}, actualIDs)
+			})
+
+			t.Run("with typed parameters", func(t *testing.T) {
+				var (
+					actualStr  string
+					actualDate time.Time
+					actualMap  map[string]uint8
+				)
+				row := conn.QueryRow(
+					"SELECT {str:String}, {date:Date}, {map:Map(String, UInt8)}",
+					clickhouse.Param("str", "a\tb"),
+					clickhouse.Param("date", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+					clickhouse.Param("map", map[string]uint8{"a": 1}),
+				)
+				require.NoError(t, row.Err())
+				require.NoError(t, row.Scan(&actualStr, &actualDate, &actualMap))
+
+				assert.Equal(t, "a\tb", actualStr)
+				assert.Equal(t, "2024-01-02", actualDate.Format("2006-01-02"))
+				assert.Equal(t, map[string]uint8{"a": 1}, actualMap)
 			})
 
 			t.Run("with identifier type", func(t *testing.T) {
@@ -0,0 +1,60 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package std
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	clickhouse_tests "github.com/ClickHouse/clickhouse-go/v2/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdExecWithStats(t *testing.T) {
+	useSSL, err := strconv.ParseBool(clickhouse_tests.GetEnv("CLICKHOUSE_USE_SSL", "false"))
+	require.NoError(t, err)
+	for name, protocol := range map[string]clickhouse.Protocol{"Http": clickhouse.HTTP, "Native": clickhouse.Native} {
+		t.Run(name, func(t *testing.T) {
+			db, err := GetStdDSNConnection(protocol, useSSL, nil)
+			require.NoError(t, err)
+			defer db.Close()
+			ctx := context.Background()
+			table := "test_std_exec_with_stats_" + strings.ToLower(name)
+			_, err = db.Exec("CREATE TABLE " + table + " (id UInt64) Engine MergeTree() ORDER BY id")
+			require.NoError(t, err)
+			defer db.Exec("DROP TABLE IF EXISTS " + table)
+
+			conn, err := db.Conn(ctx)
+			require.NoError(t, err)
+			defer conn.Close()
+			var stats clickhouse.QueryStats
+			require.NoError(t, conn.Raw(func(driverConn any) error {
+				stats, err = driverConn.(clickhouse.StatsExecer).ExecWithStats(ctx, "INSERT INTO "+table+" SELECT number FROM numbers(1000)")
+				return err
+			}))
+			assert.Equal(t, uint64(1000), stats.ReadRows)
+			assert.Equal(t, uint64(1000), stats.WrittenRows)
+			assert.Equal(t, uint64(8000), stats.WrittenBytes)
+			assert.NotZero(t, stats.Elapsed)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package std
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	clickhouse_tests "github.com/ClickHouse/clickhouse-go/v2/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdQueryStream(t *testing.T) {
+	useSSL, err := strconv.ParseBool(clickhouse_tests.GetEnv("CLICKHOUSE_USE_SSL", "false"))
+	require.NoError(t, err)
+	db, err := GetStdDSNConnection(clickhouse.HTTP, useSSL, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var body []byte
+	require.NoError(t, conn.Raw(func(driverConn any) error {
+		stream, err := driverConn.(clickhouse.QueryStreamer).QueryStream(ctx, "CSV", "SELECT number, toString(number) FROM numbers(3)")
+		if err != nil {
+			return err
+		}
+		defer stream.Close()
+		body, err = io.ReadAll(stream)
+		return err
+	}))
+	assert.Equal(t, "0,\"0\"\n1,\"1\"\n2,\"2\"\n", string(body))
+
+	// an exception raised after the response has started is returned by Read
+	err = conn.Raw(func(driverConn any) error {
+		stream, err := driverConn.(clickhouse.QueryStreamer).QueryStream(ctx, "JSONEachRow",
+			"SELECT throwIf(number = 100000, 'stream failure') FROM numbers(200000) SETTINGS max_block_size = 1000, wait_end_of_query = 0, http_write_exception_in_output_format = 0")
+		if err != nil {
+			return err
+		}
+		defer stream.Close()
+		_, err = io.Copy(io.Discard, stream)
+		return err
+	})
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "stream failure"))
+}
@@ -0,0 +1,62 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package std
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	clickhouse_tests "github.com/ClickHouse/clickhouse-go/v2/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaKey(t *testing.T) {
+	useSSL, err := strconv.ParseBool(clickhouse_tests.GetEnv("CLICKHOUSE_USE_SSL", "false"))
+	require.NoError(t, err)
+	for _, protocol := range []clickhouse.Protocol{clickhouse.Native, clickhouse.HTTP} {
+		t.Run(fmt.Sprintf("%s protocol", protocol.String()), func(t *testing.T) {
+			conn, err := GetStdDSNConnection(protocol, useSSL, url.Values{"quota_key": []string{"connection_key"}})
+			require.NoError(t, err)
+			defer conn.Close()
+
+			assert.Equal(t, "connection_key", getQueryQuotaKey(t, conn, context.Background()))
+			// the per query quota key overrides the one of the connection
+			ctx := clickhouse.Context(context.Background(), clickhouse.WithQuotaKey("query_key"))
+			assert.Equal(t, "query_key", getQueryQuotaKey(t, conn, ctx))
+		})
+	}
+}
+
+func getQueryQuotaKey(t *testing.T, conn *sql.DB, ctx context.Context) string {
+	var queryID string
+	require.NoError(t, conn.QueryRowContext(ctx, "SELECT queryID()").Scan(&queryID))
+
+	_, err := conn.Exec("SYSTEM FLUSH LOGS")
+	require.NoError(t, err)
+
+	var quotaKey string
+	row := conn.QueryRow(fmt.Sprintf("SELECT quota_key FROM system.query_log WHERE query_id = '%s' AND type = 'QueryFinish'", queryID))
+	require.NoError(t, row.Scan(&quotaKey))
+	return quotaKey
+}
@@ -65,3 +65,62 @@ func TestStdWithTotals(t *testing.T) {
 	}
 	assert.Equal(t, 1, count)
 }
+
+func TestStdWithTotalsAndExtremes(t *testing.T) {
+	const query = `
+	SELECT
+		number AS n
+		, COUNT()
+	FROM (
+		SELECT number FROM system.numbers LIMIT 100
+	) GROUP BY n WITH TOTALS
+	SETTINGS extremes = 1
+	`
+	useSSL, err := strconv.ParseBool(clickhouse_tests.GetEnv("CLICKHOUSE_USE_SSL", "false"))
+	require.NoError(t, err)
+	conn, err := GetStdDSNConnection(clickhouse.Native, useSSL, nil)
+	require.NoError(t, err)
+	rows, err := conn.Query(query)
+	require.NoError(t, err)
+	read := func() (values [][2]uint64) {
+		for rows.Next() {
+			var n, c uint64
+			require.NoError(t, rows.Scan(&n, &c))
+			values = append(values, [2]uint64{n, c})
+		}
+		require.NoError(t, rows.Err())
+		return values
+	}
+	require.Len(t, read(), 100)
+	require.True(t, rows.NextResultSet())
+	assert.Equal(t, [][2]uint64{{0, 100}}, read())
+	require.True(t, rows.NextResultSet())
+	assert.Equal(t, [][2]uint64{{0, 1}, {99, 1}}, read())
+	assert.False(t, rows.NextResultSet())
+}
+
+// The Native output format of HTTP leaves out totals and extremes, the query only returns its rows
+func TestStdWithTotalsOverHTTP(t *testing.T) {
+	const query = `
+	SELECT
+		number AS n
+		, COUNT()
+	FROM (
+		SELECT number FROM system.numbers LIMIT 100
+	) GROUP BY n WITH TOTALS
+	SETTINGS extremes = 1
+	`
+	useSSL, err := strconv.ParseBool(clickhouse_tests.GetEnv("CLICKHOUSE_USE_SSL", "false"))
+	require.NoError(t, err)
+	conn, err := GetStdDSNConnection(clickhouse.HTTP, useSSL, nil)
+	require.NoError(t, err)
+	rows, err := conn.Query(query)
+	require.NoError(t, err)
+	var count int
+	for rows.Next() {
+		count++
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, 100, count)
+	assert.False(t, rows.NextResultSet())
+}
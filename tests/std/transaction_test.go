@@ -0,0 +1,121 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package std
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	clickhouse_tests "github.com/ClickHouse/clickhouse-go/v2/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdTransaction(t *testing.T) {
+	dsns := map[string]clickhouse.Protocol{"Native": clickhouse.Native, "Http": clickhouse.HTTP}
+	useSSL, err := strconv.ParseBool(clickhouse_tests.GetEnv("CLICKHOUSE_USE_SSL", "false"))
+	require.NoError(t, err)
+	for name, protocol := range dsns {
+		t.Run(fmt.Sprintf("%s Protocol", name), func(t *testing.T) {
+			ctx := context.Background()
+			conn, err := GetStdDSNConnection(protocol, useSSL, url.Values{"transactions": []string{"true"}})
+			require.NoError(t, err)
+			defer conn.Close()
+			if !CheckMinServerVersion(conn, 22, 7, 0) {
+				t.Skip(fmt.Errorf("unsupported clickhouse version"))
+				return
+			}
+			// the server must be started with allow_experimental_transactions
+			tx, err := conn.BeginTx(ctx, nil)
+			if err != nil && strings.Contains(err.Error(), "Transactions are not supported") {
+				t.Skip("transactions are not enabled on the server")
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, tx.Rollback())
+
+			const ddl = "CREATE TABLE test_std_transaction (id UInt64) Engine MergeTree() ORDER BY id"
+			conn.Exec("DROP TABLE IF EXISTS test_std_transaction")
+			defer func() {
+				conn.Exec("DROP TABLE IF EXISTS test_std_transaction")
+			}()
+			_, err = conn.Exec(ddl)
+			require.NoError(t, err)
+
+			// rolled back inserts are discarded
+			tx, err = conn.BeginTx(ctx, nil)
+			require.NoError(t, err)
+			_, err = tx.Exec("INSERT INTO test_std_transaction VALUES (1)")
+			require.NoError(t, err)
+			require.NoError(t, tx.Rollback())
+
+			tx, err = conn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSnapshot})
+			require.NoError(t, err)
+			_, err = tx.Exec("INSERT INTO test_std_transaction VALUES (2)")
+			require.NoError(t, err)
+			batch, err := tx.Prepare("INSERT INTO test_std_transaction")
+			require.NoError(t, err)
+			_, err = batch.Exec(uint64(3))
+			require.NoError(t, err)
+			require.NoError(t, tx.Commit())
+
+			var ids []uint64
+			rows, err := conn.Query("SELECT id FROM test_std_transaction ORDER BY id")
+			require.NoError(t, err)
+			for rows.Next() {
+				var id uint64
+				require.NoError(t, rows.Scan(&id))
+				ids = append(ids, id)
+			}
+			require.NoError(t, rows.Err())
+			assert.Equal(t, []uint64{2, 3}, ids)
+
+			_, err = conn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+			require.Error(t, err)
+			_, err = conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+			require.Error(t, err)
+		})
+	}
+}
+
+// Without transactions, BeginTx only groups the statements of a batch, so a read-only transaction is fine
+func TestStdReadOnlyTxWithoutTransactions(t *testing.T) {
+	dsns := map[string]clickhouse.Protocol{"Native": clickhouse.Native, "Http": clickhouse.HTTP}
+	useSSL, err := strconv.ParseBool(clickhouse_tests.GetEnv("CLICKHOUSE_USE_SSL", "false"))
+	require.NoError(t, err)
+	for name, protocol := range dsns {
+		t.Run(fmt.Sprintf("%s Protocol", name), func(t *testing.T) {
+			ctx := context.Background()
+			conn, err := GetStdDSNConnection(protocol, useSSL, nil)
+			require.NoError(t, err)
+			defer conn.Close()
+			tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+			require.NoError(t, err)
+			var n uint8
+			require.NoError(t, tx.QueryRowContext(ctx, "SELECT 1").Scan(&n))
+			assert.Equal(t, uint8(1), n)
+			require.NoError(t, tx.Commit())
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// spanRecorder is a TracerProvider that keeps the names and attributes of the ended spans
+type spanRecorder struct {
+	noop.TracerProvider
+	noop.Tracer
+	mutex sync.Mutex
+	ended map[string][]map[attribute.Key]attribute.Value
+}
+
+type recordedSpan struct {
+	noop.Span
+	recorder   *spanRecorder
+	name       string
+	attributes map[attribute.Key]attribute.Value
+}
+
+type spanRecorderProvider struct {
+	noop.TracerProvider
+	recorder *spanRecorder
+}
+
+func (p spanRecorderProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return p.recorder
+}
+
+func (r *spanRecorder) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+	span := &recordedSpan{recorder: r, name: name, attributes: make(map[attribute.Key]attribute.Value)}
+	span.SetAttributes(config.Attributes()...)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+func (r *recordedSpan) SetAttributes(attributes ...attribute.KeyValue) {
+	for _, a := range attributes {
+		r.attributes[a.Key] = a.Value
+	}
+}
+
+func (r *recordedSpan) End(...trace.SpanEndOption) {
+	r.recorder.mutex.Lock()
+	defer r.recorder.mutex.Unlock()
+	r.recorder.ended[r.name] = append(r.recorder.ended[r.name], r.attributes)
+}
+
+func (r *spanRecorder) spans(name string) []map[attribute.Key]attribute.Value {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.ended[name]
+}
+
+func TestTelemetrySpans(t *testing.T) {
+	env, err := GetTestEnvironment(testSet)
+	require.NoError(t, err)
+	recorder := &spanRecorder{ended: make(map[string][]map[attribute.Key]attribute.Value)}
+	opts := ClientOptionsFromEnv(env, clickhouse.Settings{}, false)
+	opts.TracerProvider = spanRecorderProvider{recorder: recorder}
+	conn, err := clickhouse.Open(&opts)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx := context.Background()
+	require.NoError(t, conn.Ping(ctx))
+	require.NoError(t, conn.Exec(ctx, "DROP TABLE IF EXISTS test_telemetry_spans"))
+	require.NoError(t, conn.Exec(ctx, "CREATE TABLE test_telemetry_spans (id UInt64) Engine MergeTree() ORDER BY id"))
+	defer conn.Exec(ctx, "DROP TABLE IF EXISTS test_telemetry_spans")
+
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_telemetry_spans")
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, batch.Append(uint64(i)))
+	}
+	require.NoError(t, batch.Send())
+
+	rows, err := conn.Query(clickhouse.Context(ctx, clickhouse.WithQueryID("test-telemetry-spans")), "SELECT id FROM test_telemetry_spans")
+	require.NoError(t, err)
+	var count int
+	for rows.Next() {
+		count++
+	}
+	require.NoError(t, rows.Close())
+	require.Equal(t, 10, count)
+	require.Error(t, conn.Exec(ctx, "SELECT * FROM test_telemetry_unknown_table"))
+
+	for _, name := range []string{"clickhouse.acquire", "clickhouse.dial", "clickhouse.handshake", "clickhouse.ping", "clickhouse.batch.send", "clickhouse.query"} {
+		assert.NotEmpty(t, recorder.spans(name), name)
+	}
+	sends := recorder.spans("clickhouse.batch.send")
+	require.Len(t, sends, 1)
+	assert.Equal(t, int64(10), sends[0]["clickhouse.written_rows"].AsInt64())
+
+	query, failed := querySpans(recorder, "test-telemetry-spans")
+	require.NotNil(t, query)
+	assert.NotEmpty(t, query["server.address"].AsString())
+	assert.Equal(t, int64(10), query["clickhouse.read_rows"].AsInt64())
+	require.NotNil(t, failed)
+	assert.Equal(t, "60", failed["db.response.status_code"].AsString())
+}
+
+// TestTelemetrySpansHttp traces the HTTP connections of database/sql, whose pool has no acquire spans
+func TestTelemetrySpansHttp(t *testing.T) {
+	env, err := GetTestEnvironment(testSet)
+	require.NoError(t, err)
+	recorder := &spanRecorder{ended: make(map[string][]map[attribute.Key]attribute.Value)}
+	opts := ClientOptionsFromEnv(env, clickhouse.Settings{}, true)
+	opts.TracerProvider = spanRecorderProvider{recorder: recorder}
+	conn := clickhouse.OpenDB(&opts)
+	defer conn.Close()
+
+	ctx := context.Background()
+	require.NoError(t, conn.PingContext(ctx))
+	var id uint64
+	require.NoError(t, conn.QueryRowContext(clickhouse.Context(ctx, clickhouse.WithQueryID("test-telemetry-spans-http")), "SELECT 42").Scan(&id))
+	_, err = conn.ExecContext(ctx, "SELECT * FROM test_telemetry_unknown_table")
+	require.Error(t, err)
+
+	for _, name := range []string{"clickhouse.dial", "clickhouse.handshake", "clickhouse.ping", "clickhouse.query"} {
+		assert.NotEmpty(t, recorder.spans(name), name)
+	}
+	query, failed := querySpans(recorder, "test-telemetry-spans-http")
+	require.NotNil(t, query)
+	assert.NotEmpty(t, query["server.address"].AsString())
+	require.NotNil(t, failed)
+	assert.Equal(t, "60", failed["db.response.status_code"].AsString())
+}
+
+// querySpans returns the attributes of the query span with queryID and of the query span that failed
+func querySpans(recorder *spanRecorder, queryID string) (query, failed map[attribute.Key]attribute.Value) {
+	for _, attributes := range recorder.spans("clickhouse.query") {
+		switch {
+		case attributes["clickhouse.query_id"].AsString() == queryID:
+			query = attributes
+		case attributes["db.response.status_code"].AsString() != "":
+			failed = attributes
+		}
+	}
+	return query, failed
+}
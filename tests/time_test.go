@@ -0,0 +1,111 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTime(t *testing.T) {
+	conn, err := GetNativeConnection(clickhouse.Settings{
+		"enable_time_time64_type": 1,
+	}, nil, &clickhouse.Compression{
+		Method: clickhouse.CompressionLZ4,
+	})
+	ctx := context.Background()
+	require.NoError(t, err)
+	if !CheckMinServerServerVersion(conn, 25, 6, 0) {
+		t.Skip("Time type is not supported by this ClickHouse version")
+		return
+	}
+	const ddl = `
+			CREATE TABLE test_time (
+				  id    UInt64
+				, col1  Time
+				, col2  Time64(3)
+				, col3  Nullable(Time64(9))
+				, col4  Array(Time)
+			) Engine MergeTree() ORDER BY id
+		`
+	defer func() {
+		conn.Exec(ctx, "DROP TABLE IF EXISTS test_time")
+	}()
+	require.NoError(t, conn.Exec(ctx, ddl))
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_time")
+	require.NoError(t, err)
+	d := 13*time.Hour + 14*time.Minute + 15*time.Second + 123456789*time.Nanosecond
+	require.NoError(t, batch.Append(uint64(1), d, clickhouse.NewTime(-d), &d, []time.Duration{time.Hour, -time.Minute}))
+	require.NoError(t, batch.Append(uint64(2), "100:00:00", "00:00:01.5", nil, []string{}))
+	require.NoError(t, batch.Send())
+
+	rows, err := conn.Query(ctx, "SELECT col1, col2, col3, col4, toString(col2) FROM test_time ORDER BY id")
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	var (
+		col1 time.Duration
+		col2 clickhouse.Time
+		col3 *time.Duration
+		col4 []time.Duration
+		text string
+	)
+	require.NoError(t, rows.Scan(&col1, &col2, &col3, &col4, &text))
+	assert.Equal(t, d.Truncate(time.Second), col1)
+	assert.Equal(t, -d.Truncate(time.Millisecond), col2.Duration())
+	require.NotNil(t, col3)
+	assert.Equal(t, d, *col3)
+	assert.Equal(t, []time.Duration{time.Hour, -time.Minute}, col4)
+	assert.Equal(t, "-13:14:15.123", text)
+
+	require.True(t, rows.Next())
+	var null sql.NullInt64
+	require.NoError(t, rows.Scan(&col1, &col2, &null, &col4, &text))
+	assert.Equal(t, 100*time.Hour, col1)
+	assert.Equal(t, 1500*time.Millisecond, col2.Duration())
+	assert.False(t, null.Valid)
+	assert.Empty(t, col4)
+	require.False(t, rows.Next())
+	require.NoError(t, rows.Err())
+}
+
+func TestTimeBind(t *testing.T) {
+	conn, err := GetNativeConnection(clickhouse.Settings{
+		"enable_time_time64_type": 1,
+	}, nil, nil)
+	ctx := context.Background()
+	require.NoError(t, err)
+	if !CheckMinServerServerVersion(conn, 25, 6, 0) {
+		t.Skip("Time type is not supported by this ClickHouse version")
+		return
+	}
+	d := 2*time.Hour + 30*time.Minute + 250*time.Millisecond
+	var (
+		positional time.Duration
+		named      clickhouse.Time
+	)
+	require.NoError(t, conn.QueryRow(ctx, "SELECT ?", clickhouse.NewTime(d)).Scan(&positional))
+	assert.Equal(t, d.Truncate(time.Second), positional)
+	require.NoError(t, conn.QueryRow(ctx, "SELECT @d", clickhouse.Named("d", clickhouse.NewTime(-d))).Scan(&named))
+	assert.Equal(t, -d.Truncate(time.Second), named.Duration())
+}
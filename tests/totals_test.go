@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -59,3 +60,43 @@ func TestWithTotals(t *testing.T) {
 	assert.Equal(t, uint64(0), n)
 	assert.Equal(t, uint64(100), totals)
 }
+
+func TestWithTotalsAndExtremes(t *testing.T) {
+	conn, err := GetNativeConnection(clickhouse.Settings{
+		"extremes": 1,
+	}, nil, &clickhouse.Compression{
+		Method: clickhouse.CompressionLZ4,
+	})
+	ctx := context.Background()
+	require.NoError(t, err)
+	const query = `
+		SELECT
+			number AS n
+			, COUNT()
+		FROM (
+			SELECT number FROM system.numbers LIMIT 100
+		) GROUP BY n WITH TOTALS
+		`
+	rows, err := conn.Query(ctx, query)
+	require.NoError(t, err)
+	var count int
+	for rows.Next() {
+		count++
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, 100, count)
+	var (
+		n, totals uint64
+	)
+	require.NoError(t, rows.Totals(&n, &totals))
+	assert.Equal(t, uint64(100), totals)
+	var (
+		minN, minCount uint64
+		maxN, maxCount uint64
+	)
+	require.NoError(t, rows.(driver.ExtremesRows).Extremes(&minN, &minCount, &maxN, &maxCount))
+	assert.Equal(t, uint64(0), minN)
+	assert.Equal(t, uint64(99), maxN)
+	assert.Equal(t, uint64(1), minCount)
+	assert.Equal(t, uint64(1), maxCount)
+}
@@ -0,0 +1,100 @@
+// Licensed to ClickHouse, Inc. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. ClickHouse, Inc. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/chcol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariant(t *testing.T) {
+	conn, err := GetNativeConnection(clickhouse.Settings{
+		"allow_experimental_variant_type": true,
+	}, nil, &clickhouse.Compression{
+		Method: clickhouse.CompressionLZ4,
+	})
+	ctx := context.Background()
+	require.NoError(t, err)
+	if !CheckMinServerServerVersion(conn, 24, 4, 0) {
+		t.Skip("Variant type is not supported by this ClickHouse version")
+		return
+	}
+	const ddl = `
+			CREATE TABLE test_variant (
+				  c Variant(Bool, Int64, String, Array(String), Map(String, Int64))
+			) Engine MergeTree() ORDER BY tuple()
+		`
+	defer func() {
+		conn.Exec(ctx, "DROP TABLE IF EXISTS test_variant")
+	}()
+	require.NoError(t, conn.Exec(ctx, ddl))
+	batch, err := conn.PrepareBatch(ctx, "INSERT INTO test_variant")
+	require.NoError(t, err)
+	require.NoError(t, batch.Append(true))
+	require.NoError(t, batch.Append(int64(42)))
+	require.NoError(t, batch.Append("test"))
+	require.NoError(t, batch.Append([]string{"a", "b"}))
+	require.NoError(t, batch.Append(map[string]int64{"key": 1}))
+	require.NoError(t, batch.Append(nil))
+	require.NoError(t, batch.Append(clickhouse.NewVariantWithType(int64(7), "Int64")))
+	require.NoError(t, batch.Send())
+
+	rows, err := conn.Query(ctx, "SELECT c FROM test_variant")
+	require.NoError(t, err)
+	var values []chcol.Variant
+	for rows.Next() {
+		var v chcol.Variant
+		require.NoError(t, rows.Scan(&v))
+		values = append(values, v)
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, values, 7)
+	assert.Equal(t, true, values[0].Any())
+	assert.Equal(t, "Bool", values[0].Type())
+	assert.Equal(t, int64(42), values[1].Any())
+	assert.Equal(t, "test", values[2].Any())
+	assert.Equal(t, []string{"a", "b"}, values[3].Any())
+	assert.Equal(t, map[string]int64{"key": 1}, values[4].Any())
+	assert.True(t, values[5].Nil())
+	assert.Equal(t, int64(7), values[6].Any())
+}
+
+func TestVariantScanMemberType(t *testing.T) {
+	conn, err := GetNativeConnection(clickhouse.Settings{
+		"allow_experimental_variant_type": true,
+	}, nil, nil)
+	ctx := context.Background()
+	require.NoError(t, err)
+	if !CheckMinServerServerVersion(conn, 24, 4, 0) {
+		t.Skip("Variant type is not supported by this ClickHouse version")
+		return
+	}
+	var (
+		s *string
+		i int64
+	)
+	require.NoError(t, conn.QueryRow(ctx, "SELECT 'a'::Variant(String, Int64), 5::Variant(String, Int64)").Scan(&s, &i))
+	require.NotNil(t, s)
+	assert.Equal(t, "a", *s)
+	assert.Equal(t, int64(5), i)
+}